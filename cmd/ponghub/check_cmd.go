@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/wcy-dt/ponghub/internal/checker"
+	"github.com/wcy-dt/ponghub/internal/configure"
+	"github.com/wcy-dt/ponghub/internal/types/types/chk_result"
+	"github.com/wcy-dt/ponghub/internal/types/types/default_config"
+)
+
+// runCheck checks the services and prints the results without writing the log or sending notifications.
+// It returns 0 if all services are available or under maintenance and 1 otherwise. When a single service
+// is checked, the services it depends on are not checked and its failures are never reported as impacted.
+func runCheck(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("check", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	paths := registerPathFlags(flags)
+	serviceName := flags.String("service", "", "only check the service with this name")
+	jsonOutput := flags.Bool("json", false, "print the results as JSON")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	paths.apply()
+
+	cfg, err := configure.ReadConfigs(default_config.GetConfigPath())
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, "Error loading config at", default_config.GetConfigPath(), ":", err)
+		return exitError
+	}
+
+	if *serviceName != "" {
+		selected := -1
+		for i := range cfg.Services {
+			if cfg.Services[i].Name == *serviceName {
+				selected = i
+			}
+		}
+		if selected < 0 {
+			_, _ = fmt.Fprintf(stderr, "Unknown service %q\n", *serviceName)
+			return exitUsage
+		}
+		cfg.Services = cfg.Services[selected : selected+1]
+	}
+
+	checkResult := checker.CheckServices(cfg)
+
+	if *jsonOutput {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(checkResult); err != nil {
+			_, _ = fmt.Fprintln(stderr, "Error encoding the results:", err)
+			return exitError
+		}
+	} else {
+		for _, service := range checkResult {
+			available := 0
+			for _, endpoint := range service.Endpoints {
+				if endpoint.Status == chk_result.ALL {
+					available++
+				}
+			}
+			status := service.Status.String()
+			if service.ImpactedBy != "" {
+				status += " by " + service.ImpactedBy
+			}
+			_, _ = fmt.Fprintf(stdout, "%s: %s (%d/%d endpoints available)\n", service.Name, status, available, len(service.Endpoints))
+
+			for _, endpoint := range service.Endpoints {
+				_, _ = fmt.Fprintf(stdout, "  %-11s %-4s %s  %d  %dms\n", endpoint.Status, endpoint.Method, endpoint.URL, endpoint.StatusCode, endpoint.ResponseTime.Milliseconds())
+				if endpoint.Status != chk_result.ALL && len(endpoint.FailureDetails) > 0 {
+					_, _ = fmt.Fprintf(stdout, "              %s\n", endpoint.FailureDetails[len(endpoint.FailureDetails)-1])
+				}
+			}
+		}
+	}
+
+	for _, service := range checkResult {
+		if service.Status == chk_result.PART || service.Status == chk_result.NONE || service.Status == chk_result.IMPACTED {
+			return exitFailure
+		}
+	}
+	return exitOK
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunCheck(t *testing.T) {
+	restoreDefaultPaths(t)
+	okServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer okServer.Close()
+	failingServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failingServer.Close()
+	secureServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Team") != "payments" {
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer secureServer.Close()
+
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	config := `
+max_retry_times: 1
+defaults:
+  headers:
+    X-Team: "payments"
+services:
+  - name: "web"
+    endpoints:
+      - url: "` + okServer.URL + `"
+  - name: "api"
+    endpoints:
+      - url: "` + okServer.URL + `/health"
+      - url: "` + failingServer.URL + `"
+  - name: "secure"
+    defaults:
+      skip_tls_verify: true
+    endpoints:
+      - url: "` + secureServer.URL + `"
+  - name: "billing"
+    depends_on: ["orders"]
+    endpoints:
+      - url: "` + failingServer.URL + `/billing"
+  - name: "orders"
+    depends_on: ["auth", "web"]
+    endpoints:
+      - url: "` + okServer.URL + `/orders"
+      - url: "` + failingServer.URL + `/orders"
+  - name: "auth"
+    endpoints:
+      - url: "` + failingServer.URL + `/auth"
+`
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		code     int
+		expected string
+	}{
+		{name: "Available service", args: []string{"--config", configPath, "--service", "web"}, code: 0, expected: "web: all (1/1 endpoints available)"},
+		{name: "Inherited defaults", args: []string{"--config", configPath, "--service", "secure"}, code: 0, expected: "secure: all (1/1 endpoints available)"},
+		{name: "Partially available service", args: []string{"--config", configPath}, code: 1, expected: "api: part (1/2 endpoints available)"},
+		{name: "Impacted service", args: []string{"--config", configPath}, code: 1, expected: "billing: impacted by auth (0/1 endpoints available)"},
+		{name: "Impacted endpoint", args: []string{"--config", configPath}, code: 1, expected: "impacted    GET  " + failingServer.URL + "/orders"},
+		{name: "Root service", args: []string{"--config", configPath}, code: 1, expected: "auth: none (0/1 endpoints available)"},
+		{name: "Single impacted service", args: []string{"--config", configPath, "--service", "billing"}, code: 1, expected: "billing: none (0/1 endpoints available)"},
+		{name: "JSON output", args: []string{"--config", configPath, "--service", "web", "--json"}, code: 0, expected: `"status": "all"`},
+		{name: "Unknown service", args: []string{"--config", configPath, "--service", "db"}, code: 2, expected: `Unknown service "db"`},
+		{name: "Missing config", args: []string{"--config", filepath.Join(dir, "missing.yaml")}, code: 3, expected: "Error loading config"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := runCheck(tt.args, &stdout, &stderr)
+			if code != tt.code {
+				t.Errorf("Expected exit code %d, got %d", tt.code, code)
+			}
+			if output := stdout.String() + stderr.String(); !strings.Contains(output, tt.expected) {
+				t.Errorf("Expected the output to contain %q, got:\n%s", tt.expected, output)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/checker"
+	"github.com/wcy-dt/ponghub/internal/common"
+	"github.com/wcy-dt/ponghub/internal/incident"
+	"github.com/wcy-dt/ponghub/internal/logger"
+	"github.com/wcy-dt/ponghub/internal/notifier"
+	"github.com/wcy-dt/ponghub/internal/reporter"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/types/default_config"
+)
+
+// runChecksWith checks all services of the configuration, writes the log, the incidents and the report,
+// and sends the notifications and the digest. The notifications are sent even if the log or the report
+// cannot be written.
+func runChecksWith(cfg *configure.Configure) error {
+	if err := createDataDirs(); err != nil {
+		return fmt.Errorf("error creating the data directory: %w", err)
+	}
+
+	// check services based on the configuration
+	checkResult := checker.CheckServices(cfg)
+
+	// notify the result, the previous log is kept to detect recovered endpoints
+	notifier.WriteNotifications(checkResult, cfg.CertNotifyDays)
+	previousLog, err := common.ReadLogs(default_config.GetLogPath())
+	if err != nil {
+		log.Println("Error loading previous logs, recovered endpoints will not be reported:", err)
+	}
+
+	// the log and the report are written before the notifications so that the report can be attached,
+	// their errors are returned after the notifications are sent so that they do not suppress alerts
+	var errs []error
+	logResult, err := logger.GetLog(checkResult, cfg.MaxLogDays, default_config.GetLogPath())
+	if err != nil {
+		errs = append(errs, fmt.Errorf("error outputting checkResult: %w", err))
+	} else if err := logger.WriteLog(logResult, default_config.GetLogPath()); err != nil {
+		errs = append(errs, fmt.Errorf("error writing logs to %s: %w", default_config.GetLogPath(), err))
+	} else {
+		log.Println("Logs written to", default_config.GetLogPath())
+	}
+
+	// derive the incidents from the log
+	incidents, err := incident.ReadIncidents(default_config.GetIncidentHistoryPath())
+	if err != nil {
+		log.Println("Error loading incidents, the incident history starts over:", err)
+	}
+	if logResult != nil {
+		incidents = incident.Update(incidents, logResult, checkResult, time.Now())
+		if err := incident.WriteIncidents(incidents, default_config.GetIncidentHistoryPath()); err != nil {
+			log.Println("Error writing incidents:", err)
+		}
+	}
+	incidents = incident.LoadNotes(incidents, default_config.GetIncidentNotesDir())
+
+	// generate the report based on the checkResult
+	deliveryReport, err := notifier.ReadDeliveryReport(default_config.GetDeliveryReportPath())
+	if err != nil {
+		log.Println("Error loading the last delivery report:", err)
+	}
+	reportWritten := false
+	reportResult, err := reporter.GetReport(checkResult, default_config.GetLogPath(), cfg)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("error generating report data: %w", err))
+	} else if err := reporter.WriteReport(reportResult, default_config.GetReportPath(), cfg.DisplayNum, deliveryReport, incidents); err != nil {
+		errs = append(errs, fmt.Errorf("error generating report: %w", err))
+	} else {
+		reportWritten = true
+		log.Println("Report generated at", default_config.GetReportPath())
+		if err := reporter.WriteStatus(reportResult, default_config.GetStatusPath()); err != nil {
+			log.Println("Error writing status:", err)
+		}
+	}
+
+	// send notifications after the report is generated so that it can be attached
+	deliveryReport = notifier.SendNotifications(checkResult, cfg.CertNotifyDays, cfg.Notifications, previousLog, reportWritten)
+
+	// send the daily or weekly digest if it is due, its delivery is reported with the notifications
+	if logResult != nil {
+		deliveryReport = notifier.AddDigestReport(deliveryReport, notifier.SendDigest(logResult, checkResult, cfg.Notifications))
+	}
+	if deliveryReport != nil {
+		if err := notifier.WriteDeliveryReport(deliveryReport, default_config.GetDeliveryReportPath()); err != nil {
+			log.Println("Error writing delivery report:", err)
+		}
+		// regenerate the report so that delivery failures are visible on the status page
+		if reportWritten {
+			if err := reporter.WriteReport(reportResult, default_config.GetReportPath(), cfg.DisplayNum, deliveryReport, incidents); err != nil {
+				log.Println("Error regenerating report:", err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
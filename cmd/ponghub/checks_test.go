@@ -0,0 +1,70 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/wcy-dt/ponghub/internal/configure"
+	"github.com/wcy-dt/ponghub/internal/types/types/default_config"
+)
+
+func TestRunChecksWith_NotifiesOnLogError(t *testing.T) {
+	downServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer downServer.Close()
+	var notified atomic.Int32
+	webhookServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		notified.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer webhookServer.Close()
+
+	restoreDefaultPaths(t)
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	config := `
+max_retry_times: 1
+services:
+  - name: "api"
+    endpoints:
+      - url: "` + downServer.URL + `"
+notifications:
+  enabled: true
+  channels:
+    - name: ops
+      type: webhook
+      webhook:
+        url: "` + webhookServer.URL + `"
+`
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	cfg, err := configure.ReadConfigs(configPath)
+	if err != nil {
+		t.Fatalf("ReadConfigs failed: %v", err)
+	}
+
+	// a corrupt log fails the log and the report, but the outage is still notified
+	default_config.SetDataDir(filepath.Join(dir, "data"))
+	if err := os.MkdirAll(default_config.GetDataDir(), 0755); err != nil {
+		t.Fatalf("Failed to create data directory: %v", err)
+	}
+	if err := os.WriteFile(default_config.GetLogPath(), []byte("{corrupt"), 0644); err != nil {
+		t.Fatalf("Failed to write log: %v", err)
+	}
+
+	if err := runChecksWith(cfg); err == nil {
+		t.Error("Expected the log error to be returned")
+	}
+	if notified.Load() == 0 {
+		t.Error("Expected the outage to be notified despite the log error")
+	}
+	if _, err := os.Stat(default_config.GetReportPath()); !os.IsNotExist(err) {
+		t.Errorf("Expected no report to be written from a corrupt log, got %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"cmp"
+	"flag"
+	"os"
+	"path/filepath"
+
+	"github.com/wcy-dt/ponghub/internal/types/types/default_config"
+)
+
+const (
+	// configEnv, dataDirEnv, templateEnv, outputEnv and incidentsDirEnv are the environment variables
+	// that set the paths when the corresponding flags are not given
+	configEnv       = "PONGHUB_CONFIG"
+	dataDirEnv      = "PONGHUB_DATA_DIR"
+	templateEnv     = "PONGHUB_TEMPLATE"
+	outputEnv       = "PONGHUB_OUTPUT"
+	incidentsDirEnv = "PONGHUB_INCIDENTS_DIR"
+)
+
+// pathFlags holds the paths of the files read and written by PongHub
+type pathFlags struct {
+	config       *string
+	dataDir      *string
+	template     *string
+	output       *string
+	incidentsDir *string
+}
+
+// registerPathFlags defines the path flags on the flag set, their defaults are taken from the
+// environment variables or the default configuration
+func registerPathFlags(flags *flag.FlagSet) *pathFlags {
+	return &pathFlags{
+		config:       flags.String("config", cmp.Or(os.Getenv(configEnv), default_config.GetDefaultConfigPath()), "configuration file, directory or glob pattern, or "+configEnv),
+		dataDir:      flags.String("data-dir", cmp.Or(os.Getenv(dataDirEnv), default_config.GetDefaultDataDir()), "directory of the log and the other data files, or "+dataDirEnv),
+		template:     flags.String("template", cmp.Or(os.Getenv(templateEnv), default_config.GetDefaultTemplatePath()), "path to the HTML template of the report, or "+templateEnv),
+		output:       flags.String("output", os.Getenv(outputEnv), "path to the HTML report, index.html in the data directory if empty, or "+outputEnv),
+		incidentsDir: flags.String("incidents-dir", cmp.Or(os.Getenv(incidentsDirEnv), default_config.GetDefaultIncidentNotesDir()), "directory of the markdown notes of incidents, or "+incidentsDirEnv),
+	}
+}
+
+// apply sets the parsed paths in the default configuration
+func (p *pathFlags) apply() {
+	default_config.SetConfigPath(*p.config)
+	default_config.SetDataDir(*p.dataDir)
+	default_config.SetTemplatePath(*p.template)
+	default_config.SetReportPath(*p.output)
+	default_config.SetIncidentNotesDir(*p.incidentsDir)
+}
+
+// createDataDirs creates the data directory and the directory of the report if they do not exist
+func createDataDirs() error {
+	if err := os.MkdirAll(default_config.GetDataDir(), 0755); err != nil {
+		return err
+	}
+	return os.MkdirAll(filepath.Dir(default_config.GetReportPath()), 0755)
+}
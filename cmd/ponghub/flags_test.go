@@ -0,0 +1,99 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/wcy-dt/ponghub/internal/types/types/default_config"
+)
+
+// restoreDefaultPaths restores the default paths after the test, as commands set them from their flags
+func restoreDefaultPaths(t *testing.T) {
+	t.Cleanup(func() {
+		default_config.SetConfigPath("")
+		default_config.SetDataDir("")
+		default_config.SetTemplatePath("")
+		default_config.SetReportPath("")
+		default_config.SetIncidentNotesDir("")
+	})
+}
+
+func TestPathFlags(t *testing.T) {
+	restoreDefaultPaths(t)
+
+	tests := []struct {
+		name             string
+		env              map[string]string
+		args             []string
+		expectedConfig   string
+		expectedLog      string
+		expectedTemplate string
+		expectedReport   string
+		expectedNotes    string
+	}{
+		{
+			name:             "Defaults",
+			expectedConfig:   "config.yaml",
+			expectedLog:      filepath.Join("data", "ponghub_log.json"),
+			expectedTemplate: "templates/report.html",
+			expectedReport:   filepath.Join("data", "index.html"),
+			expectedNotes:    "incidents",
+		},
+		{
+			name:             "Environment variables",
+			env:              map[string]string{configEnv: "staging.yaml", dataDirEnv: "staging", outputEnv: "public/staging.html", incidentsDirEnv: "notes/staging"},
+			expectedConfig:   "staging.yaml",
+			expectedLog:      filepath.Join("staging", "ponghub_log.json"),
+			expectedTemplate: "templates/report.html",
+			expectedReport:   "public/staging.html",
+			expectedNotes:    "notes/staging",
+		},
+		{
+			name:             "Flags override environment variables",
+			env:              map[string]string{configEnv: "staging.yaml", dataDirEnv: "staging"},
+			args:             []string{"--config", "prod.yaml", "--data-dir", "prod", "--template", "custom.html", "--incidents-dir", "notes/prod"},
+			expectedConfig:   "prod.yaml",
+			expectedLog:      filepath.Join("prod", "ponghub_log.json"),
+			expectedTemplate: "custom.html",
+			expectedReport:   filepath.Join("prod", "index.html"),
+			expectedNotes:    "notes/prod",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{configEnv, dataDirEnv, templateEnv, outputEnv, incidentsDirEnv} {
+				t.Setenv(name, tt.env[name])
+			}
+
+			flags := flag.NewFlagSet("ponghub", flag.ContinueOnError)
+			flags.SetOutput(io.Discard)
+			paths := registerPathFlags(flags)
+			if err := flags.Parse(tt.args); err != nil {
+				t.Fatalf("Failed to parse flags: %v", err)
+			}
+			paths.apply()
+
+			if got := default_config.GetConfigPath(); got != tt.expectedConfig {
+				t.Errorf("Expected config path %q, got %q", tt.expectedConfig, got)
+			}
+			if got := default_config.GetLogPath(); got != tt.expectedLog {
+				t.Errorf("Expected log path %q, got %q", tt.expectedLog, got)
+			}
+			if got, expected := default_config.GetAckPath(), filepath.Join(filepath.Dir(tt.expectedLog), "ack.txt"); got != expected {
+				t.Errorf("Expected ack path %q, got %q", expected, got)
+			}
+			if got := default_config.GetTemplatePath(); got != tt.expectedTemplate {
+				t.Errorf("Expected template path %q, got %q", tt.expectedTemplate, got)
+			}
+			if got := default_config.GetReportPath(); got != tt.expectedReport {
+				t.Errorf("Expected report path %q, got %q", tt.expectedReport, got)
+			}
+			if got := default_config.GetIncidentNotesDir(); got != tt.expectedNotes {
+				t.Errorf("Expected incident notes directory %q, got %q", tt.expectedNotes, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/wcy-dt/ponghub/internal/common"
+	"github.com/wcy-dt/ponghub/internal/types/types/default_config"
+)
+
+// runHistory prints the logged history of a service, or of one of its endpoints.
+// It returns 1 if the service or endpoint is not in the log.
+func runHistory(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("history", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	paths := registerPathFlags(flags)
+	serviceName := flags.String("service", "", "name of the service (required)")
+	endpointURL := flags.String("endpoint", "", "URL of the endpoint as written in the configuration")
+	limit := flags.Int("limit", 20, "number of most recent entries to print, 0 for all")
+	jsonOutput := flags.Bool("json", false, "print the entries as JSON")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	paths.apply()
+
+	if *serviceName == "" {
+		_, _ = fmt.Fprintln(stderr, "The --service flag is required")
+		return exitUsage
+	}
+
+	logResult, err := common.ReadLogs(default_config.GetLogPath())
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, "Error loading logs at", default_config.GetLogPath(), ":", err)
+		return exitError
+	}
+	serviceLog, exists := logResult[*serviceName]
+	if !exists {
+		_, _ = fmt.Fprintf(stderr, "No log found for service %q\n", *serviceName)
+		return exitFailure
+	}
+
+	var endpointURLs []string
+	for url := range serviceLog.Endpoints {
+		endpointURLs = append(endpointURLs, url)
+	}
+	sort.Strings(endpointURLs)
+
+	history := serviceLog.ServiceHistory
+	if *endpointURL != "" {
+		if history, exists = serviceLog.Endpoints[*endpointURL]; !exists {
+			_, _ = fmt.Fprintf(stderr, "No log found for endpoint %q of service %q, logged endpoints:\n", *endpointURL, *serviceName)
+			for _, url := range endpointURLs {
+				_, _ = fmt.Fprintln(stderr, "  "+url)
+			}
+			return exitFailure
+		}
+	}
+	if *limit > 0 && len(history) > *limit {
+		history = history[len(history)-*limit:]
+	}
+
+	if *jsonOutput {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(history); err != nil {
+			_, _ = fmt.Fprintln(stderr, "Error encoding the history:", err)
+			return exitError
+		}
+		return exitOK
+	}
+
+	for _, entry := range history {
+		if entry.ResponseTime > 0 {
+			_, _ = fmt.Fprintf(stdout, "%s  %-11s %dms\n", entry.Time, entry.Status, entry.ResponseTime)
+		} else {
+			_, _ = fmt.Fprintf(stdout, "%s  %s\n", entry.Time, entry.Status)
+		}
+	}
+	if *endpointURL == "" && len(endpointURLs) > 0 {
+		_, _ = fmt.Fprintln(stdout, "\nEndpoints:")
+		for _, url := range endpointURLs {
+			_, _ = fmt.Fprintln(stdout, "  "+url)
+		}
+	}
+	return exitOK
+}
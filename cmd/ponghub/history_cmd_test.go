@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunHistory(t *testing.T) {
+	restoreDefaultPaths(t)
+	dataDir := t.TempDir()
+	logContent := `{
+  "api": {
+    "service_history": [
+      {"time": "2025-03-05T10:00:00Z", "status": "all"},
+      {"time": "2025-03-05T10:30:00Z", "status": "none"},
+      {"time": "2025-03-05T11:00:00Z", "status": "all"}
+    ],
+    "endpoints": {
+      "https://api.example.com": [
+        {"time": "2025-03-05T10:00:00Z", "status": "all", "response_time": 120},
+        {"time": "2025-03-05T10:30:00Z", "status": "none"}
+      ]
+    }
+  }
+}`
+	if err := os.WriteFile(filepath.Join(dataDir, "ponghub_log.json"), []byte(logContent), 0644); err != nil {
+		t.Fatalf("Failed to write log: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		code     int
+		expected string
+	}{
+		{name: "Service", args: []string{"--service", "api", "--limit", "2"}, code: 0, expected: "2025-03-05T10:30:00Z  none\n2025-03-05T11:00:00Z  all\n\nEndpoints:\n  https://api.example.com\n"},
+		{name: "Endpoint", args: []string{"--service", "api", "--endpoint", "https://api.example.com"}, code: 0, expected: "2025-03-05T10:00:00Z  all         120ms"},
+		{name: "JSON output", args: []string{"--service", "api", "--limit", "1", "--json"}, code: 0, expected: `"time": "2025-03-05T11:00:00Z"`},
+		{name: "Unknown service", args: []string{"--service", "web"}, code: 1, expected: `No log found for service "web"`},
+		{name: "Unknown endpoint", args: []string{"--service", "api", "--endpoint", "https://api.example.com/health"}, code: 1, expected: "logged endpoints:\n  https://api.example.com"},
+		{name: "Missing service", args: []string{}, code: 2, expected: "The --service flag is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := runHistory(append([]string{"--data-dir", dataDir}, tt.args...), &stdout, &stderr)
+			if code != tt.code {
+				t.Errorf("Expected exit code %d, got %d", tt.code, code)
+			}
+			if output := stdout.String() + stderr.String(); !strings.Contains(output, tt.expected) {
+				t.Errorf("Expected the output to contain %q, got:\n%s", tt.expected, output)
+			}
+		})
+	}
+}
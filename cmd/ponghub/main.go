@@ -1,49 +1,113 @@
 package main
 
 import (
+	"flag"
+	"fmt"
+	"io"
 	"log"
+	"os"
+	"strings"
 
-	"github.com/wcy-dt/ponghub/internal/checker"
 	"github.com/wcy-dt/ponghub/internal/configure"
-	"github.com/wcy-dt/ponghub/internal/logger"
-	"github.com/wcy-dt/ponghub/internal/notifier"
-	"github.com/wcy-dt/ponghub/internal/reporter"
 	"github.com/wcy-dt/ponghub/internal/types/types/default_config"
 )
 
+const (
+	// exitOK is returned when a command succeeds
+	exitOK = 0
+
+	// exitFailure is returned when checks fail, the configuration is invalid or a notification failed
+	exitFailure = 1
+
+	// exitUsage is returned for invalid arguments
+	exitUsage = 2
+
+	// exitError is returned when a command cannot run, e.g. because the configuration cannot be loaded
+	exitError = 3
+)
+
+// commands maps the name of each subcommand to the function running it
+var commands = map[string]func(args []string, stdout, stderr io.Writer) int{
+	"check":       runCheck,
+	"validate":    runValidate,
+	"report":      runReport,
+	"serve":       runServe,
+	"history":     runHistory,
+	"schema":      runSchema,
+	"secrets":     runSecrets,
+	"notify-test": runNotifyTest,
+}
+
+// usage describes the commands and exit codes
+const usage = `Usage: ponghub [command] [flags]
+
+Without a command, all services are checked and the log, report and notifications are written.
+
+Commands:
+  check        check the services and print the results
+  validate     validate the configuration
+  report       generate the report from the log without checking the services
+  serve        check the services periodically and serve the report over HTTP
+  history      print the log of a service or an endpoint
+  schema       print the JSON Schema of the configuration file
+  secrets      generate a key, encrypt or decrypt the secrets file
+  notify-test  send a test notification
+  help         print this help
+
+Exit codes: 0 success, 1 failed checks, invalid configuration or failed notifications,
+2 invalid arguments, 3 errors such as a missing configuration file.
+
+Run "ponghub <command> -h" for the flags of a command.
+`
+
 func main() {
-	// load the default configuration
-	cfg, err := configure.ReadConfigs(default_config.GetConfigPath())
-	if err != nil {
-		log.Fatalln("Error loading config at", default_config.GetConfigPath(), ":", err)
-	}
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
 
-	// check services based on the configuration
-	checkResult := checker.CheckServices(cfg)
+// run runs the subcommand named by the first argument, or all checks if no subcommand is given
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return runAll(args, stderr)
+	}
+	if args[0] == "help" {
+		_, _ = fmt.Fprint(stdout, usage)
+		return exitOK
+	}
 
-	// notify the result
-	notifier.WriteNotifications(checkResult, cfg.CertNotifyDays)
-	notifier.SendNotifications(checkResult, cfg.CertNotifyDays, cfg.Notifications)
+	command, exists := commands[args[0]]
+	if !exists {
+		_, _ = fmt.Fprintf(stderr, "Unknown command %q\n\n%s", args[0], usage)
+		return exitUsage
+	}
+	return command(args[1:], stdout, stderr)
+}
 
-	// get and write log results
-	logResult, err := logger.GetLog(checkResult, cfg.MaxLogDays, default_config.GetLogPath())
-	if err != nil {
-		log.Fatalln("Error outputting checkResult:", err)
+// runAll checks all services and writes the log, report and notifications
+func runAll(args []string, stderr io.Writer) int {
+	flags := flag.NewFlagSet("ponghub", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		_, _ = fmt.Fprint(stderr, usage+"\nFlags:\n")
+		flags.PrintDefaults()
 	}
-	if err := logger.WriteLog(logResult, default_config.GetLogPath()); err != nil {
-		log.Fatalln("Error writing logs to", default_config.GetLogPath(), ":", err)
-	} else {
-		log.Println("Logs written to", default_config.GetLogPath())
+	paths := registerPathFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
 	}
+	paths.apply()
 
-	// generate the report based on the checkResult
-	reportResult, err := reporter.GetReport(checkResult, default_config.GetLogPath(), cfg)
-	if err != nil {
-		log.Fatalln("Error generating report data:", err)
+	if err := runChecks(); err != nil {
+		log.Println(err)
+		return exitError
 	}
-	if err := reporter.WriteReport(reportResult, default_config.GetReportPath(), cfg.DisplayNum); err != nil {
-		log.Fatalln("Error generating report:", err)
-	} else {
-		log.Println("Report generated at", default_config.GetReportPath())
+	return exitOK
+}
+
+// runChecks loads the configuration and runs the checks with it
+func runChecks() error {
+	cfg, err := configure.ReadConfigs(default_config.GetConfigPath())
+	if err != nil {
+		return fmt.Errorf("error loading config at %s: %w", default_config.GetConfigPath(), err)
 	}
+	return runChecksWith(cfg)
 }
@@ -1,79 +1,87 @@
 package main
 
 import (
+	"bytes"
 	"io"
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
-	"github.com/wcy-dt/ponghub/internal/checker"
 	"github.com/wcy-dt/ponghub/internal/configure"
-	"github.com/wcy-dt/ponghub/internal/logger"
-	"github.com/wcy-dt/ponghub/internal/notifier"
-	"github.com/wcy-dt/ponghub/internal/reporter"
 	"github.com/wcy-dt/ponghub/internal/types/types/default_config"
 )
 
 // TestMain_append tests the main functionality when appending to an existing log file.
 func TestMain_append(t *testing.T) {
-	runMainFunctionality(true)
+	runMainFunctionality(t, true)
 }
 
 // TestMain_new tests the main functionality when creating a new log file.
 func TestMain_new(t *testing.T) {
-	runMainFunctionality(false)
+	runMainFunctionality(t, false)
 }
 
-// runMainFunctionality runs the main functionality for testing purposes.
-// If copyExistingLog is true, it copies the existing log file to a temporary location.
-func runMainFunctionality(copyExistingLog bool) {
+// TestRun tests the dispatch of the subcommands.
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		code     int
+		expected string
+	}{
+		{name: "Help", args: []string{"help"}, code: 0, expected: "Commands:"},
+		{name: "Unknown command", args: []string{"deploy"}, code: 2, expected: `Unknown command "deploy"`},
+		{name: "Unknown flag", args: []string{"--verbose"}, code: 2, expected: "flag provided but not defined"},
+		{name: "Subcommand", args: []string{"history"}, code: 2, expected: "The --service flag is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(tt.args, &stdout, &stderr)
+			if code != tt.code {
+				t.Errorf("Expected exit code %d, got %d", tt.code, code)
+			}
+			if output := stdout.String() + stderr.String(); !strings.Contains(output, tt.expected) {
+				t.Errorf("Expected the output to contain %q, got:\n%s", tt.expected, output)
+			}
+		})
+	}
+}
+
+// runMainFunctionality runs the checks of the default configuration through runChecksWith with a temporary
+// data directory, which writes the log, the incidents and the report and sends the notifications.
+// If copyExistingLog is true, it copies the existing log file to the data directory first.
+func runMainFunctionality(t *testing.T, copyExistingLog bool) {
 	// load the default configuration
 	cfg, err := configure.ReadConfigs(default_config.GetConfigPath())
 	if err != nil {
-		log.Fatalln("Error loading config at", default_config.GetConfigPath(), ":", err)
+		t.Fatalf("Error loading config at %s: %v", default_config.GetConfigPath(), err)
 	}
 
-	// copy log file to a temporary location for testing (only if copyExistingLog is true)
+	existingLogPath := default_config.GetLogPath()
+	restoreDefaultPaths(t)
+	default_config.SetDataDir(t.TempDir())
 	if copyExistingLog {
-		logPath := default_config.GetLogPath()
-		if err := copyLogFile(logPath, tmpLogPath); err != nil {
-			log.Fatalln("Error copying log file:", err)
+		if err := copyLogFile(existingLogPath, default_config.GetLogPath()); err != nil {
+			t.Fatalf("Error copying log file: %v", err)
 		}
 	}
 
-	// check services based on the configuration
-	checkResult := checker.CheckServices(cfg)
-
-	// notify the result
-	notifier.WriteNotifications(checkResult, cfg.CertNotifyDays)
-	notifier.SendNotifications(checkResult, cfg.CertNotifyDays, cfg.Notifications)
-
-	// get and write log results
-	logResult, err := logger.GetLog(checkResult, cfg.MaxLogDays, tmpLogPath)
-	if err != nil {
-		log.Fatalln("Error outputting checkResult:", err)
-	}
-	if err := logger.WriteLog(logResult, tmpLogPath); err != nil {
-		log.Fatalln("Error writing logs to", tmpLogPath, ":", err)
-	} else {
-		log.Println("Logs written to", tmpLogPath)
-	}
-
-	// generate the report based on the checkResult
-	reportResult, err := reporter.GetReport(checkResult, tmpLogPath, cfg)
-	if err != nil {
-		log.Fatalln("Error generating report data:", err)
+	if err := runChecksWith(cfg); err != nil {
+		t.Fatalf("runChecksWith failed: %v", err)
 	}
-	if err := reporter.WriteReport(reportResult, default_config.GetReportPath(), cfg.DisplayNum); err != nil {
-		log.Fatalln("Error generating report:", err)
-	} else {
-		log.Println("Report generated at", default_config.GetReportPath())
-	}
-
-	// Remove the temporary log file after tests
-	if err := os.Remove(tmpLogPath); err != nil {
-		log.Println("Error removing temporary log file:", err)
+	for _, path := range []string{
+		default_config.GetLogPath(),
+		default_config.GetReportPath(),
+		default_config.GetStatusPath(),
+		default_config.GetIncidentHistoryPath(),
+	} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("Expected %s to be written: %v", path, err)
+		}
 	}
 }
 
@@ -130,9 +138,6 @@ func copyLogFile(srcPath, dstPath string) error {
 	return err
 }
 
-// tmpLogPath is a temporary log file path used for testing purposes.
-const tmpLogPath = "data/ponghub_log_test.json"
-
 func TestMain(m *testing.M) {
 	// Change the working directory to the root of the project
 	root, err := filepath.Abs("../..")
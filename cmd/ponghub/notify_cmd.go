@@ -0,0 +1,65 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/wcy-dt/ponghub/internal/configure"
+	"github.com/wcy-dt/ponghub/internal/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/default_config"
+	"github.com/wcy-dt/ponghub/internal/types/types/event_type"
+)
+
+// runNotifyTest sends a synthetic event through the configured notification channels and prints
+// the delivery result. It returns 0 on success, 1 if a channel failed, 2 on invalid arguments
+// and 3 if the configuration cannot be loaded.
+func runNotifyTest(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("notify-test", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	paths := registerPathFlags(flags)
+	channel := flags.String("channel", "", "only notify the channel with this method or name")
+	eventTypeName := flags.String("type", event_type.OUTAGE.String(), "event type: outage, recovery, cert_warning, cert_expired or cert_renewed")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	paths.apply()
+
+	eventType, ok := event_type.ParseEventType(*eventTypeName)
+	if !ok {
+		_, _ = fmt.Fprintf(stderr, "Unknown event type %q, expected outage, recovery, cert_warning, cert_expired or cert_renewed\n", *eventTypeName)
+		return exitUsage
+	}
+
+	cfg, err := configure.ReadConfigs(default_config.GetConfigPath())
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, "Error loading config at", default_config.GetConfigPath(), ":", err)
+		return exitError
+	}
+
+	report, err := notifier.SendTestNotification(cfg.Notifications, eventType, *channel)
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, "Error sending test notification:", err)
+		return exitFailure
+	}
+
+	// print the result of every channel
+	_, _ = fmt.Fprintln(stdout, report.Title)
+	for _, result := range report.Results {
+		status := "OK"
+		switch {
+		case result.Error != "":
+			status = "FAILED: " + result.Error
+		case result.Skipped:
+			status = "SKIPPED: no plain text message for this event type"
+		case result.Muted:
+			status = "MUTED"
+		}
+		_, _ = fmt.Fprintf(stdout, "  %-20s %s (attempts: %d, latency: %dms)\n", result.Channel, status, result.Attempts, result.LatencyMs)
+	}
+
+	if len(report.Failed()) > 0 {
+		return exitFailure
+	}
+	return exitOK
+}
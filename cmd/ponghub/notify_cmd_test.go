@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunNotifyTest(t *testing.T) {
+	okServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer okServer.Close()
+	failingServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer failingServer.Close()
+
+	restoreDefaultPaths(t)
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	config := `
+services:
+  - name: "api"
+    endpoints:
+      - url: "https://example.com"
+notifications:
+  enabled: true
+  channels:
+    - name: ops
+      type: webhook
+      webhook:
+        url: "` + okServer.URL + `"
+    - name: broken
+      type: webhook
+      webhook:
+        url: "` + failingServer.URL + `"
+`
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		code     int
+		expected string
+	}{
+		{name: "Single channel", args: []string{"--config", configPath, "--channel", "ops"}, code: 0, expected: "ops"},
+		{name: "Failing channel", args: []string{"--config", configPath, "--type", "cert_warning"}, code: 1, expected: "broken               FAILED"},
+		{name: "Unknown channel", args: []string{"--config", configPath, "--channel", "pager"}, code: 1, expected: "unknown notification channel"},
+		{name: "Unknown event type", args: []string{"--type", "meteor"}, code: 2, expected: "Unknown event type"},
+		{name: "Unknown flag", args: []string{"--verbose"}, code: 2, expected: "flag provided but not defined"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := runNotifyTest(tt.args, &stdout, &stderr)
+			if code != tt.code {
+				t.Errorf("Expected exit code %d, got %d", tt.code, code)
+			}
+			if output := stdout.String() + stderr.String(); !strings.Contains(output, tt.expected) {
+				t.Errorf("Expected the output to contain %q, got:\n%s", tt.expected, output)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/wcy-dt/ponghub/internal/checker"
+	"github.com/wcy-dt/ponghub/internal/configure"
+	"github.com/wcy-dt/ponghub/internal/incident"
+	"github.com/wcy-dt/ponghub/internal/notifier"
+	"github.com/wcy-dt/ponghub/internal/reporter"
+	"github.com/wcy-dt/ponghub/internal/types/types/default_config"
+)
+
+// runReport generates the report from the existing log, incidents and delivery report without
+// checking the services. Certificate details are not shown as they are only known after a check.
+func runReport(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("report", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	paths := registerPathFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	paths.apply()
+
+	cfg, err := configure.ReadConfigs(default_config.GetConfigPath())
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, "Error loading config at", default_config.GetConfigPath(), ":", err)
+		return exitError
+	}
+	if err := createDataDirs(); err != nil {
+		_, _ = fmt.Fprintln(stderr, "Error creating the data directory:", err)
+		return exitError
+	}
+
+	reportResult, err := reporter.GetReport(checker.ListServices(cfg), default_config.GetLogPath(), cfg)
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, "Error generating report data:", err)
+		return exitError
+	}
+
+	incidents, err := incident.ReadIncidents(default_config.GetIncidentHistoryPath())
+	if err != nil {
+		log.Println("Error loading incidents:", err)
+	}
+	incidents = incident.LoadNotes(incidents, default_config.GetIncidentNotesDir())
+	deliveryReport, err := notifier.ReadDeliveryReport(default_config.GetDeliveryReportPath())
+	if err != nil {
+		log.Println("Error loading the last delivery report:", err)
+	}
+
+	if err := reporter.WriteReport(reportResult, default_config.GetReportPath(), cfg.DisplayNum, deliveryReport, incidents); err != nil {
+		_, _ = fmt.Fprintln(stderr, "Error generating report:", err)
+		return exitError
+	}
+	if err := reporter.WriteStatus(reportResult, default_config.GetStatusPath()); err != nil {
+		_, _ = fmt.Fprintln(stderr, "Error writing status:", err)
+		return exitError
+	}
+	_, _ = fmt.Fprintln(stdout, "Report generated at", default_config.GetReportPath())
+	return exitOK
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunReport(t *testing.T) {
+	restoreDefaultPaths(t)
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	config := `
+services:
+  - name: "api"
+    endpoints:
+      - url: "https://api.example.com"
+`
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	logContent := `{"api": {
+  "service_history": [{"time": "2025-03-05T10:00:00Z", "status": "all"}],
+  "endpoints": {"https://api.example.com": [{"time": "2025-03-05T10:00:00Z", "status": "all", "response_time": 120}]}
+}}`
+	if err := os.WriteFile(filepath.Join(dir, "ponghub_log.json"), []byte(logContent), 0644); err != nil {
+		t.Fatalf("Failed to write log: %v", err)
+	}
+
+	outputPath := filepath.Join(dir, "public", "status.html")
+	var stdout, stderr bytes.Buffer
+	code := runReport([]string{"--config", configPath, "--data-dir", dir, "--output", outputPath}, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("Expected exit code 0, got %d: %s", code, stderr.String())
+	}
+
+	report, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to read report: %v", err)
+	}
+	if !strings.Contains(string(report), "https://api.example.com") {
+		t.Error("Expected the report to contain the logged endpoint")
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/wcy-dt/ponghub/internal/schema"
+)
+
+// runSchema prints the JSON Schema of the configuration file, or writes it to the --output file,
+// for editors to complete and validate config.yaml
+func runSchema(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("schema", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	outputPath := flags.String("output", "", "write the schema to this file instead of the standard output")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	data, err := schema.Marshal()
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, "Error generating the schema:", err)
+		return exitError
+	}
+
+	if *outputPath == "" {
+		_, _ = stdout.Write(data)
+		return exitOK
+	}
+	if err := os.WriteFile(*outputPath, data, 0644); err != nil {
+		_, _ = fmt.Fprintln(stderr, "Error writing the schema:", err)
+		return exitError
+	}
+	_, _ = fmt.Fprintln(stdout, "Schema written to", *outputPath)
+	return exitOK
+}
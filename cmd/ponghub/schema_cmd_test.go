@@ -0,0 +1,37 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunSchema(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := runSchema(nil, &stdout, &stderr); code != 0 {
+		t.Fatalf("Expected exit code 0, got %d: %s", code, stderr.String())
+	}
+	var printed map[string]any
+	if err := json.Unmarshal(stdout.Bytes(), &printed); err != nil {
+		t.Fatalf("Expected the schema to be JSON: %v", err)
+	}
+	if _, exists := printed["properties"].(map[string]any)["services"]; !exists {
+		t.Error("Expected the schema to describe the services")
+	}
+
+	outputPath := filepath.Join(t.TempDir(), "config.schema.json")
+	stdout.Reset()
+	if code := runSchema([]string{"--output", outputPath}, &stdout, &stderr); code != 0 {
+		t.Fatalf("Expected exit code 0, got %d: %s", code, stderr.String())
+	}
+	written, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to read the schema: %v", err)
+	}
+	var schema map[string]any
+	if err := json.Unmarshal(written, &schema); err != nil {
+		t.Errorf("Expected the written schema to be JSON: %v", err)
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/wcy-dt/ponghub/internal/common/params"
+)
+
+// secretsUsage describes the subcommands of the secrets command
+const secretsUsage = `Usage: ponghub secrets <keygen|encrypt|decrypt> [flags]
+
+  keygen   print a new key for the secrets file, to be set in ` + params.SecretsKeyEnv + `
+  encrypt  encrypt a YAML map of secret names to values into the secrets file
+  decrypt  print the decrypted content of the secrets file
+`
+
+// runSecrets manages the encrypted secrets file read by the {{secret(store:name)}} parameters,
+// whose key is read from the PONGHUB_SECRETS_KEY environment variable
+func runSecrets(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		_, _ = fmt.Fprint(stderr, secretsUsage)
+		return exitUsage
+	}
+
+	flags := flag.NewFlagSet("secrets "+args[0], flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	switch args[0] {
+	case "keygen":
+		if err := flags.Parse(args[1:]); err != nil {
+			return exitUsage
+		}
+		key, err := params.GenerateSecretsKey()
+		if err != nil {
+			_, _ = fmt.Fprintln(stderr, "Error generating the key:", err)
+			return exitError
+		}
+		_, _ = fmt.Fprintln(stdout, key)
+		return exitOK
+	case "encrypt":
+		inputPath := flags.String("input", "secrets.yaml", "YAML map of secret names to values")
+		outputPath := flags.String("output", "secrets.enc", "encrypted secrets file")
+		if err := flags.Parse(args[1:]); err != nil {
+			return exitUsage
+		}
+		return encryptSecretsFile(*inputPath, *outputPath, stdout, stderr)
+	case "decrypt":
+		inputPath := flags.String("input", "secrets.enc", "encrypted secrets file")
+		if err := flags.Parse(args[1:]); err != nil {
+			return exitUsage
+		}
+		return decryptSecretsFile(*inputPath, stdout, stderr)
+	default:
+		_, _ = fmt.Fprintf(stderr, "Unknown secrets command %q\n\n%s", args[0], secretsUsage)
+		return exitUsage
+	}
+}
+
+// encryptSecretsFile encrypts the secrets of the input file into the output file
+func encryptSecretsFile(inputPath, outputPath string, stdout, stderr io.Writer) int {
+	key, err := params.ParseSecretsKey(os.Getenv(params.SecretsKeyEnv))
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, "Error reading the key:", err)
+		return exitError
+	}
+	plaintext, err := os.ReadFile(inputPath)
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, "Error reading the secrets:", err)
+		return exitError
+	}
+	secrets, err := params.ParseSecrets(plaintext)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "Error parsing %s: %v\n", inputPath, err)
+		return exitError
+	}
+
+	data, err := params.EncryptSecrets(plaintext, key)
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, "Error encrypting the secrets:", err)
+		return exitError
+	}
+	if err := os.WriteFile(outputPath, data, 0600); err != nil {
+		_, _ = fmt.Fprintln(stderr, "Error writing the secrets:", err)
+		return exitError
+	}
+	_, _ = fmt.Fprintf(stdout, "%d secret(s) encrypted to %s\n", len(secrets), outputPath)
+	return exitOK
+}
+
+// decryptSecretsFile prints the decrypted content of the secrets file, e.g. to edit and encrypt it again
+func decryptSecretsFile(inputPath string, stdout, stderr io.Writer) int {
+	key, err := params.ParseSecretsKey(os.Getenv(params.SecretsKeyEnv))
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, "Error reading the key:", err)
+		return exitError
+	}
+	data, err := os.ReadFile(inputPath)
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, "Error reading the secrets:", err)
+		return exitError
+	}
+	plaintext, err := params.DecryptSecrets(data, key)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "Error decrypting %s: %v\n", inputPath, err)
+		return exitError
+	}
+	_, _ = stdout.Write(plaintext)
+	return exitOK
+}
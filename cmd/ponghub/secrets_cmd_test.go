@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/wcy-dt/ponghub/internal/common/params"
+)
+
+func TestRunSecrets(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := runSecrets([]string{"keygen"}, &stdout, &stderr); code != exitOK {
+		t.Fatalf("Expected exit code 0, got %d: %s", code, stderr.String())
+	}
+	key := strings.TrimSpace(stdout.String())
+	if _, err := params.ParseSecretsKey(key); err != nil {
+		t.Fatalf("Expected a valid key, got %q: %v", key, err)
+	}
+
+	dir := t.TempDir()
+	inputPath, outputPath := filepath.Join(dir, "secrets.yaml"), filepath.Join(dir, "secrets.enc")
+	content := "smtp_password: hunter22\napi_token: abc\n"
+	if err := os.WriteFile(inputPath, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write secrets: %v", err)
+	}
+
+	// the key is required
+	t.Setenv(params.SecretsKeyEnv, "")
+	if code := runSecrets([]string{"encrypt", "--input", inputPath, "--output", outputPath}, &stdout, &stderr); code != exitError {
+		t.Errorf("Expected exit code 3 without key, got %d", code)
+	}
+
+	t.Setenv(params.SecretsKeyEnv, key)
+	stdout.Reset()
+	if code := runSecrets([]string{"encrypt", "--input", inputPath, "--output", outputPath}, &stdout, &stderr); code != exitOK {
+		t.Fatalf("Expected exit code 0, got %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "2 secret(s) encrypted") {
+		t.Errorf("Expected the number of secrets, got %q", stdout.String())
+	}
+	encrypted, err := os.ReadFile(outputPath)
+	if err != nil || strings.Contains(string(encrypted), "hunter22") {
+		t.Fatalf("Expected the secrets to be encrypted, got %q (%v)", encrypted, err)
+	}
+
+	stdout.Reset()
+	if code := runSecrets([]string{"decrypt", "--input", outputPath}, &stdout, &stderr); code != exitOK {
+		t.Fatalf("Expected exit code 0, got %d: %s", code, stderr.String())
+	}
+	if stdout.String() != content {
+		t.Errorf("Expected the decrypted secrets %q, got %q", content, stdout.String())
+	}
+
+	if code := runSecrets([]string{"rotate"}, &stdout, &stderr); code != exitUsage {
+		t.Errorf("Expected exit code 2 for an unknown command, got %d", code)
+	}
+}
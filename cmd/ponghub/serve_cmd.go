@@ -0,0 +1,186 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/configure"
+	"github.com/wcy-dt/ponghub/internal/reporter"
+	"github.com/wcy-dt/ponghub/internal/types/types/default_config"
+)
+
+// runServe checks the services at every interval like a scheduled run and serves the report over HTTP
+// until it is interrupted. The configuration is reloaded before every run, and immediately when its files
+// change or on SIGHUP. An invalid configuration is rejected and the services are checked with the last
+// valid one. A run in progress is completed before the configuration is reloaded.
+func runServe(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	paths := registerPathFlags(flags)
+	addr := flags.String("addr", ":8080", "address to serve the report on")
+	interval := flags.Duration("interval", 5*time.Minute, "time between two runs of the checks")
+	watchInterval := flags.Duration("watch-interval", 2*time.Second, "time between two checks of the configuration files for changes, 0 to only reload on SIGHUP")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if *interval <= 0 {
+		_, _ = fmt.Fprintln(stderr, "The interval must be positive")
+		return exitUsage
+	}
+	paths.apply()
+
+	reloader, err := configure.NewReloader(default_config.GetConfigPath())
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, "Error loading config at", default_config.GetConfigPath(), ":", err)
+		return exitError
+	}
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, "Error listening on", *addr, ":", err)
+		return exitError
+	}
+	server := &http.Server{Handler: newReportHandler(), ReadHeaderTimeout: 10 * time.Second}
+	serveErrors := make(chan error, 1)
+	go func() {
+		serveErrors <- server.Serve(listener)
+	}()
+	_, _ = fmt.Fprintf(stdout, "Serving the report on %s, checking every %s\n", listener.Addr(), *interval)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	defer signal.Stop(hangup)
+
+	// changes of the configuration files are detected by polling their sizes and modification times
+	var watch <-chan time.Time
+	if *watchInterval > 0 {
+		watchTicker := time.NewTicker(*watchInterval)
+		defer watchTicker.Stop()
+		watch = watchTicker.C
+	}
+
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+	for {
+		if err := runChecksWith(reloader.Config()); err != nil {
+			log.Println(err)
+		}
+
+	wait:
+		for {
+			select {
+			case <-ctx.Done():
+				shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				if err := server.Shutdown(shutdownCtx); err != nil {
+					log.Println("Error shutting down the server:", err)
+				}
+				return exitOK
+			case err := <-serveErrors:
+				if !errors.Is(err, http.ErrServerClosed) {
+					_, _ = fmt.Fprintln(stderr, "Error serving the report:", err)
+				}
+				return exitError
+			case <-hangup:
+				log.Println("Reloading the configuration on SIGHUP")
+				if reloadConfig(reloader) {
+					ticker.Reset(*interval)
+					break wait
+				}
+			case <-watch:
+				if reloader.Changed() {
+					log.Println("Configuration files changed, reloading the configuration")
+					if reloadConfig(reloader) {
+						ticker.Reset(*interval)
+						break wait
+					}
+				}
+			case <-ticker.C:
+				// the configuration is read before every run so that its parameters are resolved again
+				reloadConfig(reloader)
+				break wait
+			}
+		}
+	}
+}
+
+// reloadConfig reloads the configuration and reports whether it is valid, the last valid configuration
+// is kept otherwise
+func reloadConfig(reloader *configure.Reloader) bool {
+	if err := reloader.Reload(); err != nil {
+		log.Printf("Keeping the last valid configuration, the configuration at %s is invalid:\n%v", default_config.GetConfigPath(), err)
+		return false
+	}
+	return true
+}
+
+// newReportHandler serves the report, its static files and the JSON status. The other data files
+// are not served as they may contain details of the notification channels.
+func newReportHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/static/", http.StripPrefix("/static/", http.FileServer(http.Dir(default_config.GetStaticDir()))))
+	mux.HandleFunc("/api/status", serveStatus)
+	mux.HandleFunc("/status.json", func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, default_config.GetStatusPath())
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" && r.URL.Path != "/index.html" {
+			http.NotFound(w, r)
+			return
+		}
+		http.ServeFile(w, r, default_config.GetReportPath())
+	})
+	return mux
+}
+
+// serveStatus serves the JSON status of the services, filtered by the group and tag query parameters.
+// Both can be repeated or hold comma-separated lists, e.g. /api/status?group=Public%20Web&tag=db,cache
+func serveStatus(w http.ResponseWriter, r *http.Request) {
+	status, err := reporter.ReadStatus(default_config.GetStatusPath())
+	if errors.Is(err, fs.ErrNotExist) {
+		http.Error(w, "The status is not available until the services are checked", http.StatusServiceUnavailable)
+		return
+	} else if err != nil {
+		log.Println("Error reading status:", err)
+		http.Error(w, "Error reading the status", http.StatusInternalServerError)
+		return
+	}
+
+	query := r.URL.Query()
+	status = reporter.FilterStatus(status, splitQueryValues(query["group"]), splitQueryValues(query["tag"]))
+
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(status); err != nil {
+		log.Println("Error encoding status:", err)
+	}
+}
+
+// splitQueryValues splits the comma-separated values of a query parameter, empty values are dropped
+func splitQueryValues(values []string) []string {
+	var split []string
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				split = append(split, part)
+			}
+		}
+	}
+	return split
+}
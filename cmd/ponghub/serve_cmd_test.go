@@ -0,0 +1,128 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/reporter"
+	"github.com/wcy-dt/ponghub/internal/types/types/default_config"
+)
+
+func TestNewReportHandler(t *testing.T) {
+	restoreDefaultPaths(t)
+	dataDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dataDir, "index.html"), []byte("<html>report</html>"), 0644); err != nil {
+		t.Fatalf("Failed to write report: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dataDir, "notification_outbox.json"), []byte("[]"), 0644); err != nil {
+		t.Fatalf("Failed to write outbox: %v", err)
+	}
+	default_config.SetDataDir(dataDir)
+
+	server := httptest.NewServer(newReportHandler())
+	defer server.Close()
+
+	tests := []struct {
+		path string
+		code int
+	}{
+		{path: "/", code: http.StatusOK},
+		{path: "/static/style.css", code: http.StatusOK},
+		{path: "/notification_outbox.json", code: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			resp, err := http.Get(server.URL + tt.path)
+			if err != nil {
+				t.Fatalf("Request failed: %v", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+			if resp.StatusCode != tt.code {
+				t.Errorf("Expected status %d, got %d", tt.code, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestServeStatus(t *testing.T) {
+	restoreDefaultPaths(t)
+	default_config.SetDataDir(t.TempDir())
+
+	server := httptest.NewServer(newReportHandler())
+	defer server.Close()
+
+	getStatus := func(query string) (int, reporter.Status) {
+		t.Helper()
+		resp, err := http.Get(server.URL + "/api/status" + query)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		var status reporter.Status
+		if resp.StatusCode == http.StatusOK {
+			if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+				t.Fatalf("Failed to decode status: %v", err)
+			}
+		}
+		return resp.StatusCode, status
+	}
+
+	if code, _ := getStatus(""); code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status %d before the first check, got %d", http.StatusServiceUnavailable, code)
+	}
+
+	history := reporter.History{{Time: "2025-03-05T10:00:00Z", Status: "all"}}
+	status := reporter.Status{
+		UpdateTime: "2025-03-05T10:00:00Z",
+		Groups: []reporter.Group{
+			{Name: "Public Web", Status: "all", Services: reporter.Reporter{
+				{Name: "website", Group: "Public Web", ServiceHistory: history, Endpoints: reporter.Endpoints{{URL: "https://example.com"}}},
+			}},
+			{Name: "Internal APIs", Status: "all", Services: reporter.Reporter{
+				{Name: "api", Group: "Internal APIs", Tags: []string{"db"}, ServiceHistory: history, Endpoints: reporter.Endpoints{{URL: "https://api.example.com"}}},
+				{Name: "queue", Group: "Internal APIs", ServiceHistory: history, Endpoints: reporter.Endpoints{{URL: "https://queue.example.com", Tags: []string{"cache"}}}},
+			}},
+		},
+	}
+	data, err := json.Marshal(status)
+	if err != nil {
+		t.Fatalf("Failed to encode status: %v", err)
+	}
+	if err := os.WriteFile(default_config.GetStatusPath(), data, 0644); err != nil {
+		t.Fatalf("Failed to write status: %v", err)
+	}
+
+	tests := []struct {
+		query    string
+		services []string
+	}{
+		{query: "", services: []string{"website", "api", "queue"}},
+		{query: "?group=public%20web", services: []string{"website"}},
+		{query: "?tag=db,cache", services: []string{"api", "queue"}},
+		{query: "?tag=db&tag=cache&group=Public%20Web", services: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			code, status := getStatus(tt.query)
+			if code != http.StatusOK {
+				t.Fatalf("Expected status %d, got %d", http.StatusOK, code)
+			}
+			var services []string
+			for _, group := range status.Groups {
+				for _, service := range group.Services {
+					services = append(services, service.Name)
+				}
+			}
+			if !reflect.DeepEqual(services, tt.services) {
+				t.Errorf("Expected services %v, got %v", tt.services, services)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+
+	"github.com/wcy-dt/ponghub/internal/configure"
+	"github.com/wcy-dt/ponghub/internal/types/types/default_config"
+)
+
+// runValidate loads the configuration and prints the problems found in it with their line and column.
+// It returns 0 if the configuration is valid and 1 otherwise.
+func runValidate(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("validate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	paths := registerPathFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	paths.apply()
+
+	configPath := default_config.GetConfigPath()
+	if _, err := configure.ReadConfigs(configPath); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			_, _ = fmt.Fprintln(stderr, err)
+			return exitError
+		}
+		problems := strings.Split(err.Error(), "\n")
+		for _, problem := range problems {
+			_, _ = fmt.Fprintln(stdout, problem)
+		}
+		_, _ = fmt.Fprintf(stdout, "%d problem(s) found\n", len(problems))
+		return exitFailure
+	}
+
+	_, _ = fmt.Fprintf(stdout, "%s: configuration is valid\n", configPath)
+	return exitOK
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunValidate(t *testing.T) {
+	restoreDefaultPaths(t)
+	dir := t.TempDir()
+
+	tests := []struct {
+		name     string
+		config   string
+		code     int
+		expected []string
+	}{
+		{
+			name: "Valid config",
+			config: `
+services:
+  - name: "api"
+    endpoints:
+      - url: "https://example.com"
+`,
+			code:     0,
+			expected: []string{"configuration is valid"},
+		},
+		{
+			name: "Invalid config",
+			config: `
+services:
+  - name: "api"
+    timout: 5
+    endpoints:
+      - url: "example.com"
+        method: "DELETE"
+        status_code: 1000
+        response_regex: "ok("
+      - url: "https://example.com/{{env(PONGHUB_TEST_UNSET)}}/{{nope}}"
+  - name: "api"
+notifications:
+  enabled: true
+  methods: ["webhook", "sms"]
+  digest:
+    enabled: true
+    period: "monthly"
+`,
+			code: 1,
+			expected: []string{
+				`:4:5: services[0].timout: unknown key "timout"`,
+				":6:14: services[0].endpoints[0].url: URL must be an absolute http or https URL",
+				`:7:17: services[0].endpoints[0].method: value "DELETE" is not one of GET, POST, PUT`,
+				":8:22: services[0].endpoints[0].status_code: value 1000 is not between 100 and 599",
+				":9:25: services[0].endpoints[0].response_regex: invalid regular expression",
+				":10:14: services[0].endpoints[1].url: environment variable PONGHUB_TEST_UNSET of {{env(PONGHUB_TEST_UNSET)}} is not set",
+				":10:14: services[0].endpoints[1].url: unknown parameter {{nope}}",
+				`:11:11: services[1].name: service name "api" is already used by services[0]`,
+				":14:13: notifications.methods[0]: no webhook settings configured",
+				`:14:24: notifications.methods[1]: value "sms" is not one of default, email, webhook`,
+				`:17:13: notifications.digest.period: value "monthly" is not one of daily, weekly`,
+				"11 problem(s) found",
+			},
+		},
+		{
+			name:     "Malformed YAML",
+			config:   "services: [",
+			code:     1,
+			expected: []string{"configc.yaml:1: did not find expected node content"},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(dir, "config"+string(rune('a'+i))+".yaml")
+			if err := os.WriteFile(configPath, []byte(tt.config), 0644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			var stdout, stderr bytes.Buffer
+			code := runValidate([]string{"--config", configPath}, &stdout, &stderr)
+			if code != tt.code {
+				t.Errorf("Expected exit code %d, got %d", tt.code, code)
+			}
+			output := stdout.String() + stderr.String()
+			for _, expected := range tt.expected {
+				if !strings.Contains(output, expected) {
+					t.Errorf("Expected the output to contain %q, got:\n%s", expected, output)
+				}
+			}
+		})
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := runValidate([]string{"--config", filepath.Join(dir, "missing.yaml")}, &stdout, &stderr); code != 3 {
+		t.Errorf("Expected exit code 3 for a missing config, got %d", code)
+	}
+}
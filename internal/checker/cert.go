@@ -17,8 +17,9 @@ func isHTTPS(urlStr string) bool {
 	return u.Scheme == "https"
 }
 
-// checkSSLCertificates checks the SSL certificates of the URL
-func checkSSLCertificates(urlStr string) (remainingDays int, isExpired bool, err error) {
+// checkSSLCertificates checks the SSL certificates of the URL. If skipVerify is set, the certificate
+// is not verified, so that the expiration of self-signed certificates can still be checked.
+func checkSSLCertificates(urlStr string, skipVerify bool) (remainingDays int, isExpired bool, err error) {
 	u, err := url.Parse(urlStr)
 	if err != nil {
 		return 0, false, err
@@ -37,7 +38,8 @@ func checkSSLCertificates(urlStr string) (remainingDays int, isExpired bool, err
 	address := host + ":" + port
 
 	conn, err := tls.Dial("tcp", address, &tls.Config{
-		ServerName: host,
+		ServerName:         host,
+		InsecureSkipVerify: skipVerify,
 	})
 	if err != nil {
 		return 0, false, err
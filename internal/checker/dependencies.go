@@ -0,0 +1,67 @@
+package checker
+
+import (
+	"cmp"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	"github.com/wcy-dt/ponghub/internal/types/types/chk_result"
+)
+
+// markImpacted marks the failing services that depend on an unavailable service as impacted, together
+// with their failing endpoints, so that only the service at the root of the dependencies is alerted on.
+// Dependencies are followed transitively, services under maintenance are not considered unavailable.
+func markImpacted(checkResult []checker.Service) {
+	indexes := make(map[string]int, len(checkResult))
+	for i, service := range checkResult {
+		indexes[service.Name] = i
+	}
+
+	// roots holds the root cause of each resolved service, or an empty string if it has none
+	roots := make(map[int]string)
+	var findRoot func(i int, visiting map[int]bool) string
+	findRoot = func(i int, visiting map[int]bool) string {
+		if root, resolved := roots[i]; resolved {
+			return root
+		}
+		visiting[i] = true
+		defer delete(visiting, i)
+
+		root := ""
+		for _, name := range checkResult[i].DependsOn {
+			j, exists := indexes[name]
+			if !exists || visiting[j] || !isFailing(checkResult[j].Status) {
+				continue
+			}
+			root = cmp.Or(findRoot(j, visiting), name)
+			break
+		}
+		roots[i] = root
+		return root
+	}
+
+	impactedBy := make(map[int]string)
+	for i := range checkResult {
+		if !isFailing(checkResult[i].Status) {
+			continue
+		}
+		if root := findRoot(i, make(map[int]bool)); root != "" {
+			impactedBy[i] = root
+		}
+	}
+
+	// the statuses are changed only after all roots are found, as they are found by the original statuses
+	for i, root := range impactedBy {
+		checkResult[i].Status = chk_result.IMPACTED
+		checkResult[i].ImpactedBy = root
+		for j := range checkResult[i].Endpoints {
+			if isFailing(checkResult[i].Endpoints[j].Status) {
+				checkResult[i].Endpoints[j].Status = chk_result.IMPACTED
+			}
+		}
+	}
+}
+
+// isFailing reports whether the status is a full or partial failure
+func isFailing(status chk_result.CheckResult) bool {
+	return status == chk_result.NONE || status == chk_result.PART
+}
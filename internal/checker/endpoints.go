@@ -1,6 +1,7 @@
 package checker
 
 import (
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"io"
@@ -10,13 +11,16 @@ import (
 	"strings"
 	"time"
 
+	"github.com/wcy-dt/ponghub/internal/common"
 	"github.com/wcy-dt/ponghub/internal/common/params"
 	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
 	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/types/highlight"
 )
 
-// checkEndpoint checks a single port based on the provided configuration
-func checkEndpoint(cfg *configure.Endpoint, timeout int, maxRetryTimes int, serviceName string) checker.Endpoint {
+// checkEndpoint checks a single port based on the provided configuration, whose timeout and retries
+// are set from the defaults when the configuration is loaded
+func checkEndpoint(cfg *configure.Endpoint, serviceName string) checker.Endpoint {
 	var failureDetails []string
 	successNum := 0
 	attemptNum := 0
@@ -25,6 +29,8 @@ func checkEndpoint(cfg *configure.Endpoint, timeout int, maxRetryTimes int, serv
 	var responseBody string
 
 	httpMethod := getHttpMethod(cfg.Method)
+	maxRetryTimes := cfg.MaxRetryTimes
+	skipVerify := cfg.SkipTLSVerify != nil && *cfg.SkipTLSVerify
 	maxResponseTime := time.Duration(0)
 
 	// SSL certificate related variables
@@ -33,18 +39,11 @@ func checkEndpoint(cfg *configure.Endpoint, timeout int, maxRetryTimes int, serv
 	isCertExpired := false
 
 	// Generate display URL for smart showing of template vs resolved URL
-	resolver := params.NewParameterResolver()
-	displayURL, highlightSegments := resolver.HighlightChanges(cfg.URL)
-	originalURL := cfg.URL
-	if originalURL == "" {
-		originalURL = cfg.ParsedURL
-		displayURL = cfg.ParsedURL
-		highlightSegments = nil
-	}
+	displayURL, highlightSegments := getDisplayURL(cfg)
 
 	// Check SSL certificate if it's an HTTPS URL
 	if urlIsHTTPS {
-		remainingDays, expired, err := checkSSLCertificates(cfg.ParsedURL)
+		remainingDays, expired, err := checkSSLCertificates(cfg.ParsedURL, skipVerify)
 		if err != nil {
 			urlIsHTTPS = false
 			// Only log success details during tests to avoid exposing secrets
@@ -62,7 +61,12 @@ func checkEndpoint(cfg *configure.Endpoint, timeout int, maxRetryTimes int, serv
 	for currentAttemptNum := range maxRetryTimes {
 		attemptNum++
 		client := &http.Client{
-			Timeout: time.Duration(timeout) * time.Second,
+			Timeout: time.Duration(cfg.Timeout) * time.Second,
+		}
+		if skipVerify {
+			transport := http.DefaultTransport.(*http.Transport).Clone()
+			transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+			client.Transport = transport
 		}
 		// Only log request details during tests to avoid exposing secrets
 		logIfTest("[%s] %s %s (attempt %d/%d)\n",
@@ -71,7 +75,7 @@ func checkEndpoint(cfg *configure.Endpoint, timeout int, maxRetryTimes int, serv
 		// build the request
 		req, err := http.NewRequest(httpMethod, cfg.ParsedURL, nil)
 		if err != nil {
-			failureDetails = append(failureDetails, fmt.Sprintf("StatusCode: N/A, Error: %s", err.Error()))
+			failureDetails = append(failureDetails, fmt.Sprintf("StatusCode: N/A, Error: %s", common.RedactURLError(err).Error()))
 			log.Printf("FAILED - Error: %s", err.Error())
 			continue
 		}
@@ -87,7 +91,7 @@ func checkEndpoint(cfg *configure.Endpoint, timeout int, maxRetryTimes int, serv
 		resp, err := client.Do(req)
 		responseTime := time.Since(reqStartTime)
 		if err != nil {
-			failureDetails = append(failureDetails, fmt.Sprintf("StatusCode: N/A, Error: %s", err.Error()))
+			failureDetails = append(failureDetails, fmt.Sprintf("StatusCode: N/A, Error: %s", common.RedactURLError(err).Error()))
 			log.Printf("FAILED - Error: %s", err.Error())
 			continue
 		}
@@ -148,6 +152,7 @@ func checkEndpoint(cfg *configure.Endpoint, timeout int, maxRetryTimes int, serv
 		IsCertExpired:     isCertExpired,
 		DisplayURL:        displayURL,
 		HighlightSegments: highlightSegments,
+		Tags:              cfg.Tags,
 	}
 }
 
@@ -184,7 +189,8 @@ func isSuccessfulResponse(cfg *configure.Endpoint, rsp *http.Response, body []by
 	if cfg.ResponseRegex != "" {
 		matched, err := regexp.Match(cfg.ResponseRegex, body)
 		if err != nil {
-			log.Fatalln("Error parsing regexp:", err)
+			log.Println("Error parsing regexp:", err)
+			return false
 		}
 		if !matched {
 			return false
@@ -208,3 +214,12 @@ func isSuccessfulResponse(cfg *configure.Endpoint, rsp *http.Response, body []by
 
 	return false
 }
+
+// getDisplayURL returns the URL shown in the report, highlighting the segments generated by parameters
+func getDisplayURL(cfg *configure.Endpoint) (string, []highlight.Segment) {
+	if cfg.URL == "" {
+		return cfg.ParsedURL, nil
+	}
+	resolver := params.NewParameterResolver()
+	return resolver.HighlightChanges(cfg.URL)
+}
@@ -0,0 +1,33 @@
+package checker
+
+import (
+	"log"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/common/schedule"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+)
+
+// inMaintenance checks if the service is in one of its maintenance windows at the given time
+func inMaintenance(service *configure.Service, t time.Time) bool {
+	for i, windowConfig := range service.Maintenance {
+		window, err := newMaintenanceWindow(windowConfig)
+		if err != nil {
+			log.Printf("Ignoring maintenance window %d of service %s: %v", i, service.Name, err)
+			continue
+		}
+		if active, end := window.ActiveAt(t); active {
+			log.Printf("Service %s is under maintenance until %s %s", service.Name, end.Format(time.RFC3339), windowConfig.Description)
+			return true
+		}
+	}
+	return false
+}
+
+// newMaintenanceWindow creates a recurring window if a cron expression is configured, otherwise a one-off window
+func newMaintenanceWindow(config configure.MaintenanceWindow) (*schedule.Window, error) {
+	if config.Cron != "" {
+		return schedule.NewRecurringWindow(config.Cron, config.Duration, config.Timezone)
+	}
+	return schedule.NewOneOffWindow(config.Start, config.End, config.Timezone)
+}
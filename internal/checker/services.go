@@ -1,6 +1,9 @@
 package checker
 
 import (
+	"cmp"
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
@@ -8,7 +11,8 @@ import (
 	"github.com/wcy-dt/ponghub/internal/types/types/chk_result"
 )
 
-// CheckServices checks all services defined in the configuration
+// CheckServices checks all services defined in the configuration. Failing services that depend on
+// an unavailable service are reported as impacted.
 func CheckServices(cfg *configure.Configure) []checker.Service {
 	var checkResult []checker.Service
 	for _, service := range cfg.Services {
@@ -21,7 +25,7 @@ func CheckServices(cfg *configure.Configure) []checker.Service {
 		startTime := time.Now()
 		var endpointResults []checker.Endpoint
 		for _, endpoint := range service.Endpoints {
-			endpointResult := checkEndpoint(&endpoint, service.Timeout, service.MaxRetryTimes, service.Name)
+			endpointResult := checkEndpoint(&endpoint, service.Name)
 			endpointResults = append(endpointResults, endpointResult)
 			attemptNum += endpointResult.AttemptNum
 			successNum += endpointResult.SuccessNum
@@ -34,6 +38,9 @@ func CheckServices(cfg *configure.Configure) []checker.Service {
 
 		serviceResult := checker.Service{
 			Name:       service.Name,
+			Group:      service.Group,
+			Tags:       service.Tags,
+			DependsOn:  service.DependsOn,
 			Status:     getTestResult(onlineEndpointNum, endpointNum),
 			Endpoints:  endpointResults,
 			StartTime:  startTime.Format(time.RFC3339),
@@ -41,7 +48,46 @@ func CheckServices(cfg *configure.Configure) []checker.Service {
 			AttemptNum: attemptNum,
 			SuccessNum: successNum,
 		}
+
+		// the service is still checked during maintenance, but reported with maintenance status
+		if inMaintenance(&service, startTime) {
+			serviceResult.Status = chk_result.MAINTENANCE
+			for i := range serviceResult.Endpoints {
+				serviceResult.Endpoints[i].Status = chk_result.MAINTENANCE
+			}
+		}
+
 		checkResult = append(checkResult, serviceResult)
 	}
+
+	markImpacted(checkResult)
 	return checkResult
 }
+
+// ListServices returns the configured services and endpoints without checking them, so that a report
+// can be generated from the log alone. Their status is unknown and certificates are not inspected.
+func ListServices(cfg *configure.Configure) []checker.Service {
+	var services []checker.Service
+	for _, service := range cfg.Services {
+		serviceResult := checker.Service{
+			Name:      service.Name,
+			Group:     service.Group,
+			Tags:      service.Tags,
+			DependsOn: service.DependsOn,
+			Status:    chk_result.UNKNOWN,
+		}
+		for _, endpoint := range service.Endpoints {
+			displayURL, highlightSegments := getDisplayURL(&endpoint)
+			serviceResult.Endpoints = append(serviceResult.Endpoints, checker.Endpoint{
+				URL:               endpoint.URL,
+				Method:            cmp.Or(strings.ToUpper(endpoint.Method), http.MethodGet),
+				Status:            chk_result.UNKNOWN,
+				DisplayURL:        displayURL,
+				HighlightSegments: highlightSegments,
+				Tags:              endpoint.Tags,
+			})
+		}
+		services = append(services, serviceResult)
+	}
+	return services
+}
@@ -0,0 +1,66 @@
+package common
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// redactedError is an error whose message is redacted, the original error is still wrapped
+type redactedError struct {
+	message string
+	err     error
+}
+
+// Error returns the redacted message
+func (e *redactedError) Error() string {
+	return e.message
+}
+
+// Unwrap returns the original error
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+// RedactURLError removes the URLs of failed requests from the error message, as they may contain secrets
+// such as the tokens of notification channels or the resolved parameters of an endpoint. All wrapped
+// and joined errors are searched for URLs. Errors without a URL are returned unchanged.
+func RedactURLError(err error) error {
+	urls := collectErrorURLs(err, nil)
+	if len(urls) == 0 {
+		return err
+	}
+	// replace longer URLs first, so that URLs containing others are removed completely
+	slices.SortFunc(urls, func(a, b string) int { return len(b) - len(a) })
+
+	message := err.Error()
+	for _, u := range urls {
+		message = strings.ReplaceAll(message, fmt.Sprintf(" %q", u), "")
+	}
+	for _, u := range urls {
+		message = strings.ReplaceAll(message, u, "[redacted]")
+	}
+	return &redactedError{message: message, err: err}
+}
+
+// collectErrorURLs appends the URLs of all *url.Error in the tree of err to urls.
+// Errors that are already redacted are not searched.
+func collectErrorURLs(err error, urls []string) []string {
+	switch e := err.(type) {
+	case nil, *redactedError:
+		return urls
+	case *url.Error:
+		if e.URL != "" && !slices.Contains(urls, e.URL) {
+			urls = append(urls, e.URL)
+		}
+		return collectErrorURLs(e.Err, urls)
+	case interface{ Unwrap() error }:
+		return collectErrorURLs(e.Unwrap(), urls)
+	case interface{ Unwrap() []error }:
+		for _, wrapped := range e.Unwrap() {
+			urls = collectErrorURLs(wrapped, urls)
+		}
+	}
+	return urls
+}
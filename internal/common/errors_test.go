@@ -0,0 +1,49 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestRedactURLError(t *testing.T) {
+	request, _ := http.NewRequest(http.MethodPost, "http://127.0.0.1:1/bot123456:SECRET/sendMessage?token=abc", nil)
+	_, err := http.DefaultClient.Do(request)
+	if err == nil {
+		t.Fatal("Expected the request to fail")
+	}
+
+	redacted := RedactURLError(fmt.Errorf("request failed: %w", err))
+	if message := redacted.Error(); strings.Contains(message, "SECRET") || strings.Contains(message, "token=abc") ||
+		!strings.HasPrefix(message, "request failed: Post: ") {
+		t.Errorf("Expected the URL to be removed, got %q", message)
+	}
+	if RedactURLError(redacted).Error() != redacted.Error() {
+		t.Error("Expected a redacted error to be left unchanged")
+	}
+
+	if err := RedactURLError(context.Canceled); !errors.Is(err, context.Canceled) || err.Error() != "context canceled" {
+		t.Errorf("Expected an error without URL to be unchanged, got %v", err)
+	}
+}
+
+func TestRedactURLError_Joined(t *testing.T) {
+	first := &url.Error{Op: "Post", URL: "https://hooks.example.com/first?token=abc", Err: errors.New("connection refused")}
+	second := &url.Error{Op: "Get", URL: "https://api.example.com/bot123456:SECRET/send", Err: errors.New("timeout")}
+
+	redacted := RedactURLError(fmt.Errorf("delivery failed: %w", errors.Join(first, second)))
+	message := redacted.Error()
+	if strings.Contains(message, "token=abc") || strings.Contains(message, "SECRET") {
+		t.Errorf("Expected both URLs to be removed, got %q", message)
+	}
+	if !strings.Contains(message, "Post: connection refused") || !strings.Contains(message, "Get: timeout") {
+		t.Errorf("Expected the errors to be kept without their URLs, got %q", message)
+	}
+	if !errors.Is(redacted, second) {
+		t.Error("Expected the joined errors to stay wrapped")
+	}
+}
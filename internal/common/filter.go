@@ -0,0 +1,31 @@
+package common
+
+import (
+	"slices"
+	"strings"
+)
+
+// MatchesFilter reports whether a service in the group with the given tags is selected by a filter of
+// groups and tags. Empty filters select everything, otherwise the group must be one of the groups and
+// one of the tags one of the filter tags. Groups and tags are compared case-insensitively.
+func MatchesFilter(groups, tags []string, group string, tagLists ...[]string) bool {
+	if len(groups) > 0 && !containsFold(groups, group) {
+		return false
+	}
+	if len(tags) == 0 {
+		return true
+	}
+	for _, tagList := range tagLists {
+		for _, tag := range tagList {
+			if containsFold(tags, tag) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// containsFold reports whether the values contain the value, ignoring case
+func containsFold(values []string, value string) bool {
+	return slices.ContainsFunc(values, func(other string) bool { return strings.EqualFold(other, value) })
+}
@@ -0,0 +1,149 @@
+package markdown
+
+import (
+	"html"
+	"html/template"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	headingPattern    = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	unorderedPattern  = regexp.MustCompile(`^\s*[-*+]\s+(.*)$`)
+	orderedPattern    = regexp.MustCompile(`^\s*\d+[.)]\s+(.*)$`)
+	linkPattern       = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	boldPattern       = regexp.MustCompile(`\*\*(.+?)\*\*`)
+	italicPattern     = regexp.MustCompile(`\*(.+?)\*`)
+	safeURLPattern    = regexp.MustCompile(`^(https?://|mailto:|/|#|\./|\.\./|[^:]*$)`)
+	inlineCodePattern = regexp.MustCompile("`([^`]+)`")
+)
+
+// headingLevelOffset demotes headings so that they fit below the headings of the page
+const headingLevelOffset = 2
+
+// renderer converts markdown line by line, collecting the lines of the current block
+type renderer struct {
+	out       strings.Builder
+	paragraph []string
+	listTag   string
+	listItems []string
+	code      []string
+	inCode    bool
+}
+
+// ToHTML converts a subset of markdown to HTML: headings, paragraphs, ordered and unordered lists,
+// fenced code blocks, inline code, bold, italic and links. All other HTML is escaped.
+func ToHTML(source string) template.HTML {
+	r := &renderer{}
+	for _, line := range strings.Split(strings.ReplaceAll(source, "\r\n", "\n"), "\n") {
+		r.line(line)
+	}
+	if r.inCode {
+		r.flushCode()
+	}
+	r.flush()
+	return template.HTML(r.out.String())
+}
+
+// line processes a single line of markdown
+func (r *renderer) line(line string) {
+	if strings.HasPrefix(strings.TrimSpace(line), "```") {
+		if r.inCode {
+			r.flushCode()
+		} else {
+			r.flush()
+			r.inCode = true
+		}
+		return
+	}
+	if r.inCode {
+		r.code = append(r.code, line)
+		return
+	}
+
+	if strings.TrimSpace(line) == "" {
+		r.flush()
+		return
+	}
+	if match := headingPattern.FindStringSubmatch(line); match != nil {
+		r.flush()
+		level := strconv.Itoa(min(len(match[1])+headingLevelOffset, 6))
+		r.out.WriteString("<h" + level + ">" + inline(match[2]) + "</h" + level + ">\n")
+		return
+	}
+	if match := unorderedPattern.FindStringSubmatch(line); match != nil {
+		r.listItem("ul", match[1])
+		return
+	}
+	if match := orderedPattern.FindStringSubmatch(line); match != nil {
+		r.listItem("ol", match[1])
+		return
+	}
+
+	if r.listTag != "" {
+		r.flush()
+	}
+	r.paragraph = append(r.paragraph, strings.TrimSpace(line))
+}
+
+// listItem adds an item to the current list, starting a new list if the type changes
+func (r *renderer) listItem(tag, text string) {
+	if r.listTag != tag {
+		r.flush()
+		r.listTag = tag
+	}
+	r.listItems = append(r.listItems, text)
+}
+
+// flush writes the current paragraph or list
+func (r *renderer) flush() {
+	if len(r.paragraph) > 0 {
+		r.out.WriteString("<p>" + inline(strings.Join(r.paragraph, " ")) + "</p>\n")
+		r.paragraph = nil
+	}
+	if r.listTag != "" {
+		r.out.WriteString("<" + r.listTag + ">\n")
+		for _, item := range r.listItems {
+			r.out.WriteString("<li>" + inline(item) + "</li>\n")
+		}
+		r.out.WriteString("</" + r.listTag + ">\n")
+		r.listTag = ""
+		r.listItems = nil
+	}
+}
+
+// flushCode writes the current code block
+func (r *renderer) flushCode() {
+	r.out.WriteString("<pre><code>" + html.EscapeString(strings.Join(r.code, "\n")) + "</code></pre>\n")
+	r.code = nil
+	r.inCode = false
+}
+
+// inline escapes the text and converts inline code, links, bold and italic text.
+// Code spans are converted separately so that their content is not formatted.
+func inline(text string) string {
+	var out strings.Builder
+	last := 0
+	for _, match := range inlineCodePattern.FindAllStringSubmatchIndex(text, -1) {
+		out.WriteString(format(text[last:match[0]]))
+		out.WriteString("<code>" + html.EscapeString(text[match[2]:match[3]]) + "</code>")
+		last = match[1]
+	}
+	out.WriteString(format(text[last:]))
+	return out.String()
+}
+
+// format escapes the text and converts links, bold and italic text. Links with unsafe schemes
+// such as javascript: are rendered as plain text.
+func format(text string) string {
+	text = linkPattern.ReplaceAllStringFunc(html.EscapeString(text), func(link string) string {
+		match := linkPattern.FindStringSubmatch(link)
+		if !safeURLPattern.MatchString(html.UnescapeString(match[2])) {
+			return match[1]
+		}
+		return `<a href="` + match[2] + `" target="_blank" rel="noopener">` + match[1] + `</a>`
+	})
+	text = boldPattern.ReplaceAllString(text, "<strong>$1</strong>")
+	return italicPattern.ReplaceAllString(text, "<em>$1</em>")
+}
@@ -0,0 +1,54 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestToHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{
+			name:     "Heading and paragraph",
+			source:   "# Root cause\nThe database\nwas **full**.",
+			expected: "<h3>Root cause</h3>\n<p>The database was <strong>full</strong>.</p>\n",
+		},
+		{
+			name:     "Lists",
+			source:   "- first *item*\n- second\n1. step\n2. `make run`",
+			expected: "<ul>\n<li>first <em>item</em></li>\n<li>second</li>\n</ul>\n<ol>\n<li>step</li>\n<li><code>make run</code></li>\n</ol>\n",
+		},
+		{
+			name:     "Code block",
+			source:   "```\n<b>**not bold**</b>\n```",
+			expected: "<pre><code>&lt;b&gt;**not bold**&lt;/b&gt;</code></pre>\n",
+		},
+		{
+			name:     "Links",
+			source:   "See [postmortem](https://example.com/pm?a=1&b=2) and [x](javascript:void)",
+			expected: `<p>See <a href="https://example.com/pm?a=1&amp;b=2" target="_blank" rel="noopener">postmortem</a> and x</p>` + "\n",
+		},
+		{
+			name:     "HTML is escaped",
+			source:   "<script>alert('x')</script>",
+			expected: "<p>&lt;script&gt;alert(&#39;x&#39;)&lt;/script&gt;</p>\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if html := string(ToHTML(tt.source)); html != tt.expected {
+				t.Errorf("Expected:\n%s\ngot:\n%s", tt.expected, html)
+			}
+		})
+	}
+}
+
+func TestToHTML_UnclosedCodeBlock(t *testing.T) {
+	if html := string(ToHTML("text\n```\ncode")); !strings.HasSuffix(html, "<pre><code>code</code></pre>\n") {
+		t.Errorf("Expected the unclosed code block to be rendered, got %q", html)
+	}
+}
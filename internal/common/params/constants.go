@@ -6,6 +6,24 @@ const (
 	HexCharset     = "0123456789abcdef"
 )
 
+// Secret providers of the {{secret(provider:name)}} parameter
+const (
+	SecretProviderEnv   = "env"
+	SecretProviderFile  = "file"
+	SecretProviderStore = "store"
+)
+
+const (
+	// SecretsKeyEnv is the environment variable of the base64 encoded key of the encrypted secrets file
+	SecretsKeyEnv = "PONGHUB_SECRETS_KEY"
+
+	// secretsKeySize is the size of the AES-256 key of the secrets file in bytes
+	secretsKeySize = 32
+
+	// secretsHeader starts the encrypted secrets file, it identifies the format and is authenticated with it
+	secretsHeader = "ponghub-secrets:v1:"
+)
+
 // TimeFormatReplacements Time format pattern replacements for strftime-like patterns to Go time format
 var TimeFormatReplacements = map[string]string{
 	"%Y": "2006",    // 4-digit year
@@ -17,6 +17,12 @@ import (
 type ParameterResolver struct {
 	currentTime time.Time
 	randSource  *mathrand.Rand
+
+	// unresolved collects the parameters that were not recognized or whose environment variable is not set
+	unresolved []string
+
+	// secretErrors holds why the unresolved secrets could not be read
+	secretErrors map[string]error
 }
 
 // NewParameterResolver creates a new parameter resolver with current time
@@ -81,8 +87,21 @@ func (pr *ParameterResolver) resolveSpecialParameter(param string) string {
 		if value := os.Getenv(envVar); value != "" {
 			return value
 		}
+		pr.unresolved = append(pr.unresolved, param)
 		return ""
 
+	// Secrets
+	case strings.HasPrefix(param, "secret(") && strings.HasSuffix(param, ")"):
+		value, err := resolveSecret(param[7 : len(param)-1])
+		if err != nil {
+			pr.unresolved = append(pr.unresolved, param)
+			if pr.secretErrors == nil {
+				pr.secretErrors = make(map[string]error)
+			}
+			pr.secretErrors[param] = err
+		}
+		return value
+
 	// Sequence numbers (based on current time)
 	case param == "seq":
 		return fmt.Sprintf("%d", pr.currentTime.UnixNano()%1000000)
@@ -176,6 +195,7 @@ func (pr *ParameterResolver) resolveSpecialParameter(param string) string {
 		if strings.Contains(param, "%") {
 			return pr.formatTimeWithPattern(param)
 		}
+		pr.unresolved = append(pr.unresolved, param)
 		return param // Return as-is if not recognized
 	}
 }
@@ -192,6 +212,11 @@ func (pr *ParameterResolver) resolveSpecialParameterWithSecret(param string) str
 		}
 		return ""
 
+	// Secrets
+	case strings.HasPrefix(param, "secret(") && strings.HasSuffix(param, ")"):
+		value, _ := resolveSecret(param[7 : len(param)-1])
+		return pr.maskSensitiveValue(value)
+
 	// For other parameters, use normal resolution
 	default:
 		return pr.resolveSpecialParameter(param)
@@ -292,6 +317,20 @@ func (pr *ParameterResolver) resolveParametersWithDepth(input string, depth int,
 	return result
 }
 
+// Unresolved returns the parameters that could not be resolved since the resolver was created or
+// Unresolved was last called, i.e. unknown parameters, environment variables that are not set and
+// secrets that cannot be read
+func (pr *ParameterResolver) Unresolved() []string {
+	unresolved := pr.unresolved
+	pr.unresolved = nil
+	return unresolved
+}
+
+// SecretError returns why an unresolved secret parameter could not be read
+func (pr *ParameterResolver) SecretError(param string) error {
+	return pr.secretErrors[param]
+}
+
 // GetResolvedValue returns the resolved value for display purposes
 func (pr *ParameterResolver) GetResolvedValue(original string) string {
 	return pr.ResolveParameters(original)
@@ -0,0 +1,194 @@
+package params
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// secretsStore caches the decrypted secrets file until it changes, as the channels resolve their
+// parameters concurrently
+var secretsStore struct {
+	sync.Mutex
+	path    string
+	size    int64
+	modTime time.Time
+	secrets map[string]string
+}
+
+// SetSecretsFile sets the path of the encrypted secrets file read by the store provider
+func SetSecretsFile(path string) {
+	secretsStore.Lock()
+	defer secretsStore.Unlock()
+	if path != secretsStore.path {
+		secretsStore.path = path
+		secretsStore.secrets = nil
+	}
+}
+
+// resolveSecret resolves a secret reference of the form provider:name, from an environment variable,
+// a file such as a Docker or Kubernetes secret, or the encrypted secrets file
+func resolveSecret(ref string) (string, error) {
+	provider, name, found := strings.Cut(ref, ":")
+	if !found || name == "" {
+		return "", errors.New("expected a reference such as env:NAME, file:PATH or store:NAME")
+	}
+
+	switch provider {
+	case SecretProviderEnv:
+		if value := os.Getenv(name); value != "" {
+			return value, nil
+		}
+		return "", fmt.Errorf("environment variable %s is not set", name)
+	case SecretProviderFile:
+		content, err := os.ReadFile(name)
+		if err != nil {
+			return "", err
+		}
+		// secret files usually end with a newline that is not part of the secret
+		if value := strings.TrimRight(string(content), "\r\n"); value != "" {
+			return value, nil
+		}
+		return "", fmt.Errorf("file %s is empty", name)
+	case SecretProviderStore:
+		return readStoredSecret(name)
+	default:
+		return "", fmt.Errorf("unknown secret provider %q, expected %s, %s or %s",
+			provider, SecretProviderEnv, SecretProviderFile, SecretProviderStore)
+	}
+}
+
+// readStoredSecret returns a secret of the encrypted secrets file, which is decrypted again when it changes
+func readStoredSecret(name string) (string, error) {
+	secretsStore.Lock()
+	defer secretsStore.Unlock()
+	if secretsStore.path == "" {
+		return "", errors.New("secrets_file is not set")
+	}
+
+	info, err := os.Stat(secretsStore.path)
+	if err != nil {
+		return "", err
+	}
+	if secretsStore.secrets == nil || info.Size() != secretsStore.size || !info.ModTime().Equal(secretsStore.modTime) {
+		secrets, err := loadSecretsFile(secretsStore.path)
+		if err != nil {
+			return "", err
+		}
+		secretsStore.secrets, secretsStore.size, secretsStore.modTime = secrets, info.Size(), info.ModTime()
+	}
+
+	if value, exists := secretsStore.secrets[name]; exists && value != "" {
+		return value, nil
+	}
+	return "", fmt.Errorf("secret %s is not set in %s", name, secretsStore.path)
+}
+
+// loadSecretsFile decrypts the secrets file with the key of the SecretsKeyEnv environment variable
+func loadSecretsFile(path string) (map[string]string, error) {
+	key, err := ParseSecretsKey(os.Getenv(SecretsKeyEnv))
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := DecryptSecrets(data, key)
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting %s: %w", path, err)
+	}
+	secrets, err := ParseSecrets(plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", path, err)
+	}
+	return secrets, nil
+}
+
+// GenerateSecretsKey returns a new random key for the secrets file, encoded in base64
+func GenerateSecretsKey() (string, error) {
+	key := make([]byte, secretsKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// ParseSecretsKey decodes a base64 encoded key of the secrets file
+func ParseSecretsKey(encoded string) ([]byte, error) {
+	if encoded == "" {
+		return nil, fmt.Errorf("environment variable %s is not set", SecretsKeyEnv)
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil || len(key) != secretsKeySize {
+		return nil, fmt.Errorf("the key must be %d bytes encoded in base64", secretsKeySize)
+	}
+	return key, nil
+}
+
+// ParseSecrets parses the YAML map of secret names to values of a decrypted secrets file. The values
+// are left out of the errors, as they may contain secrets.
+func ParseSecrets(plaintext []byte) (map[string]string, error) {
+	var secrets map[string]string
+	if err := yaml.Unmarshal(plaintext, &secrets); err != nil {
+		return nil, errors.New("expected a YAML map of secret names to string values")
+	}
+	return secrets, nil
+}
+
+// EncryptSecrets encrypts the content of a secrets file with AES-256-GCM
+func EncryptSecrets(plaintext, key []byte) ([]byte, error) {
+	gcm, err := newSecretsCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	sealed := gcm.Seal(nonce, nonce, plaintext, []byte(secretsHeader))
+	return []byte(secretsHeader + base64.StdEncoding.EncodeToString(sealed) + "\n"), nil
+}
+
+// DecryptSecrets decrypts a secrets file encrypted by EncryptSecrets
+func DecryptSecrets(data, key []byte) ([]byte, error) {
+	encoded, found := strings.CutPrefix(strings.TrimSpace(string(data)), secretsHeader)
+	if !found {
+		return nil, errors.New("not an encrypted secrets file")
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errors.New("the encrypted secrets are not valid base64")
+	}
+
+	gcm, err := newSecretsCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("the encrypted secrets are truncated")
+	}
+	plaintext, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], []byte(secretsHeader))
+	if err != nil {
+		return nil, errors.New("wrong key or corrupted secrets file")
+	}
+	return plaintext, nil
+}
+
+// newSecretsCipher returns the AES-GCM cipher of the secrets file
+func newSecretsCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,152 @@
+package params
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeTestSecretsFile encrypts the secrets with a new key set in the environment and sets the file
+// as the secrets file
+func writeTestSecretsFile(t *testing.T, content string) string {
+	encodedKey, err := GenerateSecretsKey()
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	t.Setenv(SecretsKeyEnv, encodedKey)
+	key, err := ParseSecretsKey(encodedKey)
+	if err != nil {
+		t.Fatalf("Failed to parse key: %v", err)
+	}
+
+	data, err := EncryptSecrets([]byte(content), key)
+	if err != nil {
+		t.Fatalf("Failed to encrypt secrets: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "secrets.enc")
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("Failed to write secrets: %v", err)
+	}
+	SetSecretsFile(path)
+	t.Cleanup(func() { SetSecretsFile("") })
+	return path
+}
+
+func TestResolveSecret(t *testing.T) {
+	t.Setenv("PONGHUB_TEST_SECRET", "from-env")
+	secretPath := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(secretPath, []byte("from-file\n"), 0600); err != nil {
+		t.Fatalf("Failed to write secret: %v", err)
+	}
+	writeTestSecretsFile(t, "api_token: from-store\n")
+
+	tests := []struct {
+		ref      string
+		expected string
+		err      string
+	}{
+		{ref: "env:PONGHUB_TEST_SECRET", expected: "from-env"},
+		{ref: "env:PONGHUB_TEST_UNSET", err: "environment variable PONGHUB_TEST_UNSET is not set"},
+		{ref: "file:" + secretPath, expected: "from-file"},
+		{ref: "file:" + secretPath + ".missing", err: "no such file"},
+		{ref: "store:api_token", expected: "from-store"},
+		{ref: "store:missing", err: "secret missing is not set"},
+		{ref: "vault:token", err: `unknown secret provider "vault"`},
+		{ref: "token", err: "expected a reference"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			value, err := resolveSecret(tt.ref)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("Expected an error containing %q, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil || value != tt.expected {
+				t.Errorf("Expected %q, got %q (%v)", tt.expected, value, err)
+			}
+		})
+	}
+}
+
+func TestResolveSecret_StoreChanged(t *testing.T) {
+	path := writeTestSecretsFile(t, "api_token: first\n")
+	if value, _ := resolveSecret("store:api_token"); value != "first" {
+		t.Fatalf("Expected the first secret, got %q", value)
+	}
+
+	// the file is decrypted again when it changes
+	key, _ := ParseSecretsKey(os.Getenv(SecretsKeyEnv))
+	data, err := EncryptSecrets([]byte("api_token: second-value\n"), key)
+	if err != nil {
+		t.Fatalf("Failed to encrypt secrets: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("Failed to write secrets: %v", err)
+	}
+	if value, _ := resolveSecret("store:api_token"); value != "second-value" {
+		t.Errorf("Expected the changed secret, got %q", value)
+	}
+
+	// a wrong key is reported
+	otherKey, _ := GenerateSecretsKey()
+	t.Setenv(SecretsKeyEnv, otherKey)
+	SetSecretsFile("")
+	SetSecretsFile(path)
+	if _, err := resolveSecret("store:api_token"); err == nil || !strings.Contains(err.Error(), "wrong key") {
+		t.Errorf("Expected a wrong key error, got %v", err)
+	}
+}
+
+func TestDecryptSecrets(t *testing.T) {
+	encodedKey, _ := GenerateSecretsKey()
+	key, _ := ParseSecretsKey(encodedKey)
+	data, err := EncryptSecrets([]byte("token: value"), key)
+	if err != nil {
+		t.Fatalf("Failed to encrypt secrets: %v", err)
+	}
+	if strings.Contains(string(data), "value") {
+		t.Error("Expected the secrets to be encrypted")
+	}
+
+	plaintext, err := DecryptSecrets(data, key)
+	if err != nil || string(plaintext) != "token: value" {
+		t.Errorf("Expected the secrets to be decrypted, got %q (%v)", plaintext, err)
+	}
+
+	tampered := []byte(strings.Replace(string(data), secretsHeader, "ponghub-secrets:v2:", 1))
+	if _, err := DecryptSecrets(tampered, key); err == nil {
+		t.Error("Expected a file with another header to be rejected")
+	}
+	if _, err := ParseSecretsKey("c2hvcnQ="); err == nil {
+		t.Error("Expected a short key to be rejected")
+	}
+}
+
+func TestResolveParameters_Secret(t *testing.T) {
+	writeTestSecretsFile(t, "api_token: abcdefghijkl\n")
+	pr := NewParameterResolver()
+
+	if result := pr.ResolveParameters("Bearer {{secret(store:api_token)}}"); result != "Bearer abcdefghijkl" {
+		t.Errorf("Expected the secret to be resolved, got %q", result)
+	}
+
+	// secrets are masked for display
+	result, _ := pr.HighlightChanges("https://example.com/?token={{secret(store:api_token)}}")
+	if result != "https://example.com/?token=a**********l" {
+		t.Errorf("Expected the secret to be masked, got %q", result)
+	}
+
+	// secrets that cannot be read are unresolved with the reason
+	pr.ResolveParameters("{{secret(store:missing)}}")
+	unresolved := pr.Unresolved()
+	if len(unresolved) != 1 || unresolved[0] != "secret(store:missing)" {
+		t.Fatalf("Expected the secret to be unresolved, got %v", unresolved)
+	}
+	if err := pr.SecretError(unresolved[0]); err == nil || !strings.Contains(err.Error(), "not set") {
+		t.Errorf("Expected the reason of the unresolved secret, got %v", err)
+	}
+}
@@ -0,0 +1,154 @@
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cron is a parsed five-field cron expression: minute, hour, day of month, month and day of week
+type Cron struct {
+	minute, hour, dom, month, dow []bool
+
+	// domAny and dowAny are set if the day fields are "*", standard cron matches either day field
+	// if both are restricted
+	domAny, dowAny bool
+}
+
+// cronField describes the value range of a cron field
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	monthNames = map[string]int{"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6, "jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12}
+	dayNames   = map[string]int{"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6}
+
+	cronFields = []cronField{
+		{name: "minute", min: 0, max: 59},
+		{name: "hour", min: 0, max: 23},
+		{name: "day of month", min: 1, max: 31},
+		{name: "month", min: 1, max: 12, names: monthNames},
+		{name: "day of week", min: 0, max: 7, names: dayNames},
+	}
+)
+
+// ParseCron parses a five-field cron expression. Fields support "*", lists, ranges, steps and
+// three-letter month and day names, e.g. "0 2 * * sat,sun" or "*/15 9-17 * * mon-fri".
+func ParseCron(expr string) (*Cron, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("invalid cron expression %q: expected 5 fields, got %d", expr, len(fields))
+	}
+
+	parsed := make([][]bool, len(fields))
+	for i, field := range fields {
+		values, err := parseCronField(field, cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+		}
+		parsed[i] = values
+	}
+
+	// 7 is an alias for Sunday
+	parsed[4][0] = parsed[4][0] || parsed[4][7]
+
+	return &Cron{
+		minute: parsed[0],
+		hour:   parsed[1],
+		dom:    parsed[2],
+		month:  parsed[3],
+		dow:    parsed[4],
+		domAny: fields[2] == "*",
+		dowAny: fields[4] == "*",
+	}, nil
+}
+
+// parseCronField parses a comma-separated cron field into the set of matching values
+func parseCronField(field string, spec cronField) ([]bool, error) {
+	values := make([]bool, spec.max+1)
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			rangePart = part[:i]
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step <= 0 {
+				return nil, fmt.Errorf("invalid step in %s field %q", spec.name, part)
+			}
+		}
+
+		start, end := spec.min, spec.max
+		if rangePart != "*" {
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if start, err = parseCronValue(bounds[0], spec); err != nil {
+				return nil, err
+			}
+			end = start
+			if len(bounds) == 2 {
+				if end, err = parseCronValue(bounds[1], spec); err != nil {
+					return nil, err
+				}
+			} else if step > 1 {
+				// "5/10" means every 10 starting at 5
+				end = spec.max
+			}
+			if end < start {
+				return nil, fmt.Errorf("invalid range in %s field %q", spec.name, part)
+			}
+		}
+
+		for value := start; value <= end; value += step {
+			values[value] = true
+		}
+	}
+	return values, nil
+}
+
+// parseCronValue parses a single number or name of a cron field
+func parseCronValue(value string, spec cronField) (int, error) {
+	if number, ok := spec.names[strings.ToLower(value)]; ok {
+		return number, nil
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil || number < spec.min || number > spec.max {
+		return 0, fmt.Errorf("invalid %s %q", spec.name, value)
+	}
+	return number, nil
+}
+
+// Matches checks if the cron expression fires at the minute of the given time
+func (c *Cron) Matches(t time.Time) bool {
+	if !c.minute[t.Minute()] || !c.hour[t.Hour()] || !c.month[int(t.Month())] {
+		return false
+	}
+
+	domMatch := c.dom[t.Day()]
+	dowMatch := c.dow[int(t.Weekday())]
+	switch {
+	case c.domAny && c.dowAny:
+		return true
+	case c.domAny:
+		return dowMatch
+	case c.dowAny:
+		return domMatch
+	default:
+		return domMatch || dowMatch
+	}
+}
+
+// Previous returns the latest minute at or before t, at most within before it, at which the cron
+// expression fires, and false if it does not fire in that range
+func (c *Cron) Previous(t time.Time, within time.Duration) (time.Time, bool) {
+	current := t.Truncate(time.Minute)
+	for offset := time.Duration(0); offset <= within; offset += time.Minute {
+		if at := current.Add(-offset); c.Matches(at) {
+			return at, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,82 @@
+package schedule
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseCron_Matches(t *testing.T) {
+	tests := []struct {
+		expr     string
+		time     string
+		expected bool
+	}{
+		{expr: "* * * * *", time: "2025-03-05 13:37", expected: true},
+		{expr: "0 2 * * *", time: "2025-03-05 02:00", expected: true},
+		{expr: "0 2 * * *", time: "2025-03-05 02:01", expected: false},
+		{expr: "*/15 9-17 * * mon-fri", time: "2025-03-05 09:45", expected: true},
+		{expr: "*/15 9-17 * * mon-fri", time: "2025-03-08 09:45", expected: false},
+		{expr: "0 22 * * sat,sun", time: "2025-03-09 22:00", expected: true},
+		{expr: "0 0 * * 7", time: "2025-03-09 00:00", expected: true},
+		{expr: "30 4 1 jan-jun *", time: "2025-03-01 04:30", expected: true},
+		{expr: "30 4 1 jan-jun *", time: "2025-07-01 04:30", expected: false},
+		{expr: "5/20 * * * *", time: "2025-03-05 10:45", expected: true},
+		{expr: "5/20 * * * *", time: "2025-03-05 10:40", expected: false},
+		// both day fields restricted: either one matches
+		{expr: "0 0 1 * mon", time: "2025-03-03 00:00", expected: true},
+		{expr: "0 0 1 * mon", time: "2025-03-01 00:00", expected: true},
+		{expr: "0 0 1 * mon", time: "2025-03-04 00:00", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr+" "+tt.time, func(t *testing.T) {
+			cron, err := ParseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseCron failed: %v", err)
+			}
+			at, _ := time.Parse("2006-01-02 15:04", tt.time)
+			if cron.Matches(at) != tt.expected {
+				t.Errorf("Expected Matches(%s) to be %v", tt.time, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseCron_Errors(t *testing.T) {
+	tests := []struct {
+		expr   string
+		errMsg string
+	}{
+		{expr: "0 2 * *", errMsg: "expected 5 fields"},
+		{expr: "60 * * * *", errMsg: "invalid minute"},
+		{expr: "* 5-1 * * *", errMsg: "invalid range"},
+		{expr: "*/0 * * * *", errMsg: "invalid step"},
+		{expr: "* * * * funday", errMsg: "invalid day of week"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := ParseCron(tt.expr)
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Expected error containing %q, got %v", tt.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestCron_Previous(t *testing.T) {
+	cron, err := ParseCron("0 9 * * mon")
+	if err != nil {
+		t.Fatalf("ParseCron failed: %v", err)
+	}
+
+	at, _ := time.Parse("2006-01-02 15:04", "2025-03-05 13:37")
+	previous, found := cron.Previous(at, 7*24*time.Hour)
+	if !found || previous.Format("2006-01-02 15:04") != "2025-03-03 09:00" {
+		t.Errorf("Expected the previous Monday 09:00, got %v, %v", previous, found)
+	}
+	if _, found := cron.Previous(at, 24*time.Hour); found {
+		t.Error("Expected no match within a day")
+	}
+}
@@ -0,0 +1,129 @@
+package schedule
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// timeLayouts are the accepted layouts of one-off window boundaries besides RFC 3339
+var timeLayouts = []string{"2006-01-02 15:04", "2006-01-02T15:04", "2006-01-02"}
+
+// Window is a time range that is either one-off, from Start to End, or recurring,
+// starting whenever Cron fires and lasting for Duration
+type Window struct {
+	Start, End time.Time
+	Cron       *Cron
+	Duration   time.Duration
+	Location   *time.Location
+}
+
+// NewOneOffWindow creates a window between two times given in RFC 3339 or "2006-01-02 15:04" format,
+// times without offset are interpreted in the given time zone, or local time if it is empty
+func NewOneOffWindow(start, end, timezone string) (*Window, error) {
+	location, err := LoadLocation(timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	window := &Window{Location: location}
+	if window.Start, err = parseTime(start, location); err != nil {
+		return nil, err
+	}
+	if window.End, err = parseTime(end, location); err != nil {
+		return nil, err
+	}
+	if !window.End.After(window.Start) {
+		return nil, fmt.Errorf("window end %q is not after its start %q", end, start)
+	}
+	return window, nil
+}
+
+// NewRecurringWindow creates a window that starts whenever the cron expression fires in the
+// given time zone, or local time if it is empty, and lasts for the given duration, e.g. "2h"
+func NewRecurringWindow(cron, duration, timezone string) (*Window, error) {
+	location, err := LoadLocation(timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	parsedCron, err := ParseCron(cron)
+	if err != nil {
+		return nil, err
+	}
+	parsedDuration, err := time.ParseDuration(duration)
+	if err != nil {
+		return nil, fmt.Errorf("invalid window duration %q: %w", duration, err)
+	}
+	if parsedDuration < time.Minute {
+		return nil, fmt.Errorf("window duration %q is shorter than a minute", duration)
+	}
+	return &Window{Cron: parsedCron, Duration: parsedDuration, Location: location}, nil
+}
+
+// NewDailyWindow creates a window from a start to an end time of day in "15:04" format,
+// on the given days of the week, e.g. ["mon", "tue"], or every day if days is empty.
+// A window whose end is before its start ends on the next day.
+func NewDailyWindow(start, end string, days []string, timezone string) (*Window, error) {
+	startTime, err := time.Parse("15:04", start)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start time %q, expected HH:MM", start)
+	}
+	endTime, err := time.Parse("15:04", end)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end time %q, expected HH:MM", end)
+	}
+
+	duration := endTime.Sub(startTime)
+	if duration <= 0 {
+		duration += 24 * time.Hour
+	}
+
+	dayField := "*"
+	if len(days) > 0 {
+		dayField = strings.Join(days, ",")
+	}
+	cron := fmt.Sprintf("%d %d * * %s", startTime.Minute(), startTime.Hour(), dayField)
+	return NewRecurringWindow(cron, duration.String(), timezone)
+}
+
+// ActiveAt checks if the window is active at the given time and returns when it ends
+func (w *Window) ActiveAt(t time.Time) (bool, time.Time) {
+	if w.Cron == nil {
+		if !t.Before(w.Start) && t.Before(w.End) {
+			return true, w.End
+		}
+		return false, time.Time{}
+	}
+
+	// look for the latest start of the window within its duration before t
+	if start, found := w.Cron.Previous(t.In(w.Location), w.Duration-time.Minute); found {
+		return true, start.Add(w.Duration)
+	}
+	return false, time.Time{}
+}
+
+// LoadLocation loads the time zone with the given name, or local time if it is empty
+func LoadLocation(timezone string) (*time.Location, error) {
+	if timezone == "" {
+		return time.Local, nil
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone %q: %w", timezone, err)
+	}
+	return location, nil
+}
+
+// parseTime parses a window boundary in one of the accepted layouts
+func parseTime(value string, location *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, value, location); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q, expected RFC 3339 or YYYY-MM-DD HH:MM", value)
+}
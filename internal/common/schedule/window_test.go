@@ -0,0 +1,85 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+// mustParse parses a time in UTC for the tests
+func mustParse(t *testing.T, value string) time.Time {
+	t.Helper()
+	parsed, err := time.Parse("2006-01-02 15:04", value)
+	if err != nil {
+		t.Fatalf("Failed to parse %s: %v", value, err)
+	}
+	return parsed
+}
+
+func TestWindow_OneOff(t *testing.T) {
+	window, err := NewOneOffWindow("2025-03-05 02:00", "2025-03-05T04:00:00Z", "UTC")
+	if err != nil {
+		t.Fatalf("NewOneOffWindow failed: %v", err)
+	}
+
+	if active, _ := window.ActiveAt(mustParse(t, "2025-03-05 01:59")); active {
+		t.Error("Expected the window to be inactive before its start")
+	}
+	active, end := window.ActiveAt(mustParse(t, "2025-03-05 03:00"))
+	if !active || !end.Equal(mustParse(t, "2025-03-05 04:00")) {
+		t.Errorf("Expected the window to be active until 04:00, got %v, %v", active, end)
+	}
+	if active, _ := window.ActiveAt(mustParse(t, "2025-03-05 04:00")); active {
+		t.Error("Expected the window to be inactive at its end")
+	}
+
+	if _, err := NewOneOffWindow("2025-03-05 04:00", "2025-03-05 02:00", ""); err == nil {
+		t.Error("Expected an error for a window ending before its start")
+	}
+}
+
+func TestWindow_Recurring(t *testing.T) {
+	window, err := NewRecurringWindow("0 2 * * sun", "3h", "Asia/Shanghai")
+	if err != nil {
+		t.Fatalf("NewRecurringWindow failed: %v", err)
+	}
+
+	// Sunday 02:00 in Shanghai is Saturday 18:00 UTC
+	active, end := window.ActiveAt(mustParse(t, "2025-03-08 19:30"))
+	if !active || !end.Equal(mustParse(t, "2025-03-08 21:00")) {
+		t.Errorf("Expected the window to be active until 21:00 UTC, got %v, %v", active, end)
+	}
+	if active, _ := window.ActiveAt(mustParse(t, "2025-03-08 21:00")); active {
+		t.Error("Expected the window to be inactive after its duration")
+	}
+	if active, _ := window.ActiveAt(mustParse(t, "2025-03-09 19:30")); active {
+		t.Error("Expected the window to be inactive on Monday")
+	}
+}
+
+func TestWindow_Daily(t *testing.T) {
+	window, err := NewDailyWindow("22:00", "07:00", []string{"fri", "sat"}, "UTC")
+	if err != nil {
+		t.Fatalf("NewDailyWindow failed: %v", err)
+	}
+
+	tests := []struct {
+		time     string
+		expected bool
+	}{
+		{time: "2025-03-07 21:59", expected: false}, // Friday
+		{time: "2025-03-07 23:00", expected: true},
+		{time: "2025-03-08 06:59", expected: true}, // Saturday morning, started on Friday
+		{time: "2025-03-08 07:00", expected: false},
+		{time: "2025-03-09 03:00", expected: true}, // Sunday morning, started on Saturday
+		{time: "2025-03-10 03:00", expected: false},
+	}
+	for _, tt := range tests {
+		if active, _ := window.ActiveAt(mustParse(t, tt.time)); active != tt.expected {
+			t.Errorf("Expected ActiveAt(%s) to be %v", tt.time, tt.expected)
+		}
+	}
+
+	if _, err := NewDailyWindow("25:00", "07:00", nil, ""); err == nil {
+		t.Error("Expected an error for an invalid start time")
+	}
+}
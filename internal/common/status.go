@@ -8,9 +8,13 @@ func calcMergedStatus(statusList []chk_result.CheckResult) chk_result.CheckResul
 		return chk_result.NONE
 	}
 
-	hasNone, hasAll := false, false
+	hasNone, hasAll, hasImpacted := false, false, false
 	for _, s := range statusList {
 		switch s {
+		case chk_result.MAINTENANCE:
+			return chk_result.MAINTENANCE
+		case chk_result.IMPACTED:
+			hasImpacted = true
 		case chk_result.NONE:
 			hasNone = true
 		case chk_result.ALL:
@@ -19,6 +23,8 @@ func calcMergedStatus(statusList []chk_result.CheckResult) chk_result.CheckResul
 	}
 
 	switch {
+	case hasImpacted:
+		return chk_result.IMPACTED
 	case hasNone && !hasAll:
 		return chk_result.NONE
 	case !hasNone && hasAll:
@@ -1,68 +1,93 @@
 package configure
 
 import (
-	"log"
-	"os"
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
 
 	"github.com/wcy-dt/ponghub/internal/common/params"
 	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
 	"github.com/wcy-dt/ponghub/internal/types/types/default_config"
-
-	"gopkg.in/yaml.v3"
 )
 
-// ReadConfigs loads the configuration from a YAML file at the specified path
+// ReadConfigs loads the configuration from a YAML file, a directory of YAML files or a glob pattern
+// at the specified path, merges the files they include and validates it. All problems found are
+// returned together as configure.ValidationErrors with their file, line and column, so that no check
+// runs with an invalid configuration.
 func ReadConfigs(path string) (*configure.Configure, error) {
-	// Read the configuration file
-	f, err := os.Open(path)
+	paths, err := expandConfigPath(path)
 	if err != nil {
 		return nil, err
 	}
-	defer func(f *os.File) {
-		if err := f.Close(); err != nil {
-			log.Println("Error closing config file:", err)
-		}
-	}(f)
 
-	// Decode the YAML configuration
-	cfg := new(configure.Configure)
-	decoder := yaml.NewDecoder(f)
-	if err := decoder.Decode(cfg); err != nil {
-		log.Fatalln("Failed to decode YAML config:", err)
+	// Read the configuration files and the files they include, and merge them
+	loader := newConfigLoader()
+	for _, file := range paths {
+		loader.load(file)
 	}
+	if len(loader.files) == 0 {
+		loader.sort(loader.errs)
+		return nil, loader.errs
+	}
+	cfg := loader.merge()
+	cfg.Files = loader.paths
 
-	// Resolve dynamic parameters
-	resolveConfigParameters(cfg)
-
-	// Set default values for the configuration
+	// Set default values for the configuration, before the parameters of the inherited settings are resolved
 	setDefaultConfigs(cfg)
 
-	if len(cfg.Services) == 0 {
-		log.Fatalln("No services defined in the configuration file")
+	// Resolve dynamic parameters
+	errs := resolveConfigParameters(cfg)
+
+	errs = append(errs, Validate(cfg)...)
+	loader.locate(errs)
+	errs = append(loader.errs, errs...)
+	if len(errs) > 0 {
+		loader.sort(errs)
+		return nil, errs
 	}
 	return cfg, nil
 }
 
-// resolveConfigParameters resolves dynamic parameters in configuration
-func resolveConfigParameters(cfg *configure.Configure) {
+// resolveConfigParameters resolves dynamic parameters in configuration and returns the parameters
+// that cannot be resolved, the resolved values are not included as they may contain secrets
+func resolveConfigParameters(cfg *configure.Configure) configure.ValidationErrors {
+	params.SetSecretsFile(cfg.SecretsFile)
 	resolver := params.NewParameterResolver()
+	var errs configure.ValidationErrors
+	resolve := func(path, value string) string {
+		resolved := resolver.ResolveParameters(value)
+		for _, param := range resolver.Unresolved() {
+			if strings.HasPrefix(param, "env(") {
+				errs.Add(path, fmt.Sprintf("environment variable %s of {{%s}} is not set", param[4:len(param)-1], param))
+			} else if strings.HasPrefix(param, "secret(") {
+				errs.Add(path, fmt.Sprintf("secret {{%s}} cannot be read: %v", param, resolver.SecretError(param)))
+			} else {
+				errs.Add(path, fmt.Sprintf("unknown parameter {{%s}}", param))
+			}
+		}
+		return resolved
+	}
 
 	for i := range cfg.Services {
 		for j := range cfg.Services[i].Endpoints {
 			endpoint := &cfg.Services[i].Endpoints[j]
+			path := fmt.Sprintf("services[%d].endpoints[%d]", i, j)
 
 			// Resolve parameters
-			endpoint.ParsedURL = resolver.ResolveParameters(endpoint.URL)
-			endpoint.ParsedBody = resolver.ResolveParameters(endpoint.Body)
-			endpoint.ParsedResponseRegex = resolver.ResolveParameters(endpoint.ResponseRegex)
+			endpoint.ParsedURL = resolve(path+".url", endpoint.URL)
+			endpoint.ParsedBody = resolve(path+".body", endpoint.Body)
+			endpoint.ParsedResponseRegex = resolve(path+".response_regex", endpoint.ResponseRegex)
 			if endpoint.Headers != nil {
 				endpoint.ParsedHeaders = make(map[string]string)
-				for key, value := range endpoint.Headers {
-					endpoint.ParsedHeaders[key] = resolver.ResolveParameters(value)
+				for _, key := range slices.Sorted(maps.Keys(endpoint.Headers)) {
+					endpoint.ParsedHeaders[key] = resolve(path+".headers."+key, endpoint.Headers[key])
 				}
 			}
 		}
 	}
+	return errs
 }
 
 // setDefaultConfigs sets default values for the configuration fields
@@ -74,32 +99,104 @@ func setDefaultConfigs(cfg *configure.Configure) {
 	default_config.SetDefaultDisplayNum(&cfg.DisplayNum)
 
 	for i := range cfg.Services {
-		default_config.SetDefaultTimeout(&cfg.Services[i].Timeout)
-		default_config.SetDefaultMaxRetryTimes(&cfg.Services[i].MaxRetryTimes)
+		setEndpointDefaults(cfg, &cfg.Services[i])
 	}
 
 	// Set default notification configuration
 	setDefaultNotifications(cfg)
 }
 
+// setEndpointDefaults sets the settings of the endpoints of a service that they do not set themselves.
+// The defaults of the service take precedence over its timeout and retries, then come the global
+// defaults and finally the global timeout and retries.
+func setEndpointDefaults(cfg *configure.Configure, service *configure.Service) {
+	scopes := []*configure.EndpointDefaults{
+		service.Defaults,
+		{Timeout: service.Timeout, MaxRetryTimes: service.MaxRetryTimes},
+		cfg.Defaults,
+		{Timeout: cfg.Timeout, MaxRetryTimes: cfg.MaxRetryTimes},
+	}
+	for i := range service.Endpoints {
+		for _, defaults := range scopes {
+			if defaults != nil {
+				inheritDefaults(&service.Endpoints[i], defaults)
+			}
+		}
+	}
+}
+
+// inheritDefaults sets the settings of an endpoint that are not set yet from defaults.
+// Headers are inherited unless the endpoint sets a header of the same name, tags are added.
+func inheritDefaults(endpoint *configure.Endpoint, defaults *configure.EndpointDefaults) {
+	endpoint.Method = cmp.Or(endpoint.Method, defaults.Method)
+	endpoint.StatusCode = cmp.Or(endpoint.StatusCode, defaults.StatusCode)
+	endpoint.ResponseRegex = cmp.Or(endpoint.ResponseRegex, defaults.ResponseRegex)
+	endpoint.Timeout = cmp.Or(endpoint.Timeout, defaults.Timeout)
+	endpoint.MaxRetryTimes = cmp.Or(endpoint.MaxRetryTimes, defaults.MaxRetryTimes)
+	if endpoint.SkipTLSVerify == nil {
+		endpoint.SkipTLSVerify = defaults.SkipTLSVerify
+	}
+
+	for name, value := range defaults.Headers {
+		if hasHeader(endpoint.Headers, name) {
+			continue
+		}
+		if endpoint.Headers == nil {
+			endpoint.Headers = make(map[string]string)
+		}
+		endpoint.Headers[name] = value
+	}
+	for _, tag := range defaults.Tags {
+		if !slices.Contains(endpoint.Tags, tag) {
+			endpoint.Tags = append(endpoint.Tags, tag)
+		}
+	}
+}
+
+// hasHeader reports whether the headers contain a header with the given name, which is case-insensitive
+func hasHeader(headers map[string]string, name string) bool {
+	for key := range headers {
+		if strings.EqualFold(key, name) {
+			return true
+		}
+	}
+	return false
+}
+
 // setDefaultNotifications sets default values for notification configuration
 func setDefaultNotifications(cfg *configure.Configure) {
 	if cfg.Notifications == nil {
 		// If no notifications configured, enable default (GitHub Actions exit 1)
 		cfg.Notifications = &configure.NotificationConfig{
-			Enabled: true,
-			Methods: []string{"default"},
-			Default: &configure.DefaultConfig{Enabled: true},
+			Enabled:        true,
+			Methods:        []string{"default"},
+			Timeout:        default_config.GetDefaultDeliveryTimeout(),
+			ChannelConfigs: configure.ChannelConfigs{Default: &configure.DefaultConfig{Enabled: true}},
 		}
 		return
 	}
 
+	default_config.SetDefaultDeliveryTimeout(&cfg.Notifications.Timeout)
+	if digest := cfg.Notifications.Digest; digest != nil {
+		if digest.Schedule == "" {
+			digest.Schedule = default_config.GetDefaultDigestSchedule(strings.EqualFold(digest.Period, "weekly"))
+		}
+		default_config.SetDefaultDigestSlowest(&digest.Slowest)
+		default_config.SetDefaultDigestCertDays(&digest.CertDays)
+	}
+
 	// Check if other notification methods are configured
 	hasOtherMethods := false
 	for _, method := range cfg.Notifications.Methods {
-		if method == "email" || method == "webhook" {
+		switch method {
+		case "email", "webhook", "telegram", "dingtalk", "feishu", "lark", "wecom", "pagerduty", "opsgenie",
+			"ntfy", "gotify", "pushover", "matrix", "command":
+			hasOtherMethods = true
+		}
+	}
+	for _, channel := range cfg.Notifications.Channels {
+		if channel != nil && !strings.EqualFold(channel.Type, "default") {
 			hasOtherMethods = true
-			break
 		}
 	}
 
@@ -0,0 +1,273 @@
+package configure
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/wcy-dt/ponghub/internal/common/params"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+)
+
+// writeTestConfig writes the configuration to a temporary file and returns its path
+func writeTestConfig(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	return path
+}
+
+func TestReadConfigs(t *testing.T) {
+	t.Setenv("PONGHUB_TEST_HOST", "example.com")
+	path := writeTestConfig(t, `
+services:
+  - name: "api"
+    endpoints:
+      - url: "https://{{env(PONGHUB_TEST_HOST)}}/health"
+        headers:
+          X-Request-ID: "{{uuid}}"
+`)
+
+	cfg, err := ReadConfigs(path)
+	if err != nil {
+		t.Fatalf("ReadConfigs failed: %v", err)
+	}
+	if cfg.Services[0].Endpoints[0].ParsedURL != "https://example.com/health" {
+		t.Errorf("Expected the URL to be resolved, got %q", cfg.Services[0].Endpoints[0].ParsedURL)
+	}
+	if cfg.Timeout <= 0 || cfg.Notifications == nil {
+		t.Error("Expected the defaults to be set")
+	}
+}
+
+func TestReadConfigs_ValidationErrors(t *testing.T) {
+	path := writeTestConfig(t, `
+timeout: "soon"
+services:
+  - name: "api"
+    endpoints:
+      - url: "https://example.com"
+        headers:
+          Authorization: "Bearer {{env(PONGHUB_TEST_UNSET)}}"
+    maintenance:
+      - start: "tomorrow"
+        end: "2025-03-05 04:00"
+`)
+
+	_, err := ReadConfigs(path)
+	var errs configure.ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Expected validation errors, got %v", err)
+	}
+
+	expected := []string{
+		path + ":2:10: timeout: expected integer, got string",
+		path + ":8:26: services[0].endpoints[0].headers.Authorization: environment variable PONGHUB_TEST_UNSET of {{env(PONGHUB_TEST_UNSET)}} is not set",
+		path + ":10:9: services[0].maintenance[0]: ",
+	}
+	if len(errs) != len(expected) {
+		t.Fatalf("Expected %d errors, got %d:\n%v", len(expected), len(errs), err)
+	}
+	for i, validationError := range errs {
+		if message := validationError.Error(); len(message) < len(expected[i]) || message[:len(expected[i])] != expected[i] {
+			t.Errorf("Expected error %d to start with %q, got %q", i, expected[i], message)
+		}
+	}
+}
+
+func TestReadConfigs_Defaults(t *testing.T) {
+	path := writeTestConfig(t, `
+timeout: 8
+defaults:
+  headers:
+    User-Agent: "PongHub"
+    Authorization: "Bearer global"
+  status_code: 204
+  max_retry_times: 4
+  tags: ["prod"]
+services:
+  - name: "payments"
+    timeout: 6
+    defaults:
+      method: "POST"
+      headers:
+        authorization: "Bearer payments"
+      skip_tls_verify: true
+      tags: ["payments"]
+    endpoints:
+      - url: "https://payments.example.com"
+      - url: "https://payments.example.com/health"
+        method: "GET"
+        timeout: 2
+        status_code: 200
+        skip_tls_verify: false
+        tags: ["health", "prod"]
+  - name: "web"
+    endpoints:
+      - url: "https://example.com"
+`)
+
+	cfg, err := ReadConfigs(path)
+	if err != nil {
+		t.Fatalf("ReadConfigs failed: %v", err)
+	}
+
+	inherited := cfg.Services[0].Endpoints[0]
+	if inherited.Method != "POST" || inherited.StatusCode != 204 || inherited.Timeout != 6 || inherited.MaxRetryTimes != 4 {
+		t.Errorf("Expected the settings of the service and the global defaults, got %+v", inherited)
+	}
+	if inherited.SkipTLSVerify == nil || !*inherited.SkipTLSVerify {
+		t.Error("Expected the TLS verification to be skipped")
+	}
+	if len(inherited.ParsedHeaders) != 2 || inherited.ParsedHeaders["authorization"] != "Bearer payments" || inherited.ParsedHeaders["User-Agent"] != "PongHub" {
+		t.Errorf("Expected the headers of the service to override the global ones by name, got %v", inherited.ParsedHeaders)
+	}
+	if !reflect.DeepEqual(inherited.Tags, []string{"payments", "prod"}) {
+		t.Errorf("Expected the tags of the service and the global defaults, got %v", inherited.Tags)
+	}
+
+	overridden := cfg.Services[0].Endpoints[1]
+	if overridden.Method != "GET" || overridden.StatusCode != 200 || overridden.Timeout != 2 || *overridden.SkipTLSVerify {
+		t.Errorf("Expected the settings of the endpoint to override the defaults, got %+v", overridden)
+	}
+	if !reflect.DeepEqual(overridden.Tags, []string{"health", "prod", "payments"}) {
+		t.Errorf("Expected the tags of the defaults to be added once, got %v", overridden.Tags)
+	}
+
+	global := cfg.Services[1].Endpoints[0]
+	if global.Method != "" || global.Timeout != 8 || global.ParsedHeaders["Authorization"] != "Bearer global" {
+		t.Errorf("Expected the global settings and defaults, got %+v", global)
+	}
+}
+
+func TestReadConfigs_Groups(t *testing.T) {
+	path := writeTestConfig(t, `
+services:
+  - name: "website"
+    group: "Public Web"
+    tags: ["frontend"]
+    endpoints:
+      - url: "https://example.com"
+notifications:
+  enabled: true
+  channels:
+    - name: "web-team"
+      type: "ntfy"
+      groups: ["public web", "Internal APIs"]
+      ntfy:
+        topic: "web"
+`)
+
+	_, err := ReadConfigs(path)
+	var errs configure.ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Expected validation errors, got %v", err)
+	}
+	expected := path + `:13:30: notifications.channels[0].groups[1]: unknown group "Internal APIs"`
+	if len(errs) != 1 || errs[0].Error() != expected {
+		t.Errorf("Expected %q, got:\n%v", expected, err)
+	}
+}
+
+func TestReadConfigs_Dependencies(t *testing.T) {
+	path := writeTestConfig(t, `
+services:
+  - name: "auth"
+    depends_on: ["billing"]
+    endpoints:
+      - url: "https://auth.example.com"
+  - name: "orders"
+    depends_on: ["auth", "orders", "db"]
+    endpoints:
+      - url: "https://orders.example.com"
+  - name: "billing"
+    depends_on: ["orders"]
+    endpoints:
+      - url: "https://billing.example.com"
+`)
+
+	_, err := ReadConfigs(path)
+	var errs configure.ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Expected validation errors, got %v", err)
+	}
+
+	expected := []string{
+		path + `:8:18: services[1].depends_on[0]: dependency cycle auth -> billing -> orders -> auth`,
+		path + `:8:26: services[1].depends_on[1]: a service cannot depend on itself`,
+		path + `:8:36: services[1].depends_on[2]: unknown service "db"`,
+	}
+	if len(errs) != len(expected) {
+		t.Fatalf("Expected %d errors, got %d:\n%v", len(expected), len(errs), err)
+	}
+	for i, validationError := range errs {
+		if message := validationError.Error(); message != expected[i] {
+			t.Errorf("Expected error %d to be %q, got %q", i, expected[i], message)
+		}
+	}
+}
+
+func TestReadConfigs_Secrets(t *testing.T) {
+	encodedKey, err := params.GenerateSecretsKey()
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	t.Setenv(params.SecretsKeyEnv, encodedKey)
+	t.Setenv("PONGHUB_TEST_TOKEN", "env-token")
+	key, _ := params.ParseSecretsKey(encodedKey)
+	secrets, err := params.EncryptSecrets([]byte("api_token: store-token\n"), key)
+	if err != nil {
+		t.Fatalf("Failed to encrypt secrets: %v", err)
+	}
+
+	// the secrets file is relative to the configuration file
+	dir := writeTestFiles(t, map[string]string{
+		"config.yaml": `
+secrets_file: "secrets/app.enc"
+services:
+  - name: "api"
+    endpoints:
+      - url: "https://example.com/?token={{secret(store:api_token)}}"
+        headers:
+          Authorization: "Bearer {{secret(env:PONGHUB_TEST_TOKEN)}}"
+          X-Missing: "{{secret(store:missing)}}"
+`,
+		"secrets/app.enc": string(secrets),
+	})
+	t.Cleanup(func() { params.SetSecretsFile("") })
+
+	_, err = ReadConfigs(filepath.Join(dir, "config.yaml"))
+	var errs configure.ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Expected validation errors, got %v", err)
+	}
+	expected := "services[0].endpoints[0].headers.X-Missing: secret {{secret(store:missing)}} cannot be read: secret missing is not set in " +
+		filepath.Join(dir, "secrets", "app.enc")
+	if len(errs) != 1 || !strings.HasSuffix(errs[0].Error(), expected) {
+		t.Fatalf("Expected %q, got:\n%v", expected, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(`
+secrets_file: "secrets/app.enc"
+services:
+  - name: "api"
+    endpoints:
+      - url: "https://example.com/?token={{secret(store:api_token)}}"
+        headers:
+          Authorization: "Bearer {{secret(env:PONGHUB_TEST_TOKEN)}}"
+`), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	cfg, err := ReadConfigs(filepath.Join(dir, "config.yaml"))
+	if err != nil {
+		t.Fatalf("ReadConfigs failed: %v", err)
+	}
+	endpoint := cfg.Services[0].Endpoints[0]
+	if endpoint.ParsedURL != "https://example.com/?token=store-token" || endpoint.ParsedHeaders["Authorization"] != "Bearer env-token" {
+		t.Errorf("Expected the secrets to be resolved, got %q and %v", endpoint.ParsedURL, endpoint.ParsedHeaders)
+	}
+}
@@ -0,0 +1,245 @@
+package configure
+
+import (
+	"cmp"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/wcy-dt/ponghub/internal/schema"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+
+	"gopkg.in/yaml.v3"
+)
+
+// servicePathPattern matches the index of a service at the start of a validation path and the rest of the path
+var servicePathPattern = regexp.MustCompile(`^services\[(\d+)](.*)$`)
+
+// configFile is a configuration file read by ReadConfigs with the document it was decoded from
+type configFile struct {
+	path string
+	root yaml.Node
+	cfg  configure.Configure
+}
+
+// serviceOrigin is the file of a merged service and its index in the file
+type serviceOrigin struct {
+	file  *configFile
+	index int
+}
+
+// configLoader reads configuration files and the files they include, and merges them into one configuration.
+// Services are appended in the order the files are read, a global setting can only be set in one file.
+type configLoader struct {
+	loaded   map[string]bool
+	paths    []string
+	files    []*configFile
+	errs     configure.ValidationErrors
+	services []serviceOrigin
+	settings map[string]*configFile
+}
+
+// newConfigLoader creates a loader that has not read any file yet
+func newConfigLoader() *configLoader {
+	return &configLoader{loaded: make(map[string]bool), settings: make(map[string]*configFile)}
+}
+
+// expandConfigPath returns the configuration files at a path, which is a file, a directory whose
+// .yaml and .yml files are read in lexical order, or a glob pattern such as "services/*.yaml"
+func expandConfigPath(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	switch {
+	case err == nil && !info.IsDir():
+		return []string{path}, nil
+	case err == nil:
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		var files []string
+		for _, entry := range entries {
+			if extension := filepath.Ext(entry.Name()); !entry.IsDir() && (extension == ".yaml" || extension == ".yml") {
+				files = append(files, filepath.Join(path, entry.Name()))
+			}
+		}
+		if len(files) == 0 {
+			return nil, fmt.Errorf("no configuration files in %s: %w", path, fs.ErrNotExist)
+		}
+		return files, nil
+	case !strings.ContainsAny(path, "*?["):
+		return nil, err
+	}
+
+	matches, err := filepath.Glob(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %s: %w", path, err)
+	}
+	var files []string
+	for _, match := range matches {
+		if info, err := os.Stat(match); err == nil && !info.IsDir() {
+			files = append(files, match)
+		}
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no configuration files match %s: %w", path, fs.ErrNotExist)
+	}
+	return files, nil
+}
+
+// load reads a configuration file, checks it against the schema and loads the files it includes
+// after it. Each file is read once, so that include cycles end.
+func (l *configLoader) load(path string) {
+	absolute, err := filepath.Abs(path)
+	if err != nil {
+		absolute = path
+	}
+	if l.loaded[absolute] {
+		return
+	}
+	l.loaded[absolute] = true
+	l.paths = append(l.paths, path)
+
+	file := &configFile{path: path}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		l.addErrors(file, configure.ValidationErrors{{Message: err.Error()}})
+		return
+	}
+	if err := yaml.Unmarshal(content, &file.root); err != nil {
+		l.addErrors(file, decodeErrors(err))
+		return
+	}
+
+	// Check the document against the JSON Schema of the configuration and decode it,
+	// values that cannot be decoded are reported by the schema unless it found no problem
+	errs := schema.Validate(&file.root)
+	if file.root.Kind != 0 {
+		if err := file.root.Decode(&file.cfg); err != nil && len(errs) == 0 {
+			errs = append(errs, decodeErrors(err)...)
+		}
+	}
+	l.addErrors(file, errs)
+	l.files = append(l.files, file)
+
+	// The secrets file and the included paths are relative to the file setting them
+	if file.cfg.SecretsFile != "" && !filepath.IsAbs(file.cfg.SecretsFile) {
+		file.cfg.SecretsFile = filepath.Join(filepath.Dir(path), file.cfg.SecretsFile)
+	}
+	for i, pattern := range file.cfg.Include {
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(filepath.Dir(path), pattern)
+		}
+		included, err := expandConfigPath(pattern)
+		if err != nil {
+			l.addErrors(file, configure.ValidationErrors{{Path: fmt.Sprintf("include[%d]", i), Message: err.Error()}})
+			continue
+		}
+		for _, includedPath := range included {
+			l.load(includedPath)
+		}
+	}
+}
+
+// merge merges the files read into one configuration. Services with the name of a service of the same
+// or another file are reported, as are global settings that are set in several files.
+func (l *configLoader) merge() *configure.Configure {
+	cfg := new(configure.Configure)
+	names := make(map[string]serviceOrigin)
+	for _, file := range l.files {
+		for i, service := range file.cfg.Services {
+			origin := serviceOrigin{file: file, index: i}
+			if first, exists := names[service.Name]; exists {
+				message := fmt.Sprintf("service name %q is already used by services[%d]", service.Name, first.index)
+				if first.file != file {
+					message += " of " + first.file.path
+				}
+				l.addErrors(file, configure.ValidationErrors{{Path: fmt.Sprintf("services[%d].name", i), Message: message}})
+			} else if service.Name != "" {
+				names[service.Name] = origin
+			}
+			cfg.Services = append(cfg.Services, service)
+			l.services = append(l.services, origin)
+		}
+		l.mergeSettings(cfg, file)
+	}
+	return cfg
+}
+
+// mergeSettings copies the global settings set in a file to the merged configuration. A setting that is
+// already set in another file is reported, so that the files of different teams cannot override each other.
+func (l *configLoader) mergeSettings(cfg *configure.Configure, file *configFile) {
+	merged, settings := reflect.ValueOf(cfg).Elem(), reflect.ValueOf(&file.cfg).Elem()
+	for i := range merged.NumField() {
+		key, _, _ := strings.Cut(merged.Type().Field(i).Tag.Get("yaml"), ",")
+		if key == "services" || key == "include" || key == "-" || mappingValue(findNode(&file.root, ""), key) == nil {
+			continue
+		}
+
+		if other, exists := l.settings[key]; exists {
+			l.addErrors(file, configure.ValidationErrors{{
+				Path:    key,
+				Message: fmt.Sprintf("%s is already set in %s, global settings can only be set in one file", key, other.path),
+			}})
+			continue
+		}
+		l.settings[key] = file
+		merged.Field(i).Set(settings.Field(i))
+	}
+}
+
+// locate sets the file, the line and the column of the validation errors of the merged configuration.
+// The index of a service in the path is made relative to its file, other paths are located in the file
+// that sets the global setting, or in the first file.
+func (l *configLoader) locate(errs configure.ValidationErrors) {
+	for i := range errs {
+		file := l.files[0]
+		key, _, _ := strings.Cut(errs[i].Path, ".")
+		if match := servicePathPattern.FindStringSubmatch(errs[i].Path); match != nil {
+			if index, _ := strconv.Atoi(match[1]); index < len(l.services) {
+				origin := l.services[index]
+				file = origin.file
+				errs[i].Path = fmt.Sprintf("services[%d]%s", origin.index, match[2])
+			}
+		} else if settingFile, exists := l.settings[key]; exists {
+			file = settingFile
+		}
+		l.setPosition(&errs[i], file)
+	}
+}
+
+// addErrors appends validation errors found in a file, located at the nodes of their paths
+func (l *configLoader) addErrors(file *configFile, errs configure.ValidationErrors) {
+	for i := range errs {
+		l.setPosition(&errs[i], file)
+	}
+	l.errs = append(l.errs, errs...)
+}
+
+// setPosition sets the file of a validation error, and its line and column to the node at its path
+// or to its closest ancestor if the path does not exist in the file
+func (l *configLoader) setPosition(validationError *configure.ValidationError, file *configFile) {
+	validationError.File = file.path
+	if validationError.Line > 0 {
+		return
+	}
+	if node := findNode(&file.root, validationError.Path); node != nil {
+		validationError.Line, validationError.Column = node.Line, node.Column
+	}
+}
+
+// sort orders validation errors by the order in which their files were read, then by line and column
+func (l *configLoader) sort(errs configure.ValidationErrors) {
+	slices.SortStableFunc(errs, func(a, b configure.ValidationError) int {
+		return cmp.Or(
+			cmp.Compare(slices.Index(l.paths, a.File), slices.Index(l.paths, b.File)),
+			cmp.Compare(a.Line, b.Line),
+			cmp.Compare(a.Column, b.Column),
+		)
+	})
+}
@@ -0,0 +1,143 @@
+package configure
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+)
+
+// writeTestFiles writes configuration files relative to a temporary directory and returns the directory
+func writeTestFiles(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+// serviceNames returns the names of the services of a configuration
+func serviceNames(cfg *configure.Configure) string {
+	var names []string
+	for _, service := range cfg.Services {
+		names = append(names, service.Name)
+	}
+	return strings.Join(names, ",")
+}
+
+func TestReadConfigs_Merge(t *testing.T) {
+	dir := writeTestFiles(t, map[string]string{
+		"config.yaml": `
+timeout: 10
+include: ["teams", "shared/*.yml"]
+services:
+  - name: "main"
+    endpoints:
+      - url: "https://example.com"
+`,
+		"teams/b.yaml": `
+services:
+  - name: "b"
+    endpoints:
+      - url: "https://b.example.com"
+`,
+		"teams/a.yaml": `
+include: ["../config.yaml"]
+max_log_days: 7
+services:
+  - name: "a"
+    endpoints:
+      - url: "https://a.example.com"
+`,
+		"teams/notes.txt": "not a configuration file",
+		"shared/c.yml": `
+services:
+  - name: "c"
+    endpoints:
+      - url: "https://c.example.com"
+`,
+	})
+
+	for _, path := range []string{filepath.Join(dir, "config.yaml"), filepath.Join(dir, "teams"), filepath.Join(dir, "*", "*.y*ml")} {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			cfg, err := ReadConfigs(path)
+			if err != nil {
+				t.Fatalf("ReadConfigs failed: %v", err)
+			}
+			if cfg.Timeout != 10 || cfg.MaxLogDays != 7 {
+				t.Errorf("Expected the global settings of all files, got timeout %d and max_log_days %d", cfg.Timeout, cfg.MaxLogDays)
+			}
+			if len(cfg.Services) != 4 {
+				t.Errorf("Expected the services of all files once, got %s", serviceNames(cfg))
+			}
+		})
+	}
+
+	cfg, err := ReadConfigs(filepath.Join(dir, "config.yaml"))
+	if err != nil {
+		t.Fatalf("ReadConfigs failed: %v", err)
+	}
+	if names := serviceNames(cfg); names != "main,a,b,c" {
+		t.Errorf("Expected the services in the order the files are included, got %s", names)
+	}
+
+	if _, err := ReadConfigs(filepath.Join(dir, "missing", "*.yaml")); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected a pattern without matches not to exist, got %v", err)
+	}
+}
+
+func TestReadConfigs_MergeConflicts(t *testing.T) {
+	dir := writeTestFiles(t, map[string]string{
+		"config.yaml": `
+timeout: 10
+include: ["teams/*.yaml", "missing.yaml"]
+services:
+  - name: "api"
+    endpoints:
+      - url: "https://example.com"
+`,
+		"teams/payments.yaml": `
+timeout: 20
+services:
+  - name: "payments"
+    endpoints:
+      - url: "payments.example.com"
+  - name: "api"
+    endpoints:
+      - url: "https://api.example.com"
+`,
+	})
+	configPath := filepath.Join(dir, "config.yaml")
+	teamPath := filepath.Join(dir, "teams", "payments.yaml")
+
+	_, err := ReadConfigs(configPath)
+	var errs configure.ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Expected validation errors, got %v", err)
+	}
+
+	expected := []string{
+		configPath + ":3:27: include[1]: stat " + filepath.Join(dir, "missing.yaml"),
+		teamPath + ":2:10: timeout: timeout is already set in " + configPath,
+		teamPath + ":6:14: services[0].endpoints[0].url: URL must be an absolute http or https URL",
+		teamPath + `:7:11: services[1].name: service name "api" is already used by services[0] of ` + configPath,
+	}
+	if len(errs) != len(expected) {
+		t.Fatalf("Expected %d errors, got %d:\n%v", len(expected), len(errs), err)
+	}
+	for i, validationError := range errs {
+		if message := validationError.Error(); !strings.HasPrefix(message, expected[i]) {
+			t.Errorf("Expected error %d to start with %q, got %q", i, expected[i], message)
+		}
+	}
+}
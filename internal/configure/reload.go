@@ -0,0 +1,73 @@
+package configure
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+)
+
+// Reloader keeps the last valid configuration at a path for long-running processes, which apply
+// changes to the configuration without restarting
+type Reloader struct {
+	path        string
+	cfg         *configure.Configure
+	fingerprint string
+}
+
+// NewReloader reads the configuration at the path, which must be valid
+func NewReloader(path string) (*Reloader, error) {
+	r := &Reloader{path: path}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Config returns the last valid configuration
+func (r *Reloader) Config() *configure.Configure {
+	return r.cfg
+}
+
+// Changed reports whether configuration files were edited, added or removed since the configuration
+// was last read, including the files it includes
+func (r *Reloader) Changed() bool {
+	return r.getFingerprint() != r.fingerprint
+}
+
+// Reload reads and validates the configuration again. If it is invalid, the errors are returned and
+// the last valid configuration is kept until the files change again.
+func (r *Reloader) Reload() error {
+	cfg, err := ReadConfigs(r.path)
+	if err == nil {
+		r.cfg = cfg
+	}
+	r.fingerprint = r.getFingerprint()
+	return err
+}
+
+// getFingerprint returns the sizes and modification times of the configuration files at the path
+// and of the files the last valid configuration was read from, including its secrets file
+func (r *Reloader) getFingerprint() string {
+	paths, _ := expandConfigPath(r.path)
+	if r.cfg != nil {
+		paths = append(paths, r.cfg.Files...)
+		if r.cfg.SecretsFile != "" {
+			paths = append(paths, r.cfg.SecretsFile)
+		}
+	}
+	slices.Sort(paths)
+
+	var fingerprint strings.Builder
+	for _, path := range slices.Compact(paths) {
+		info, err := os.Stat(path)
+		if err != nil {
+			_, _ = fmt.Fprintf(&fingerprint, "%s: missing\n", path)
+			continue
+		}
+		_, _ = fmt.Fprintf(&fingerprint, "%s: %d %d\n", path, info.Size(), info.ModTime().UnixNano())
+	}
+	return fingerprint.String()
+}
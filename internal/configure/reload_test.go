@@ -0,0 +1,125 @@
+package configure
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// editTestFile rewrites a test file and moves its modification time forward, so that the edit is
+// detected even on file systems with a coarse time resolution
+func editTestFile(t *testing.T, path, content string, edits *int) {
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+	*edits++
+	modTime := time.Now().Add(time.Duration(*edits) * time.Minute)
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("Failed to change the modification time of %s: %v", path, err)
+	}
+}
+
+func TestReloader(t *testing.T) {
+	dir := writeTestFiles(t, map[string]string{
+		"config.yaml": `
+include: ["teams"]
+services:
+  - name: "main"
+    endpoints:
+      - url: "https://example.com"
+`,
+		"teams/a.yaml": `
+services:
+  - name: "a"
+    endpoints:
+      - url: "https://a.example.com"
+`,
+	})
+	configPath := filepath.Join(dir, "config.yaml")
+	includedPath := filepath.Join(dir, "teams", "a.yaml")
+	edits := 0
+
+	reloader, err := NewReloader(configPath)
+	if err != nil {
+		t.Fatalf("NewReloader failed: %v", err)
+	}
+	if names := serviceNames(reloader.Config()); names != "main,a" {
+		t.Fatalf("Expected services main,a, got %s", names)
+	}
+	if reloader.Changed() {
+		t.Error("Expected no change right after reading the configuration")
+	}
+
+	// an edit of an included file is detected
+	editTestFile(t, includedPath, `
+services:
+  - name: "a"
+    endpoints:
+      - url: "https://a.example.com"
+  - name: "b"
+    endpoints:
+      - url: "https://b.example.com"
+`, &edits)
+	if !reloader.Changed() {
+		t.Fatal("Expected the edit of the included file to be detected")
+	}
+	if err := reloader.Reload(); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if names := serviceNames(reloader.Config()); names != "main,a,b" {
+		t.Errorf("Expected services main,a,b, got %s", names)
+	}
+
+	// an invalid configuration is rejected and the last valid one is kept
+	editTestFile(t, configPath, `
+include: ["teams"]
+services:
+  - name: "main"
+    endpoints:
+      - url: "not a url"
+`, &edits)
+	if !reloader.Changed() {
+		t.Fatal("Expected the edit of the configuration to be detected")
+	}
+	if err := reloader.Reload(); err == nil {
+		t.Fatal("Expected the invalid configuration to be rejected")
+	}
+	if names := serviceNames(reloader.Config()); names != "main,a,b" {
+		t.Errorf("Expected the last valid configuration to be kept, got services %s", names)
+	}
+	if reloader.Changed() {
+		t.Error("Expected the invalid configuration not to be read again until it changes")
+	}
+
+	// fixing the configuration applies it
+	editTestFile(t, configPath, `
+include: ["teams"]
+services:
+  - name: "web"
+    endpoints:
+      - url: "https://example.com"
+`, &edits)
+	if !reloader.Changed() {
+		t.Fatal("Expected the fix of the configuration to be detected")
+	}
+	if err := reloader.Reload(); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if names := serviceNames(reloader.Config()); names != "web,a,b" {
+		t.Errorf("Expected services web,a,b, got %s", names)
+	}
+}
+
+func TestNewReloader_Invalid(t *testing.T) {
+	path := writeTestConfig(t, `
+timeout: "soon"
+services:
+  - name: "api"
+    endpoints:
+      - url: "https://example.com"
+`)
+	if _, err := NewReloader(path); err == nil {
+		t.Error("Expected an invalid configuration to be rejected")
+	}
+}
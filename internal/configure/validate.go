@@ -0,0 +1,134 @@
+package configure
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/wcy-dt/ponghub/internal/common/schedule"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+)
+
+// Validate checks the services of the configuration for problems that would make their checks fail
+// or be skipped, and the notification settings for channels and policies that would be skipped or
+// never notified.
+// Keys, types and enum values are checked against the JSON Schema and duplicate service names
+// are found while merging the files by ReadConfigs.
+func Validate(cfg *configure.Configure) configure.ValidationErrors {
+	var errs configure.ValidationErrors
+	if len(cfg.Services) == 0 {
+		errs.Add("services", "no services defined")
+	}
+
+	for i, service := range cfg.Services {
+		path := fmt.Sprintf("services[%d]", i)
+		if service.Name == "" {
+			errs.Add(path+".name", "service name is empty")
+		}
+
+		for j, endpoint := range service.Endpoints {
+			validateEndpoint(&errs, fmt.Sprintf("%s.endpoints[%d]", path, j), endpoint)
+		}
+		for j, window := range service.Maintenance {
+			validateMaintenanceWindow(&errs, fmt.Sprintf("%s.maintenance[%d]", path, j), window)
+		}
+	}
+	validateDependencies(&errs, cfg.Services)
+	validateChannelGroups(&errs, cfg)
+	validateNotifications(&errs, cfg.Notifications)
+	return errs
+}
+
+// validateDependencies checks that services only depend on other existing services and that the
+// dependencies have no cycles, which are reported at the dependency closing the cycle
+func validateDependencies(errs *configure.ValidationErrors, services []configure.Service) {
+	indexes := make(map[string]int, len(services))
+	for i, service := range services {
+		indexes[service.Name] = i
+	}
+
+	for i, service := range services {
+		for j, name := range service.DependsOn {
+			path := fmt.Sprintf("services[%d].depends_on[%d]", i, j)
+			if name == service.Name {
+				errs.Add(path, "a service cannot depend on itself")
+			} else if _, exists := indexes[name]; !exists {
+				errs.Add(path, fmt.Sprintf("unknown service %q", name))
+			}
+		}
+	}
+
+	// depth-first search for cycles, state is 1 while a service is visited and 2 once it is done
+	state := make([]int, len(services))
+	var visit func(i int, chain []string)
+	visit = func(i int, chain []string) {
+		state[i] = 1
+		chain = append(chain, services[i].Name)
+		for j, name := range services[i].DependsOn {
+			next, exists := indexes[name]
+			if !exists || next == i {
+				continue
+			}
+			switch state[next] {
+			case 0:
+				visit(next, chain)
+			case 1:
+				cycle := append(slices.Clone(chain[slices.Index(chain, name):]), name)
+				errs.Add(fmt.Sprintf("services[%d].depends_on[%d]", i, j), fmt.Sprintf("dependency cycle %s", strings.Join(cycle, " -> ")))
+			}
+		}
+		state[i] = 2
+	}
+	for i := range services {
+		if state[i] == 0 {
+			visit(i, nil)
+		}
+	}
+}
+
+// validateChannelGroups checks that the groups notification channels are restricted to are used by a service
+func validateChannelGroups(errs *configure.ValidationErrors, cfg *configure.Configure) {
+	if cfg.Notifications == nil || !cfg.Notifications.Enabled {
+		return
+	}
+	for i, channel := range cfg.Notifications.Channels {
+		if channel == nil {
+			continue
+		}
+		for j, group := range channel.Groups {
+			if !slices.ContainsFunc(cfg.Services, func(service configure.Service) bool { return strings.EqualFold(service.Group, group) }) {
+				errs.Add(fmt.Sprintf("notifications.channels[%d].groups[%d]", i, j), fmt.Sprintf("unknown group %q", group))
+			}
+		}
+	}
+}
+
+// validateEndpoint checks the URL and response regex of an endpoint, the method and the expected status code
+// are checked by the schema. The URL is checked after its parameters are resolved, but not printed as it may contain secrets.
+func validateEndpoint(errs *configure.ValidationErrors, path string, endpoint configure.Endpoint) {
+	if endpoint.URL == "" {
+		errs.Add(path+".url", "URL is empty")
+	} else if parsedURL, err := url.Parse(endpoint.ParsedURL); err != nil ||
+		(parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		errs.Add(path+".url", "URL must be an absolute http or https URL")
+	}
+
+	if _, err := regexp.Compile(endpoint.ResponseRegex); err != nil {
+		errs.Add(path+".response_regex", fmt.Sprintf("invalid regular expression: %v", err))
+	}
+}
+
+// validateMaintenanceWindow checks that a recurring or one-off maintenance window can be parsed
+func validateMaintenanceWindow(errs *configure.ValidationErrors, path string, window configure.MaintenanceWindow) {
+	var err error
+	if window.Cron != "" {
+		_, err = schedule.NewRecurringWindow(window.Cron, window.Duration, window.Timezone)
+	} else {
+		_, err = schedule.NewOneOffWindow(window.Start, window.End, window.Timezone)
+	}
+	if err != nil {
+		errs.Add(path, err.Error())
+	}
+}
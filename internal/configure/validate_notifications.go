@@ -0,0 +1,165 @@
+package configure
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/common/params"
+	"github.com/wcy-dt/ponghub/internal/common/schedule"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+)
+
+// validateNotifications checks the notification configuration for channels without settings, and quiet
+// hours, escalation steps and digest settings that are invalid or refer to unknown channels. These are
+// skipped with a log message when notifications are sent. Unknown channel types are left to the schema.
+func validateNotifications(errs *configure.ValidationErrors, config *configure.NotificationConfig) {
+	if config == nil || !config.Enabled {
+		return
+	}
+
+	names := validateChannels(errs, config)
+	validateChannelNames := func(path string, channels []string) {
+		for i, channel := range channels {
+			if !slices.ContainsFunc(names, func(name string) bool { return strings.EqualFold(name, channel) }) {
+				errs.Add(fmt.Sprintf("%s[%d]", path, i), fmt.Sprintf("unknown channel %q", channel))
+			}
+		}
+	}
+
+	for i, quietHours := range config.QuietHours {
+		path := fmt.Sprintf("notifications.quiet_hours[%d]", i)
+		if _, err := schedule.NewDailyWindow(quietHours.Start, quietHours.End, quietHours.Days, quietHours.Timezone); err != nil {
+			errs.Add(path, err.Error())
+		}
+		validateChannelNames(path+".channels", quietHours.Channels)
+	}
+
+	if config.Escalation != nil {
+		for i, step := range config.Escalation.Steps {
+			path := fmt.Sprintf("notifications.escalation.steps[%d]", i)
+			if step.After != "" {
+				if after, err := time.ParseDuration(step.After); err != nil || after < 0 {
+					errs.Add(path+".after", fmt.Sprintf("invalid duration %q", step.After))
+				}
+			}
+			validateChannelNames(path+".channels", step.Channels)
+		}
+	}
+
+	if digest := config.Digest; digest != nil {
+		if digest.Schedule != "" {
+			if _, err := schedule.ParseCron(digest.Schedule); err != nil {
+				errs.Add("notifications.digest.schedule", err.Error())
+			}
+		}
+		if _, err := schedule.LoadLocation(digest.Timezone); err != nil {
+			errs.Add("notifications.digest.timezone", err.Error())
+		}
+		validateChannelNames("notifications.digest.channels", digest.Channels)
+	}
+}
+
+// validateChannels checks that the settings of all methods and named channels are configured
+// and returns the names of the channels
+func validateChannels(errs *configure.ValidationErrors, config *configure.NotificationConfig) []string {
+	var names []string
+	for i, method := range config.Methods {
+		method = strings.ToLower(method)
+		names = append(names, method)
+		if method == "default" {
+			continue
+		}
+		validateChannelSettings(errs, fmt.Sprintf("notifications.methods[%d]", i), "notifications", method, &config.ChannelConfigs)
+		if method == "email" {
+			for j := range config.Emails {
+				names = append(names, fmt.Sprintf("emails[%d]", j))
+			}
+		}
+	}
+
+	for i, channel := range config.Channels {
+		if channel == nil {
+			continue
+		}
+		path := fmt.Sprintf("notifications.channels[%d]", i)
+
+		name := channel.Name
+		if name == "" {
+			name = fmt.Sprintf("channels[%d]", i)
+		}
+		if slices.ContainsFunc(names, func(other string) bool { return strings.EqualFold(other, name) }) {
+			errs.Add(path+".name", fmt.Sprintf("channel name %q is already used", name))
+		}
+		names = append(names, name)
+
+		channelType := strings.ToLower(channel.Type)
+		switch channelType {
+		case "":
+			errs.Add(path+".type", "channel type is empty")
+		case "default":
+		default:
+			validateChannelSettings(errs, path, path, channelType, &channel.ChannelConfigs)
+		}
+	}
+	return names
+}
+
+// validateChannelSettings checks that the settings of the channel type are configured, the errors
+// are reported at path and the errors of the settings below settingsPath
+func validateChannelSettings(errs *configure.ValidationErrors, path, settingsPath, channelType string, configs *configure.ChannelConfigs) {
+	settingsKey, configured, known := channelSettings(channelType, configs)
+	switch {
+	case !known:
+	case !configured:
+		errs.Add(path, fmt.Sprintf("no %s settings configured", settingsKey))
+	case channelType == "webhook":
+		validateWebhook(errs, settingsPath+".webhook", configs.Webhook)
+	}
+}
+
+// channelSettings returns the key of the settings of the channel type and whether they are configured,
+// known is false for unknown channel types
+func channelSettings(channelType string, configs *configure.ChannelConfigs) (settingsKey string, configured, known bool) {
+	switch channelType {
+	case "default":
+		return channelType, configs.Default != nil, true
+	case "email":
+		return channelType, configs.Email != nil, true
+	case "webhook":
+		return channelType, configs.Webhook != nil, true
+	case "telegram":
+		return channelType, configs.Telegram != nil, true
+	case "dingtalk":
+		return channelType, configs.DingTalk != nil, true
+	case "feishu", "lark":
+		return "feishu", configs.Feishu != nil, true
+	case "wecom":
+		return channelType, configs.WeCom != nil, true
+	case "pagerduty":
+		return channelType, configs.PagerDuty != nil, true
+	case "opsgenie":
+		return channelType, configs.Opsgenie != nil, true
+	case "ntfy":
+		return channelType, configs.Ntfy != nil, true
+	case "gotify":
+		return channelType, configs.Gotify != nil, true
+	case "pushover":
+		return channelType, configs.Pushover != nil, true
+	case "matrix":
+		return channelType, configs.Matrix != nil, true
+	case "command":
+		return channelType, configs.Command != nil, true
+	default:
+		return channelType, false, false
+	}
+}
+
+// validateWebhook checks that the signing secret of the webhook settings does not resolve to an
+// empty value, e.g. an environment variable that is not set, since the requests are not sent then
+func validateWebhook(errs *configure.ValidationErrors, path string, config *configure.WebhookConfig) {
+	if config.SigningSecret != "" && params.NewParameterResolver().ResolveParameters(config.SigningSecret) == "" {
+		errs.Add(path+".signing_secret", "signing secret is empty")
+	}
+}
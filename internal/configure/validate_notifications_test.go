@@ -0,0 +1,75 @@
+package configure
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/wcy-dt/ponghub/internal/schema"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+)
+
+func TestValidateNotifications(t *testing.T) {
+	t.Setenv("TEST_WEBHOOK_SECRET", "")
+	config := &configure.NotificationConfig{
+		Enabled: true,
+		Methods: []string{"default", "telegram"},
+		Channels: []*configure.ChannelConfig{
+			{Name: "ops", Type: "webhook", ChannelConfigs: configure.ChannelConfigs{Webhook: &configure.WebhookConfig{URL: "https://example.com"}}},
+			{Name: "signed", Type: "webhook", ChannelConfigs: configure.ChannelConfigs{Webhook: &configure.WebhookConfig{
+				URL: "https://example.com", SigningSecret: "{{env(TEST_WEBHOOK_SECRET)}}",
+			}}},
+			{Name: "OPS", Type: "ntfy"},
+			{Name: "pager", Type: "sms"},
+			{Name: "chat"},
+		},
+		QuietHours: []configure.QuietHoursConfig{{Start: "22:00", End: "7am", Channels: []string{"ops", "email"}}},
+		Escalation: &configure.EscalationConfig{Steps: []configure.EscalationStep{
+			{Channels: []string{"Ops"}},
+			{After: "soon", Channels: []string{"pager"}},
+		}},
+		Digest: &configure.DigestConfig{Enabled: true, Schedule: "0 9 * *", Timezone: "Mars/Olympus", Channels: []string{"default"}},
+	}
+
+	var errs configure.ValidationErrors
+	validateNotifications(&errs, config)
+	var paths []string
+	for _, validationError := range errs {
+		paths = append(paths, validationError.Path)
+	}
+	expected := []string{
+		"notifications.methods[1]",
+		"notifications.channels[1].webhook.signing_secret",
+		"notifications.channels[2].name",
+		"notifications.channels[2]",
+		"notifications.channels[4].type",
+		"notifications.quiet_hours[0]",
+		"notifications.quiet_hours[0].channels[1]",
+		"notifications.escalation.steps[1].after",
+		"notifications.digest.schedule",
+		"notifications.digest.timezone",
+	}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Errorf("Expected errors at %v, got %v", expected, paths)
+	}
+
+	errs = nil
+	validateNotifications(&errs, &configure.NotificationConfig{Methods: []string{"sms"}})
+	if len(errs) != 0 {
+		t.Errorf("Expected disabled notifications not to be validated, got %v", errs)
+	}
+}
+
+func TestChannelSettingsSchemaTypes(t *testing.T) {
+	definitions := schema.Generate().Definitions
+	enums := [][]string{
+		definitions["NotificationConfig"].Properties["methods"].Items.Enum,
+		definitions["ChannelConfig"].Properties["type"].Enum,
+	}
+	for _, enum := range enums {
+		for _, channelType := range enum {
+			if _, _, known := channelSettings(channelType, &configure.ChannelConfigs{}); !known {
+				t.Errorf("Expected the settings of channel type %q of the schema to be validated", channelType)
+			}
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package configure
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// yamlErrorLinePattern matches the line number at the start of the errors of the YAML decoder
+	yamlErrorLinePattern = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+	// pathSegmentPattern matches a key of a validation path followed by its sequence indexes, e.g. "endpoints[1]"
+	pathSegmentPattern = regexp.MustCompile(`^([^\[]*)((?:\[\d+])*)$`)
+)
+
+// decodeErrors converts the errors of the YAML decoder to validation errors with their line numbers
+func decodeErrors(err error) configure.ValidationErrors {
+	messages := []string{err.Error()}
+	var typeError *yaml.TypeError
+	if errors.As(err, &typeError) {
+		messages = typeError.Errors
+	}
+
+	var errs configure.ValidationErrors
+	for _, message := range messages {
+		validationError := configure.ValidationError{Message: message}
+		if match := yamlErrorLinePattern.FindStringSubmatch(message); match != nil {
+			validationError.Line, _ = strconv.Atoi(match[1])
+			validationError.Message = match[2]
+		}
+		errs = append(errs, validationError)
+	}
+	return errs
+}
+
+// findNode returns the node at a validation path such as "services[0].endpoints[1].url"
+func findNode(root *yaml.Node, path string) *yaml.Node {
+	node := root
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	if path == "" {
+		return node
+	}
+
+	for _, segment := range strings.Split(path, ".") {
+		match := pathSegmentPattern.FindStringSubmatch(segment)
+		if match == nil {
+			return node
+		}
+		if match[1] != "" {
+			child := mappingValue(node, match[1])
+			if child == nil {
+				return node
+			}
+			node = child
+		}
+		for _, index := range strings.Split(strings.Trim(match[2], "[]"), "][") {
+			if index == "" {
+				continue
+			}
+			i, _ := strconv.Atoi(index)
+			if node.Kind != yaml.SequenceNode || i >= len(node.Content) {
+				return node
+			}
+			node = node.Content[i]
+		}
+	}
+	return node
+}
+
+// mappingValue returns the value of the key in a mapping node, or nil if it does not exist
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
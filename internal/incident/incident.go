@@ -0,0 +1,284 @@
+package incident
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	"github.com/wcy-dt/ponghub/internal/types/structures/incident"
+	"github.com/wcy-dt/ponghub/internal/types/structures/logger"
+	"github.com/wcy-dt/ponghub/internal/types/types/chk_result"
+	"github.com/wcy-dt/ponghub/internal/types/types/default_config"
+)
+
+// nonAlphanumeric matches the characters replaced in incident IDs
+var nonAlphanumeric = regexp.MustCompile(`[^a-z0-9]+`)
+
+// errorKinds maps parts of failure details to the kind of error recorded for incidents, the first match
+// wins. Only the kind is recorded, as failure details may contain the resolved URL and its secrets.
+var errorKinds = []struct {
+	pattern, kind string
+}{
+	{pattern: "SSL Certificate Error", kind: "certificate error"},
+	{pattern: "no such host", kind: "DNS lookup failed"},
+	{pattern: "connection refused", kind: "connection refused"},
+	{pattern: "connection reset", kind: "connection reset"},
+	{pattern: "imeout", kind: "timeout"},
+	{pattern: "deadline exceeded", kind: "timeout"},
+	{pattern: "x509:", kind: "TLS error"},
+	{pattern: "tls:", kind: "TLS error"},
+	{pattern: "EOF", kind: "connection closed"},
+	{pattern: "mismatch", kind: "unexpected response"},
+}
+
+// period is an incident derived from the log of a service
+type period struct {
+	start, end time.Time
+	ongoing    bool
+	endpoints  []string
+	peak       int
+}
+
+// timedStatus is a parsed history entry
+type timedStatus struct {
+	time   time.Time
+	status chk_result.CheckResult
+}
+
+// ReadIncidents loads the incidents from file or returns no incidents if the file does not exist
+func ReadIncidents(path string) (incident.Incidents, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return incident.Incidents{}, nil
+		}
+		return nil, err
+	}
+
+	var incidents incident.Incidents
+	if err := json.Unmarshal(content, &incidents); err != nil {
+		return nil, err
+	}
+	return incidents, nil
+}
+
+// WriteIncidents writes the incidents to file
+func WriteIncidents(incidents incident.Incidents, path string) error {
+	if incidents == nil {
+		incidents = incident.Incidents{}
+	}
+	content, err := json.MarshalIndent(incidents, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0644)
+}
+
+// Update derives the incidents of the checked services from the log and merges them into the
+// persisted incidents, which keep incidents whose entries have been removed from the log.
+// The failure details of the current check results are recorded for ongoing incidents.
+// Ongoing incidents that can no longer be derived are ended, and incidents that ended longer
+// ago than the retention period are dropped.
+func Update(incidents incident.Incidents, logResult logger.Logger, checkResult []checker.Service, now time.Time) incident.Incidents {
+	ongoing := make(map[string]bool)
+
+	for _, serviceResult := range checkResult {
+		serviceLog, exists := logResult[serviceResult.Name]
+		if !exists {
+			continue
+		}
+
+		for _, p := range derivePeriods(serviceLog) {
+			index := findOverlapping(incidents, serviceResult.Name, p)
+			if index < 0 {
+				incidents = append(incidents, incident.Incident{
+					ID:          newID(serviceResult.Name, p.start),
+					ServiceName: serviceResult.Name,
+					Start:       p.start.Format(time.RFC3339),
+				})
+				index = len(incidents) - 1
+			}
+
+			current := &incidents[index]
+			current.End = ""
+			if p.ongoing {
+				ongoing[current.ID] = true
+				recordErrors(current, serviceResult)
+			} else {
+				current.End = p.end.Format(time.RFC3339)
+			}
+			for _, endpoint := range p.endpoints {
+				if !slices.Contains(current.Endpoints, endpoint) {
+					current.Endpoints = append(current.Endpoints, endpoint)
+				}
+			}
+			current.PeakFailures = max(current.PeakFailures, p.peak)
+		}
+	}
+
+	cutoff := now.AddDate(0, 0, -default_config.GetDefaultIncidentRetentionDays())
+	var kept incident.Incidents
+	for _, current := range incidents {
+		if current.IsOngoing() && !ongoing[current.ID] {
+			current.End = now.Format(time.RFC3339)
+		}
+		if end, err := time.Parse(time.RFC3339, current.End); err == nil && end.Before(cutoff) {
+			continue
+		}
+		kept = append(kept, current)
+	}
+
+	sort.SliceStable(kept, func(i, j int) bool {
+		return parseTime(kept[i].Start).After(parseTime(kept[j].Start))
+	})
+	return kept
+}
+
+// derivePeriods finds the periods in which the service was partially or fully unavailable.
+// Entries logged during maintenance neither start nor end a period.
+func derivePeriods(serviceLog logger.Service) []period {
+	runs := parseHistory(serviceLog.ServiceHistory)
+	endpointHistories := make(map[string][]timedStatus, len(serviceLog.Endpoints))
+	for url, history := range serviceLog.Endpoints {
+		endpointHistories[url] = parseHistory(history)
+	}
+
+	var periods []period
+	var current *period
+	for i, run := range runs {
+		switch run.status {
+		case chk_result.NONE, chk_result.PART:
+			if current == nil {
+				current = &period{start: run.time}
+			}
+			current.end = run.time
+
+			// the endpoints of a run are checked after the service run started and before the next one
+			next := time.Time{}
+			if i+1 < len(runs) {
+				next = runs[i+1].time
+			}
+			failing := failingEndpoints(endpointHistories, run.time, next)
+			current.peak = max(current.peak, len(failing))
+			for _, url := range failing {
+				if !slices.Contains(current.endpoints, url) {
+					current.endpoints = append(current.endpoints, url)
+				}
+			}
+		case chk_result.ALL:
+			if current != nil {
+				current.end = run.time
+				periods = append(periods, *current)
+				current = nil
+			}
+		}
+	}
+
+	if current != nil {
+		current.ongoing = true
+		periods = append(periods, *current)
+	}
+	return periods
+}
+
+// failingEndpoints returns the endpoints, in alphabetical order, that were partially or fully
+// unavailable between from and to, or any time after from if to is zero
+func failingEndpoints(endpointHistories map[string][]timedStatus, from, to time.Time) []string {
+	var failing []string
+	for url, history := range endpointHistories {
+		for _, entry := range history {
+			if entry.time.Before(from) || (!to.IsZero() && !entry.time.Before(to)) {
+				continue
+			}
+			if entry.status == chk_result.NONE || entry.status == chk_result.PART {
+				failing = append(failing, url)
+				break
+			}
+		}
+	}
+	sort.Strings(failing)
+	return failing
+}
+
+// findOverlapping returns the index of the incident of the service that overlaps the period, or -1.
+// Periods whose first entries have been removed from the log are matched to their incident this way.
+func findOverlapping(incidents incident.Incidents, serviceName string, p period) int {
+	for i, current := range incidents {
+		if current.ServiceName != serviceName || parseTime(current.Start).After(p.end) {
+			continue
+		}
+		if current.IsOngoing() || !parseTime(current.End).Before(p.start) {
+			return i
+		}
+	}
+	return -1
+}
+
+// recordErrors records the kind and status code of the latest failure of the unavailable endpoints
+// of the check result
+func recordErrors(current *incident.Incident, serviceResult checker.Service) {
+	for _, endpoint := range serviceResult.Endpoints {
+		if endpoint.Status == chk_result.NONE && len(endpoint.FailureDetails) > 0 {
+			message := fmt.Sprintf("%s: %s", endpoint.URL, describeFailure(endpoint))
+			if current.FirstError == "" {
+				current.FirstError = message
+			}
+			current.LastError = message
+			return
+		}
+	}
+}
+
+// describeFailure returns the kind of the latest failure of the endpoint and its status code, if any
+func describeFailure(endpoint checker.Endpoint) string {
+	detail := endpoint.FailureDetails[len(endpoint.FailureDetails)-1]
+	kind := "request failed"
+	for _, errorKind := range errorKinds {
+		if strings.Contains(detail, errorKind.pattern) {
+			kind = errorKind.kind
+			break
+		}
+	}
+	if endpoint.StatusCode > 0 {
+		return fmt.Sprintf("%s (status code %d)", kind, endpoint.StatusCode)
+	}
+	return kind
+}
+
+// newID creates the ID of an incident from the service name and its start, e.g. "my-api-20250305-1000",
+// which is also the name of its notes file
+func newID(serviceName string, start time.Time) string {
+	slug := strings.Trim(nonAlphanumeric.ReplaceAllString(strings.ToLower(serviceName), "-"), "-")
+	if slug == "" {
+		slug = "service"
+	}
+	return slug + "-" + start.UTC().Format("20060102-1504")
+}
+
+// parseHistory parses the entries of the history with a valid time, ordered by time
+func parseHistory(history logger.History) []timedStatus {
+	var entries []timedStatus
+	for _, entry := range history {
+		entryTime, err := time.Parse(time.RFC3339, entry.Time)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, timedStatus{time: entryTime, status: chk_result.ParseCheckResult(entry.Status)})
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].time.Before(entries[j].time)
+	})
+	return entries
+}
+
+// parseTime parses an RFC 3339 time, invalid times are treated as the zero time
+func parseTime(value string) time.Time {
+	t, _ := time.Parse(time.RFC3339, value)
+	return t
+}
@@ -0,0 +1,186 @@
+package incident
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	"github.com/wcy-dt/ponghub/internal/types/structures/incident"
+	"github.com/wcy-dt/ponghub/internal/types/structures/logger"
+	"github.com/wcy-dt/ponghub/internal/types/types/chk_result"
+)
+
+// newTestHistory creates a history with an entry every 30 minutes ending at end, with the given statuses
+func newTestHistory(end time.Time, statuses ...string) logger.History {
+	var history logger.History
+	for i, status := range statuses {
+		history = append(history, logger.HistoryEntry{
+			Time:   end.Add(time.Duration(i-len(statuses)+1) * 30 * time.Minute).Format(time.RFC3339),
+			Status: status,
+		})
+	}
+	return history
+}
+
+func TestUpdate(t *testing.T) {
+	now := time.Date(2025, 3, 5, 12, 0, 0, 0, time.UTC)
+	logResult := logger.Logger{
+		"My API": {
+			ServiceHistory: newTestHistory(now, "all", "part", "none", "all", "maintenance", "none", "none"),
+			Endpoints: logger.Endpoints{
+				"https://api.example.com":        newTestHistory(now, "all", "none", "none", "all", "maintenance", "all", "none"),
+				"https://api.example.com/health": newTestHistory(now, "all", "all", "none", "all", "maintenance", "none", "none"),
+			},
+		},
+		"web": {
+			ServiceHistory: newTestHistory(now, "all", "all"),
+			Endpoints:      logger.Endpoints{"https://web.example.com": newTestHistory(now, "all", "all")},
+		},
+	}
+	checkResult := []checker.Service{
+		{
+			Name: "My API",
+			Endpoints: []checker.Endpoint{
+				{URL: "https://api.example.com", Status: chk_result.NONE, FailureDetails: []string{"connection refused"}},
+			},
+		},
+		{Name: "web"},
+	}
+
+	incidents := Update(nil, logResult, checkResult, now)
+	if len(incidents) != 2 {
+		t.Fatalf("Expected 2 incidents, got %d: %+v", len(incidents), incidents)
+	}
+
+	ongoing, resolved := incidents[0], incidents[1]
+	if ongoing.ID != "my-api-20250305-1130" || !ongoing.IsOngoing() {
+		t.Errorf("Expected the ongoing incident to be first, got %+v", ongoing)
+	}
+	if ongoing.PeakFailures != 2 || ongoing.FirstError != "https://api.example.com: connection refused" {
+		t.Errorf("Unexpected ongoing incident details: %+v", ongoing)
+	}
+	if resolved.Start != "2025-03-05T09:30:00Z" || resolved.End != "2025-03-05T10:30:00Z" {
+		t.Errorf("Unexpected period of the resolved incident: %s - %s", resolved.Start, resolved.End)
+	}
+	expectedEndpoints := []string{"https://api.example.com", "https://api.example.com/health"}
+	if !reflect.DeepEqual(resolved.Endpoints, expectedEndpoints) || resolved.PeakFailures != 2 {
+		t.Errorf("Unexpected resolved incident details: %+v", resolved)
+	}
+	if resolved.FirstError != "" {
+		t.Errorf("Expected no error to be recorded for a resolved incident, got %q", resolved.FirstError)
+	}
+
+	// the latest error is recorded while the first one is kept
+	checkResult[0].Endpoints[0].FailureDetails = []string{"timeout"}
+	incidents = Update(incidents, logResult, checkResult, now)
+	if len(incidents) != 2 {
+		t.Fatalf("Expected the incidents to be merged, got %d", len(incidents))
+	}
+	if incidents[0].FirstError != "https://api.example.com: connection refused" || incidents[0].LastError != "https://api.example.com: timeout" {
+		t.Errorf("Unexpected errors: %q, %q", incidents[0].FirstError, incidents[0].LastError)
+	}
+}
+
+func TestDescribeFailure(t *testing.T) {
+	tests := []struct {
+		detail     string
+		statusCode int
+		expected   string
+	}{
+		{detail: `StatusCode: N/A, Error: Get "https://api.example.com/?token=s3cr3t": dial tcp: lookup api.example.com: no such host`, expected: "DNS lookup failed"},
+		{detail: `StatusCode: N/A, Error: Get "https://api.example.com/?token=s3cr3t": context deadline exceeded (Client.Timeout exceeded while awaiting headers)`, expected: "timeout"},
+		{detail: "StatusCode or ResponseRegex mismatch: 503", statusCode: 503, expected: "unexpected response (status code 503)"},
+		{detail: "SSL Certificate Error: x509: certificate signed by unknown authority", expected: "certificate error"},
+		{detail: "something else with https://api.example.com/?token=s3cr3t", expected: "request failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			endpoint := checker.Endpoint{StatusCode: tt.statusCode, FailureDetails: []string{tt.detail}}
+			if description := describeFailure(endpoint); description != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, description)
+			}
+		})
+	}
+}
+
+func TestUpdate_TrimmedLog(t *testing.T) {
+	now := time.Date(2025, 3, 5, 12, 0, 0, 0, time.UTC)
+	incidents := incident.Incidents{
+		{ID: "api-20250305-1000", ServiceName: "api", Start: "2025-03-05T10:00:00Z", Endpoints: []string{"https://api.example.com"}, PeakFailures: 1},
+		{ID: "api-20250301-1000", ServiceName: "api", Start: "2025-03-01T10:00:00Z", End: "2025-03-01T11:00:00Z"},
+		{ID: "api-20241101-1000", ServiceName: "api", Start: "2024-11-01T10:00:00Z", End: "2024-11-01T11:00:00Z"},
+		{ID: "web-20250305-1000", ServiceName: "web", Start: "2025-03-05T10:00:00Z"},
+	}
+
+	// the first entries of the ongoing incident of api have been removed from the log
+	logResult := logger.Logger{
+		"api": {
+			ServiceHistory: newTestHistory(now, "none", "all"),
+			Endpoints:      logger.Endpoints{"https://api.example.com": newTestHistory(now, "none", "all")},
+		},
+		"web": {
+			ServiceHistory: newTestHistory(now, "all"),
+			Endpoints:      logger.Endpoints{"https://web.example.com": newTestHistory(now, "all")},
+		},
+	}
+	checkResult := []checker.Service{{Name: "api"}, {Name: "web"}}
+
+	incidents = Update(incidents, logResult, checkResult, now)
+
+	var ids []string
+	for _, current := range incidents {
+		ids = append(ids, current.ID)
+	}
+	expectedIDs := []string{"api-20250305-1000", "web-20250305-1000", "api-20250301-1000"}
+	if !reflect.DeepEqual(ids, expectedIDs) {
+		t.Fatalf("Expected incidents %v, got %v", expectedIDs, ids)
+	}
+	if incidents[0].End != now.Format(time.RFC3339) || incidents[0].Start != "2025-03-05T10:00:00Z" {
+		t.Errorf("Expected the trimmed incident to be merged and resolved, got %+v", incidents[0])
+	}
+	if incidents[1].End != now.Format(time.RFC3339) {
+		t.Errorf("Expected the incident that can no longer be derived to end now, got %+v", incidents[1])
+	}
+}
+
+func TestReadWriteIncidents(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "incident_history.json")
+
+	incidents, err := ReadIncidents(path)
+	if err != nil || len(incidents) != 0 {
+		t.Fatalf("Expected no incidents for a missing file, got %v, %v", incidents, err)
+	}
+
+	expected := incident.Incidents{{ID: "api-20250305-1000", ServiceName: "api", Start: "2025-03-05T10:00:00Z", Endpoints: []string{"https://api.example.com"}}}
+	if err := WriteIncidents(expected, path); err != nil {
+		t.Fatalf("WriteIncidents failed: %v", err)
+	}
+	incidents, err = ReadIncidents(path)
+	if err != nil {
+		t.Fatalf("ReadIncidents failed: %v", err)
+	}
+	if !reflect.DeepEqual(incidents, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, incidents)
+	}
+}
+
+func TestLoadNotes(t *testing.T) {
+	dir := t.TempDir()
+	notes := "# Root cause\n\nThe database ran out of **connections**.\n"
+	if err := os.WriteFile(filepath.Join(dir, "api-20250305-1000.md"), []byte(notes), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	incidents := LoadNotes(incident.Incidents{{ID: "api-20250305-1000"}, {ID: "web-20250305-1000"}}, dir)
+	if !strings.Contains(string(incidents[0].Notes), "<strong>connections</strong>") {
+		t.Errorf("Expected the notes to be rendered, got %q", incidents[0].Notes)
+	}
+	if incidents[1].Notes != "" {
+		t.Errorf("Expected no notes, got %q", incidents[1].Notes)
+	}
+}
@@ -0,0 +1,26 @@
+package incident
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/wcy-dt/ponghub/internal/common/markdown"
+	"github.com/wcy-dt/ponghub/internal/types/structures/incident"
+)
+
+// LoadNotes renders the markdown notes of the incidents found in dir, the notes of an incident
+// are read from a file named after its ID, e.g. "my-api-20250305-1000.md"
+func LoadNotes(incidents incident.Incidents, dir string) incident.Incidents {
+	for i := range incidents {
+		content, err := os.ReadFile(filepath.Join(dir, incidents[i].ID+".md"))
+		if err != nil {
+			if !os.IsNotExist(err) {
+				log.Printf("Error reading notes of incident %s: %v", incidents[i].ID, err)
+			}
+			continue
+		}
+		incidents[i].Notes = markdown.ToHTML(string(content))
+	}
+	return incidents
+}
@@ -0,0 +1,67 @@
+package notifier
+
+import (
+	"encoding/json"
+	"os"
+	"slices"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	"github.com/wcy-dt/ponghub/internal/types/types/chk_result"
+)
+
+// ReadCertAlerts loads the keys of the endpoints notified about their certificates from file,
+// or returns no keys if the file does not exist
+func ReadCertAlerts(path string) ([]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var keys []string
+	if err := json.Unmarshal(content, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// WriteCertAlerts writes the keys of the endpoints notified about their certificates to file
+func WriteCertAlerts(keys []string, path string) error {
+	if keys == nil {
+		keys = []string{}
+	}
+	content, err := json.MarshalIndent(keys, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0644)
+}
+
+// collectRenewedEndpoints finds the endpoints notified about their certificates whose certificates
+// are no longer expired or expiring soon. It also returns the sorted keys of the endpoints whose
+// certificate alerts stay open, including the alerts of endpoints under maintenance or whose
+// certificate could not be read, and the alerts of the certificate problems of this run.
+func collectRenewedEndpoints(checkResult []checker.Service, certNotifyDays int, alerted []string) (map[string][]checker.Endpoint, []string) {
+	renewedEndpoints := make(map[string][]checker.Endpoint)
+	var open []string
+	for _, serviceResult := range checkResult {
+		maintenance := serviceResult.Status == chk_result.MAINTENANCE
+		for _, endpointResult := range serviceResult.Endpoints {
+			key := incidentKey(serviceResult.Name, endpointResult)
+			certProblem := endpointResult.IsHTTPS && (endpointResult.IsCertExpired || endpointResult.CertRemainingDays <= certNotifyDays)
+			switch {
+			case certProblem && !maintenance:
+				open = append(open, key)
+			case !slices.Contains(alerted, key):
+			case maintenance || !endpointResult.IsHTTPS:
+				open = append(open, key)
+			default:
+				renewedEndpoints[serviceResult.Name] = append(renewedEndpoints[serviceResult.Name], endpointResult)
+			}
+		}
+	}
+	slices.Sort(open)
+	return renewedEndpoints, slices.Compact(open)
+}
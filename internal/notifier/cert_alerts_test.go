@@ -0,0 +1,71 @@
+package notifier
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	"github.com/wcy-dt/ponghub/internal/types/types/chk_result"
+)
+
+func TestCollectRenewedEndpoints(t *testing.T) {
+	renewed := checker.Endpoint{URL: "https://renewed.com", IsHTTPS: true, CertRemainingDays: 90}
+	expiring := checker.Endpoint{URL: "https://expiring.com", IsHTTPS: true, CertRemainingDays: 3}
+	unread := checker.Endpoint{URL: "https://unread.com", Status: chk_result.NONE}
+	valid := checker.Endpoint{URL: "https://valid.com", IsHTTPS: true, CertRemainingDays: 90}
+	paused := checker.Endpoint{URL: "https://paused.com", IsHTTPS: true, CertRemainingDays: 90}
+	checkResult := []checker.Service{
+		{Name: "api", Status: chk_result.ALL, Endpoints: []checker.Endpoint{renewed, expiring, unread, valid}},
+		{Name: "web", Status: chk_result.MAINTENANCE, Endpoints: []checker.Endpoint{paused}},
+	}
+	alerted := []string{
+		incidentKey("api", renewed),
+		incidentKey("api", unread),
+		incidentKey("web", paused),
+		incidentKey("removed", checker.Endpoint{URL: "https://removed.com"}),
+	}
+
+	renewedEndpoints, open := collectRenewedEndpoints(checkResult, 7, alerted)
+
+	if len(renewedEndpoints) != 1 || len(renewedEndpoints["api"]) != 1 || renewedEndpoints["api"][0].URL != renewed.URL {
+		t.Errorf("Expected only %s to be renewed, got %v", renewed.URL, renewedEndpoints)
+	}
+	// alerts stay open for certificates that could not be read or are under maintenance,
+	// and are opened for the certificates expiring soon
+	expected := []string{incidentKey("api", expiring), incidentKey("api", unread), incidentKey("web", paused)}
+	if len(open) != len(expected) {
+		t.Fatalf("Expected %d open alerts, got %v", len(expected), open)
+	}
+	for _, key := range expected {
+		if !slices.Contains(open, key) {
+			t.Errorf("Expected alert %s to stay open, got %v", key, open)
+		}
+	}
+}
+
+func TestReadWriteCertAlerts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cert_alerts.json")
+
+	keys, err := ReadCertAlerts(path)
+	if err != nil || len(keys) != 0 {
+		t.Fatalf("Expected no alerts for a missing file, got %v (%v)", keys, err)
+	}
+
+	if err := WriteCertAlerts([]string{"a", "b"}, path); err != nil {
+		t.Fatalf("WriteCertAlerts failed: %v", err)
+	}
+	keys, err = ReadCertAlerts(path)
+	if err != nil || !reflect.DeepEqual(keys, []string{"a", "b"}) {
+		t.Errorf("Expected the written alerts, got %v (%v)", keys, err)
+	}
+
+	if err := os.WriteFile(path, []byte("{"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if _, err := ReadCertAlerts(path); err == nil {
+		t.Error("Expected an error for a corrupt file")
+	}
+}
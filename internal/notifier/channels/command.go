@@ -0,0 +1,142 @@
+package channels
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/common/params"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+)
+
+const (
+	// commandTimeout is the default timeout for notification commands in seconds
+	commandTimeout = 30
+
+	// commandMaxStderrLength is the maximum length of stderr that is logged
+	commandMaxStderrLength = 1000
+)
+
+// CommandNotifier implements notifications by running a local executable.
+// The notification is passed as JSON on stdin and summarized in PONGHUB_* environment variables.
+type CommandNotifier struct {
+	config *configure.CommandConfig
+}
+
+// NewCommandNotifier creates a new command notifier
+func NewCommandNotifier(config *configure.CommandConfig) *CommandNotifier {
+	return &CommandNotifier{config: config}
+}
+
+// Send runs the command for a plain title and message
+func (c *CommandNotifier) Send(title, message string) error {
+	return c.SendContext(context.Background(), &notifier.Notification{Title: title, Message: message})
+}
+
+// SendEvents runs the command without a delivery deadline, see SendContext
+func (c *CommandNotifier) SendEvents(notification *notifier.Notification) error {
+	return c.SendContext(context.Background(), notification)
+}
+
+// SendContext runs the command with the notification as JSON on stdin.
+// The command is killed when its own timeout expires or the context is done.
+func (c *CommandNotifier) SendContext(parent context.Context, notification *notifier.Notification) error {
+	if c.config.Path == "" {
+		return fmt.Errorf("command path not configured")
+	}
+
+	input, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	timeout := commandTimeout
+	if c.config.Timeout > 0 {
+		timeout = c.config.Timeout
+	}
+	ctx, cancel := context.WithTimeout(parent, time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	resolver := params.NewParameterResolver()
+	args := make([]string, len(c.config.Args))
+	for i, arg := range c.config.Args {
+		args[i] = resolver.ResolveParameters(arg)
+	}
+
+	countAttempt(parent)
+	cmd := exec.CommandContext(ctx, c.config.Path, args...)
+	cmd.Dir = c.config.WorkDir
+	cmd.Env = append(os.Environ(), c.buildEnv(notification, resolver)...)
+	cmd.Stdin = bytes.NewReader(input)
+	// do not wait for grandchildren that still hold stderr open after the command was killed
+	cmd.WaitDelay = time.Second
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		// stderr may contain secrets or internal details, so it is only logged and not returned,
+		// since the error is kept in the outbox and the delivery report
+		if stderrText := truncateText(strings.TrimSpace(stderr.String()), commandMaxStderrLength); stderrText != "" {
+			log.Printf("Command %s failed, stderr:\n%s", c.config.Path, stderrText)
+		}
+		if parent.Err() != nil {
+			return fmt.Errorf("command %s aborted: %w", c.config.Path, parent.Err())
+		}
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("command %s timed out after %ds", c.config.Path, timeout)
+		}
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return fmt.Errorf("command %s exited with status %d", c.config.Path, exitErr.ExitCode())
+		}
+		return fmt.Errorf("failed to run command %s: %w", c.config.Path, err)
+	}
+
+	return nil
+}
+
+// buildEnv builds the PONGHUB_* environment variables and the configured extra variables
+func (c *CommandNotifier) buildEnv(notification *notifier.Notification, resolver *params.ParameterResolver) []string {
+	eventTypes := make(map[string]bool)
+	services := make(map[string]bool)
+	for _, event := range notification.Events {
+		eventTypes[event.Type.String()] = true
+		services[event.ServiceName] = true
+	}
+
+	env := []string{
+		"PONGHUB_TITLE=" + notification.Title,
+		"PONGHUB_MESSAGE=" + notification.Message,
+		"PONGHUB_EVENT_COUNT=" + strconv.Itoa(len(notification.Events)),
+		"PONGHUB_EVENT_TYPES=" + strings.Join(sortedKeys(eventTypes), ","),
+		"PONGHUB_SERVICES=" + strings.Join(sortedKeys(services), ","),
+		"PONGHUB_HAS_PROBLEMS=" + strconv.FormatBool(notification.HasProblems()),
+		"PONGHUB_STATUS_PAGE_URL=" + notification.StatusPageURL,
+	}
+
+	for key, value := range c.config.Env {
+		env = append(env, key+"="+resolver.ResolveParameters(value))
+	}
+	return env
+}
+
+// sortedKeys returns the keys of the set in alphabetical order
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,110 @@
+package channels
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/event_type"
+)
+
+// skipIfNoShell skips command tests on platforms without /bin/sh
+func skipIfNoShell(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping command notifier test: /bin/sh not available on windows")
+	}
+}
+
+// TestCommandNotifier_SendEvents tests that the notification is passed on stdin and in environment variables
+func TestCommandNotifier_SendEvents(t *testing.T) {
+	skipIfNoShell(t)
+
+	outDir := t.TempDir()
+	config := &configure.CommandConfig{
+		Path: "/bin/sh",
+		Args: []string{"-c", `cat > "$OUT_DIR/stdin.json" && echo "$PONGHUB_EVENT_TYPES|$PONGHUB_SERVICES|$PONGHUB_HAS_PROBLEMS|$TICKET_QUEUE" > "$OUT_DIR/env.txt"`},
+		Env: map[string]string{
+			"OUT_DIR":      outDir,
+			"TICKET_QUEUE": "ops",
+		},
+	}
+
+	notification := &notifier.Notification{
+		Title: "Alert",
+		Events: []notifier.Event{
+			{Type: event_type.RECOVERY, ServiceName: "Web", URL: "https://web.example.com"},
+			{Type: event_type.OUTAGE, ServiceName: "API", URL: "https://api.example.com"},
+		},
+	}
+	if err := NewCommandNotifier(config).SendEvents(notification); err != nil {
+		t.Fatalf("Failed to run command: %v", err)
+	}
+
+	stdin, err := os.ReadFile(filepath.Join(outDir, "stdin.json"))
+	if err != nil {
+		t.Fatalf("Failed to read stdin capture: %v", err)
+	}
+	var received notifier.Notification
+	if err := json.Unmarshal(stdin, &received); err != nil {
+		t.Fatalf("Failed to parse stdin JSON: %v", err)
+	}
+	if len(received.Events) != 2 || received.Events[1].ServiceName != "API" {
+		t.Errorf("Unexpected events on stdin: %+v", received.Events)
+	}
+
+	env, err := os.ReadFile(filepath.Join(outDir, "env.txt"))
+	if err != nil {
+		t.Fatalf("Failed to read env capture: %v", err)
+	}
+	if strings.TrimSpace(string(env)) != "outage,recovery|API,Web|true|ops" {
+		t.Errorf("Unexpected environment: %q", string(env))
+	}
+}
+
+// TestCommandNotifier_ExitStatus tests that a non-zero exit status is reported and stderr is only logged
+func TestCommandNotifier_ExitStatus(t *testing.T) {
+	skipIfNoShell(t)
+
+	config := &configure.CommandConfig{
+		Path: "/bin/sh",
+		Args: []string{"-c", "echo 'ticket API unreachable' >&2; exit 3"},
+	}
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	err := NewCommandNotifier(config).Send("Test", "Test message")
+	if err == nil {
+		t.Fatal("Expected error for non-zero exit status")
+	}
+	if !strings.Contains(err.Error(), "exited with status 3") || strings.Contains(err.Error(), "ticket API unreachable") {
+		t.Errorf("Expected exit status without stderr in error, got: %v", err)
+	}
+	if !strings.Contains(logs.String(), "ticket API unreachable") {
+		t.Errorf("Expected stderr to be logged, got: %q", logs.String())
+	}
+}
+
+// TestCommandNotifier_Timeout tests that long-running commands are killed after the timeout
+func TestCommandNotifier_Timeout(t *testing.T) {
+	skipIfNoShell(t)
+
+	config := &configure.CommandConfig{
+		Path:    "/bin/sh",
+		Args:    []string{"-c", "sleep 5"},
+		Timeout: 1,
+	}
+
+	err := NewCommandNotifier(config).Send("Test", "Test message")
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("Expected timeout error, got: %v", err)
+	}
+}
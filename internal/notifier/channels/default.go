@@ -4,9 +4,9 @@ import (
 	"fmt"
 	"log"
 	"os"
-	"path/filepath"
 
 	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/types/default_config"
 )
 
 // DefaultNotifier implements the NotificationService interface for default GitHub Actions notifications
@@ -40,7 +40,7 @@ func (d *DefaultNotifier) Send(title, message string) error {
 
 	// Create flag file to indicate default notification is enabled
 	if d.config.Enabled {
-		flagPath := filepath.Join("data", "default_enabled.txt")
+		flagPath := default_config.GetDefaultEnabledPath()
 		if err := os.WriteFile(flagPath, []byte("true"), 0644); err != nil {
 			log.Printf("Failed to create default enabled flag: %v", err)
 		} else {
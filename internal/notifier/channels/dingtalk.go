@@ -0,0 +1,89 @@
+package channels
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/common/params"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+)
+
+// dingTalkMaxTextLength is the maximum length of a DingTalk text message
+const dingTalkMaxTextLength = 5000
+
+// DingTalkNotifier implements DingTalk custom robot notifications
+type DingTalkNotifier struct {
+	config *configure.DingTalkConfig
+}
+
+// NewDingTalkNotifier creates a new DingTalk notifier
+func NewDingTalkNotifier(config *configure.DingTalkConfig) *DingTalkNotifier {
+	return &DingTalkNotifier{config: config}
+}
+
+// Send sends the title and message, see SendContext
+func (d *DingTalkNotifier) Send(title, message string) error {
+	return d.SendContext(context.Background(), &notifier.Notification{Title: title, Message: message})
+}
+
+// SendContext sends a text message through the DingTalk robot webhook
+func (d *DingTalkNotifier) SendContext(ctx context.Context, notification *notifier.Notification) error {
+	title, message := notification.Title, notification.Message
+
+	resolver := params.NewParameterResolver()
+
+	webhookURL := resolveWithEnvFallback(resolver, d.config.WebhookURL, "DINGTALK_WEBHOOK_URL")
+	if webhookURL == "" {
+		return fmt.Errorf("dingtalk webhook URL not configured")
+	}
+
+	// Sign the request if the robot has the "additional signature" security setting enabled
+	secret := resolveWithEnvFallback(resolver, d.config.Secret, "DINGTALK_SECRET")
+	if secret != "" {
+		signedURL, err := d.signURL(webhookURL, secret, time.Now())
+		if err != nil {
+			return err
+		}
+		webhookURL = signedURL
+	}
+
+	payload := map[string]interface{}{
+		"msgtype": "text",
+		"text": map[string]string{
+			"content": truncateText(title+"\n\n"+message, dingTalkMaxTextLength),
+		},
+		"at": map[string]interface{}{
+			"atMobiles": d.config.AtMobiles,
+			"isAtAll":   d.config.AtAll,
+		},
+	}
+
+	body, err := sendHTTPRequestWithResponse(ctx, webhookURL, "POST", payload, nil, d.config.Retries, d.config.Timeout, false)
+	if err != nil {
+		return fmt.Errorf("dingtalk request failed: %w", err)
+	}
+
+	return checkBotResponse(body)
+}
+
+// signURL appends the timestamp and HMAC-SHA256 signature query parameters required by DingTalk
+func (d *DingTalkNotifier) signURL(webhookURL, secret string, now time.Time) (string, error) {
+	u, err := url.Parse(webhookURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid dingtalk webhook URL: %w", err)
+	}
+
+	timestamp := fmt.Sprintf("%d", now.UnixMilli())
+	sign := signHMACSHA256(secret, strings.Join([]string{timestamp, secret}, "\n"))
+
+	query := u.Query()
+	query.Set("timestamp", timestamp)
+	query.Set("sign", sign)
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
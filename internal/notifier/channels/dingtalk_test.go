@@ -0,0 +1,87 @@
+package channels
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+)
+
+// TestDingTalkNotifier_Send tests that signed text messages are sent to the robot webhook
+func TestDingTalkNotifier_Send(t *testing.T) {
+	var receivedQuery url.Values
+	var receivedPayload map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedQuery = r.URL.Query()
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &receivedPayload); err != nil {
+			t.Errorf("Failed to parse JSON: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"errcode": 0, "errmsg": "ok"}`))
+	}))
+	defer server.Close()
+
+	config := &configure.DingTalkConfig{
+		WebhookURL: server.URL + "/robot/send?access_token=abc",
+		Secret:     "SECtest",
+		AtMobiles:  []string{"13800000000"},
+	}
+
+	if err := NewDingTalkNotifier(config).Send("Service Down", "Database error"); err != nil {
+		t.Fatalf("Failed to send dingtalk message: %v", err)
+	}
+
+	if receivedQuery.Get("access_token") != "abc" {
+		t.Errorf("Expected access_token to be preserved, got '%s'", receivedQuery.Get("access_token"))
+	}
+
+	// Verify the signature server-side
+	timestamp := receivedQuery.Get("timestamp")
+	expectedSign := signHMACSHA256("SECtest", timestamp+"\nSECtest")
+	if receivedQuery.Get("sign") != expectedSign {
+		t.Errorf("Expected sign '%s', got '%s'", expectedSign, receivedQuery.Get("sign"))
+	}
+
+	if receivedPayload["msgtype"] != "text" {
+		t.Errorf("Expected msgtype 'text', got '%v'", receivedPayload["msgtype"])
+	}
+	text, _ := receivedPayload["text"].(map[string]interface{})
+	if text["content"] != "Service Down\n\nDatabase error" {
+		t.Errorf("Unexpected content: %v", text["content"])
+	}
+}
+
+// TestDingTalkNotifier_SignURL tests the signature calculation against a fixed timestamp
+func TestDingTalkNotifier_SignURL(t *testing.T) {
+	notifier := NewDingTalkNotifier(&configure.DingTalkConfig{})
+	signedURL, err := notifier.signURL("https://oapi.dingtalk.com/robot/send?access_token=abc", "secret", time.UnixMilli(1700000000000))
+	if err != nil {
+		t.Fatalf("Failed to sign URL: %v", err)
+	}
+
+	u, _ := url.Parse(signedURL)
+	if u.Query().Get("timestamp") != "1700000000000" {
+		t.Errorf("Expected timestamp '1700000000000', got '%s'", u.Query().Get("timestamp"))
+	}
+	if u.Query().Get("sign") != signHMACSHA256("secret", "1700000000000\nsecret") {
+		t.Errorf("Unexpected sign '%s'", u.Query().Get("sign"))
+	}
+}
+
+// TestDingTalkNotifier_APIError tests that a non-zero errcode is reported as failure
+func TestDingTalkNotifier_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"errcode": 310000, "errmsg": "sign not match"}`))
+	}))
+	defer server.Close()
+
+	err := NewDingTalkNotifier(&configure.DingTalkConfig{WebhookURL: server.URL}).Send("Test", "Test message")
+	if err == nil {
+		t.Fatal("Expected error for non-zero errcode")
+	}
+}
@@ -1,14 +1,17 @@
 package channels
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
 	"log"
+	"mime"
+	"net"
 	"net/smtp"
-	"os"
 	"time"
 
 	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/structures/notifier"
 )
 
 // EmailNotifier implements email notifications
@@ -21,52 +24,69 @@ func NewEmailNotifier(config *configure.EmailConfig) *EmailNotifier {
 	return &EmailNotifier{config: config}
 }
 
-// Send sends an email notification with secure SMTP connection
+// Send sends an email notification for a plain title and message
 func (e *EmailNotifier) Send(title, message string) error {
-	// Get SMTP credentials from environment variables
-	username := os.Getenv("SMTP_USERNAME")
-	password := os.Getenv("SMTP_PASSWORD")
+	return e.SendContext(context.Background(), &notifier.Notification{Title: title, Message: message})
+}
+
+// SendEvents sends the notification without a deadline, see SendContext
+func (e *EmailNotifier) SendEvents(notification *notifier.Notification) error {
+	return e.SendContext(context.Background(), notification)
+}
 
-	if username == "" || password == "" {
-		return fmt.Errorf("SMTP credentials not found in environment variables")
+// SendContext sends a multipart email with an HTML body and a plain text fallback.
+// The SMTP session is aborted when the context is done.
+func (e *EmailNotifier) SendContext(ctx context.Context, notification *notifier.Notification) error {
+	auth, err := e.buildAuth()
+	if err != nil {
+		return err
+	}
+
+	body, err := e.buildMessage(notification)
+	if err != nil {
+		return err
 	}
 
 	addr := fmt.Sprintf("%s:%d", e.config.SMTPHost, e.config.SMTPPort)
+	countAttempt(ctx)
 
 	// Use secure connection based on configuration
 	if e.config.UseTLS {
 		// Direct TLS connection (typically port 465)
-		return e.sendWithTLS(addr, username, password, title, message)
+		return e.sendWithTLS(ctx, addr, auth, body)
 	} else if e.config.UseStartTLS {
 		// STARTTLS connection (typically port 587)
-		return e.sendWithStartTLS(addr, username, password, title, message)
+		return e.sendWithStartTLS(ctx, addr, auth, body)
 	} else {
 		// Plain connection - warn about security risk
-		fmt.Printf("WARNING: Using plain SMTP connection without TLS. This is insecure and credentials will be sent in plain text. Consider enabling use_tls or use_starttls in your configuration.\n")
-		return e.sendPlain(addr, username, password, title, message)
+		if auth != nil {
+			fmt.Printf("WARNING: Using plain SMTP connection without TLS. This is insecure and credentials will be sent in plain text. Consider enabling use_tls or use_starttls in your configuration.\n")
+		}
+		return e.sendPlain(ctx, addr, auth, body)
 	}
 }
 
 // sendWithTLS sends email using direct TLS connection
-func (e *EmailNotifier) sendWithTLS(addr, username, password, title, message string) error {
+func (e *EmailNotifier) sendWithTLS(ctx context.Context, addr string, auth smtp.Auth, body string) error {
 	tlsConfig := &tls.Config{
 		ServerName:         e.config.SMTPHost,
 		InsecureSkipVerify: e.config.SkipVerify,
 	}
 
-	conn, err := tls.Dial("tcp", addr, tlsConfig)
+	dialer := &tls.Dialer{Config: tlsConfig}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
 	if err != nil {
 		return fmt.Errorf("failed to establish TLS connection: %w", err)
 	}
-	defer func(conn *tls.Conn) {
+	defer func(conn net.Conn) {
 		if err := conn.Close(); err != nil {
 			log.Printf("Error closing TLS connection: %v", err)
 		}
 	}(conn)
 
-	client, err := smtp.NewClient(conn, e.config.SMTPHost)
+	client, err := newSMTPClient(ctx, conn, e.config.SMTPHost)
 	if err != nil {
-		return fmt.Errorf("failed to create SMTP client: %w", err)
+		return err
 	}
 	defer func(client *smtp.Client) {
 		if err := client.Quit(); err != nil {
@@ -74,19 +94,18 @@ func (e *EmailNotifier) sendWithTLS(addr, username, password, title, message str
 		}
 	}(client)
 
-	auth := smtp.PlainAuth("", username, password, e.config.SMTPHost)
-	if err := client.Auth(auth); err != nil {
-		return fmt.Errorf("SMTP authentication failed: %w", err)
+	if err := authenticate(client, auth); err != nil {
+		return err
 	}
 
-	return e.sendMessage(client, title, message)
+	return e.sendMessage(client, body)
 }
 
 // sendWithStartTLS sends email using STARTTLS
-func (e *EmailNotifier) sendWithStartTLS(addr, username, password, title, message string) error {
-	client, err := smtp.Dial(addr)
+func (e *EmailNotifier) sendWithStartTLS(ctx context.Context, addr string, auth smtp.Auth, body string) error {
+	client, err := e.dial(ctx, addr)
 	if err != nil {
-		return fmt.Errorf("failed to connect to SMTP server: %w", err)
+		return err
 	}
 	defer func(client *smtp.Client) {
 		if err := client.Quit(); err != nil {
@@ -103,19 +122,18 @@ func (e *EmailNotifier) sendWithStartTLS(addr, username, password, title, messag
 		return fmt.Errorf("failed to start TLS: %w", err)
 	}
 
-	auth := smtp.PlainAuth("", username, password, e.config.SMTPHost)
-	if err := client.Auth(auth); err != nil {
-		return fmt.Errorf("SMTP authentication failed: %w", err)
+	if err := authenticate(client, auth); err != nil {
+		return err
 	}
 
-	return e.sendMessage(client, title, message)
+	return e.sendMessage(client, body)
 }
 
 // sendPlain sends email using plain connection (not recommended)
-func (e *EmailNotifier) sendPlain(addr, username, password, title, message string) error {
-	client, err := smtp.Dial(addr)
+func (e *EmailNotifier) sendPlain(ctx context.Context, addr string, auth smtp.Auth, body string) error {
+	client, err := e.dial(ctx, addr)
 	if err != nil {
-		return fmt.Errorf("failed to connect to SMTP server: %w", err)
+		return err
 	}
 	defer func(client *smtp.Client) {
 		if err := client.Quit(); err != nil {
@@ -123,16 +141,45 @@ func (e *EmailNotifier) sendPlain(addr, username, password, title, message strin
 		}
 	}(client)
 
-	auth := smtp.PlainAuth("", username, password, e.config.SMTPHost)
-	if err := client.Auth(auth); err != nil {
-		return fmt.Errorf("SMTP authentication failed: %w", err)
+	if err := authenticate(client, auth); err != nil {
+		return err
+	}
+
+	return e.sendMessage(client, body)
+}
+
+// dial opens a plain SMTP connection that is aborted when the context is done
+func (e *EmailNotifier) dial(ctx context.Context, addr string) (*smtp.Client, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to SMTP server: %w", err)
+	}
+	client, err := newSMTPClient(ctx, conn, e.config.SMTPHost)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
 	}
+	return client, nil
+}
 
-	return e.sendMessage(client, title, message)
+// newSMTPClient creates an SMTP client on the connection, using the context deadline
+// as the connection deadline so that a stalled server cannot block the delivery
+func newSMTPClient(ctx context.Context, conn net.Conn, host string) (*smtp.Client, error) {
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return nil, fmt.Errorf("failed to set SMTP connection deadline: %w", err)
+		}
+	}
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SMTP client: %w", err)
+	}
+	return client, nil
 }
 
 // sendMessage sends the actual email message using the SMTP client
-func (e *EmailNotifier) sendMessage(client *smtp.Client, title, message string) error {
+func (e *EmailNotifier) sendMessage(client *smtp.Client, body string) error {
 	// Set sender
 	if err := client.Mail(e.config.From); err != nil {
 		return fmt.Errorf("failed to set sender: %w", err)
@@ -151,8 +198,7 @@ func (e *EmailNotifier) sendMessage(client *smtp.Client, title, message string)
 		return fmt.Errorf("failed to get data writer: %w", err)
 	}
 
-	emailBody := e.buildEmailBody(title, message)
-	if _, err := writer.Write([]byte(emailBody)); err != nil {
+	if _, err := writer.Write([]byte(body)); err != nil {
 		return fmt.Errorf("failed to write email body: %w", err)
 	}
 
@@ -163,14 +209,30 @@ func (e *EmailNotifier) sendMessage(client *smtp.Client, title, message string)
 	return nil
 }
 
-// buildEmailBody constructs the email body with proper headers
+// authenticate authenticates the SMTP client unless no authentication is configured
+func authenticate(client *smtp.Client, auth smtp.Auth) error {
+	if auth == nil {
+		return nil
+	}
+	if err := client.Auth(auth); err != nil {
+		return fmt.Errorf("SMTP authentication failed: %w", err)
+	}
+	return nil
+}
+
+// buildEmailBody constructs a plain text email body with proper headers
 func (e *EmailNotifier) buildEmailBody(title, message string) string {
+	return e.buildHeaders(title, "text/plain; charset=UTF-8") + "\r\n" + message
+}
+
+// buildHeaders constructs the email headers with the given content type
+func (e *EmailNotifier) buildHeaders(title, contentType string) string {
 	headers := make(map[string]string)
 	headers["From"] = e.config.From
 	headers["To"] = e.formatRecipients()
-	headers["Subject"] = title
+	headers["Subject"] = mime.QEncoding.Encode("UTF-8", title)
 	headers["MIME-Version"] = "1.0"
-	headers["Content-Type"] = "text/plain; charset=UTF-8"
+	headers["Content-Type"] = contentType
 	headers["Date"] = time.Now().Format(time.RFC1123Z)
 
 	// Add custom headers if configured
@@ -183,9 +245,7 @@ func (e *EmailNotifier) buildEmailBody(title, message string) string {
 	for key, value := range headers {
 		headerStr += fmt.Sprintf("%s: %s\r\n", key, value)
 	}
-
-	// Combine headers and body
-	return headerStr + "\r\n" + message
+	return headerStr
 }
 
 // formatRecipients formats the recipient list for the To header
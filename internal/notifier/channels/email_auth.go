@@ -0,0 +1,151 @@
+package channels
+
+import (
+	"errors"
+	"fmt"
+	"net/smtp"
+	"os"
+	"strings"
+
+	"github.com/wcy-dt/ponghub/internal/common/params"
+)
+
+const (
+	// emailAuthPlain is the SMTP PLAIN authentication mechanism
+	emailAuthPlain = "plain"
+
+	// emailAuthLogin is the SMTP LOGIN authentication mechanism, used by older Exchange servers
+	emailAuthLogin = "login"
+
+	// emailAuthCRAMMD5 is the SMTP CRAM-MD5 challenge-response authentication mechanism
+	emailAuthCRAMMD5 = "cram-md5"
+
+	// emailAuthXOAUTH2 is the SMTP XOAUTH2 authentication mechanism used by Gmail and Microsoft 365
+	emailAuthXOAUTH2 = "xoauth2"
+
+	// emailAuthNone disables SMTP authentication, e.g. for internal relays
+	emailAuthNone = "none"
+)
+
+// buildAuth creates the SMTP authentication for the configured mechanism.
+// It returns nil if no authentication should be performed.
+func (e *EmailNotifier) buildAuth() (smtp.Auth, error) {
+	mechanism := strings.ToLower(e.config.Auth)
+	if mechanism == emailAuthNone {
+		return nil, nil
+	}
+
+	username, err := readCredential(e.config.Username, e.config.UsernameFile, "SMTP_USERNAME")
+	if err != nil {
+		return nil, err
+	}
+	password, err := readCredential(e.config.Password, e.config.PasswordFile, "SMTP_PASSWORD")
+	if err != nil {
+		return nil, err
+	}
+
+	if mechanism == "" {
+		if username == "" && password == "" {
+			return nil, nil
+		}
+		mechanism = emailAuthPlain
+	}
+	if username == "" || password == "" {
+		return nil, fmt.Errorf("SMTP credentials not found for %s authentication", mechanism)
+	}
+
+	switch mechanism {
+	case emailAuthPlain:
+		return smtp.PlainAuth("", username, password, e.config.SMTPHost), nil
+	case emailAuthLogin:
+		return &loginAuth{username: username, password: password, host: e.config.SMTPHost}, nil
+	case emailAuthCRAMMD5:
+		return smtp.CRAMMD5Auth(username, password), nil
+	case emailAuthXOAUTH2:
+		return &xoauth2Auth{username: username, token: password, host: e.config.SMTPHost}, nil
+	default:
+		return nil, fmt.Errorf("unknown SMTP authentication mechanism: %s", e.config.Auth)
+	}
+}
+
+// readCredential reads a credential from a file if one is configured, otherwise it resolves
+// Special Parameters in value and falls back to the given environment variable
+func readCredential(value, file, envName string) (string, error) {
+	if file != "" {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return "", fmt.Errorf("failed to read SMTP credential file: %w", err)
+		}
+		return strings.TrimSpace(string(content)), nil
+	}
+	return resolveWithEnvFallback(params.NewParameterResolver(), value, envName), nil
+}
+
+// checkAuthConnection refuses to send credentials over unencrypted connections to remote hosts,
+// in the same way as smtp.PlainAuth
+func checkAuthConnection(server *smtp.ServerInfo, host string) error {
+	if !server.TLS && !isLocalhost(server.Name) {
+		return errors.New("unencrypted connection")
+	}
+	if server.Name != host {
+		return errors.New("wrong host name")
+	}
+	return nil
+}
+
+// isLocalhost checks if the SMTP server runs on the local machine
+func isLocalhost(name string) bool {
+	return name == "localhost" || name == "127.0.0.1" || name == "::1"
+}
+
+// loginAuth implements the SMTP LOGIN authentication mechanism
+type loginAuth struct {
+	username, password, host string
+}
+
+// Start begins the LOGIN authentication
+func (a *loginAuth) Start(server *smtp.ServerInfo) (string, []byte, error) {
+	if err := checkAuthConnection(server, a.host); err != nil {
+		return "", nil, err
+	}
+	return "LOGIN", nil, nil
+}
+
+// Next answers the username and password prompts of the server
+func (a *loginAuth) Next(fromServer []byte, more bool) ([]byte, error) {
+	if !more {
+		return nil, nil
+	}
+
+	prompt := strings.ToLower(strings.TrimSpace(string(fromServer)))
+	switch {
+	case strings.Contains(prompt, "username"):
+		return []byte(a.username), nil
+	case strings.Contains(prompt, "password"):
+		return []byte(a.password), nil
+	default:
+		return nil, fmt.Errorf("unexpected LOGIN challenge: %s", fromServer)
+	}
+}
+
+// xoauth2Auth implements the SMTP XOAUTH2 authentication mechanism
+type xoauth2Auth struct {
+	username, token, host string
+}
+
+// Start sends the username and the OAuth2 access token
+func (a *xoauth2Auth) Start(server *smtp.ServerInfo) (string, []byte, error) {
+	if err := checkAuthConnection(server, a.host); err != nil {
+		return "", nil, err
+	}
+	return "XOAUTH2", []byte("user=" + a.username + "\x01auth=Bearer " + a.token + "\x01\x01"), nil
+}
+
+// Next acknowledges the error details sent by the server on failure,
+// so that the server responds with the final error status
+func (a *xoauth2Auth) Next(_ []byte, more bool) ([]byte, error) {
+	if more {
+		return []byte{}, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,276 @@
+package channels
+
+import (
+	"bufio"
+	"crypto/hmac"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"net"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+)
+
+// fakeSMTPSession records what a client sent to the fake SMTP server
+type fakeSMTPSession struct {
+	Auth       string
+	Recipients []string
+	Data       string
+}
+
+// startFakeSMTPServer starts an SMTP server on localhost that accepts a single session,
+// advertises the given authentication mechanisms and accepts any credentials
+func startFakeSMTPServer(t *testing.T, mechanisms string) (int, <-chan fakeSMTPSession) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	sessions := make(chan fakeSMTPSession, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+
+		var session fakeSMTPSession
+		reader := bufio.NewReader(conn)
+		readLine := func() string {
+			line, _ := reader.ReadString('\n')
+			return strings.TrimRight(line, "\r\n")
+		}
+		decode := func(value string) string {
+			decoded, _ := base64.StdEncoding.DecodeString(value)
+			return string(decoded)
+		}
+		write := func(line string) {
+			_, _ = conn.Write([]byte(line + "\r\n"))
+		}
+
+		write("220 localhost ESMTP")
+		for {
+			line := readLine()
+			command := strings.ToUpper(line)
+			switch {
+			case line == "":
+				sessions <- session
+				return
+			case strings.HasPrefix(command, "EHLO"):
+				if mechanisms == "" {
+					write("250 localhost")
+				} else {
+					write("250-localhost")
+					write("250 AUTH " + mechanisms)
+				}
+			case strings.HasPrefix(command, "AUTH PLAIN"):
+				session.Auth = "PLAIN " + decode(strings.TrimSpace(line[len("AUTH PLAIN"):]))
+				write("235 2.7.0 Authentication successful")
+			case strings.HasPrefix(command, "AUTH LOGIN"):
+				write("334 " + base64.StdEncoding.EncodeToString([]byte("Username:")))
+				username := decode(readLine())
+				write("334 " + base64.StdEncoding.EncodeToString([]byte("Password:")))
+				session.Auth = "LOGIN " + username + " " + decode(readLine())
+				write("235 2.7.0 Authentication successful")
+			case strings.HasPrefix(command, "AUTH CRAM-MD5"):
+				write("334 " + base64.StdEncoding.EncodeToString([]byte("<1234@localhost>")))
+				session.Auth = "CRAM-MD5 " + decode(readLine())
+				write("235 2.7.0 Authentication successful")
+			case strings.HasPrefix(command, "AUTH XOAUTH2"):
+				session.Auth = "XOAUTH2 " + decode(strings.TrimSpace(line[len("AUTH XOAUTH2"):]))
+				write("235 2.7.0 Authentication successful")
+			case strings.HasPrefix(command, "MAIL FROM"):
+				write("250 OK")
+			case strings.HasPrefix(command, "RCPT TO"):
+				session.Recipients = append(session.Recipients, strings.Trim(line[len("RCPT TO:"):], "<>"))
+				write("250 OK")
+			case command == "DATA":
+				write("354 End data with <CR><LF>.<CR><LF>")
+				var data strings.Builder
+				for dataLine := readLine(); dataLine != "."; dataLine = readLine() {
+					data.WriteString(dataLine + "\n")
+				}
+				session.Data = data.String()
+				write("250 OK")
+			case command == "QUIT":
+				write("221 Bye")
+				sessions <- session
+				return
+			default:
+				write("502 Command not implemented")
+			}
+		}
+	}()
+
+	return listener.Addr().(*net.TCPAddr).Port, sessions
+}
+
+// newLocalEmailConfig returns an email config for the fake SMTP server
+func newLocalEmailConfig(port int) *configure.EmailConfig {
+	return &configure.EmailConfig{
+		SMTPHost:  "127.0.0.1",
+		SMTPPort:  port,
+		From:      "ponghub@example.com",
+		To:        []string{"ops@example.com"},
+		PlainText: true,
+	}
+}
+
+func TestEmailNotifier_Send_AuthMechanisms(t *testing.T) {
+	t.Setenv("SMTP_USERNAME", "")
+	t.Setenv("SMTP_PASSWORD", "")
+	t.Setenv("TEST_SMTP_PASSWORD", "secret")
+
+	mac := hmac.New(md5.New, []byte("secret"))
+	mac.Write([]byte("<1234@localhost>"))
+
+	tests := []struct {
+		auth     string
+		expected string
+	}{
+		{auth: "", expected: "PLAIN \x00alice\x00secret"},
+		{auth: "plain", expected: "PLAIN \x00alice\x00secret"},
+		{auth: "LOGIN", expected: "LOGIN alice secret"},
+		{auth: "cram-md5", expected: "CRAM-MD5 alice " + hex.EncodeToString(mac.Sum(nil))},
+		{auth: "xoauth2", expected: "XOAUTH2 user=alice\x01auth=Bearer secret\x01\x01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.auth, func(t *testing.T) {
+			port, sessions := startFakeSMTPServer(t, "PLAIN LOGIN CRAM-MD5 XOAUTH2")
+
+			config := newLocalEmailConfig(port)
+			config.Auth = tt.auth
+			config.Username = "alice"
+			config.Password = "{{env(TEST_SMTP_PASSWORD)}}"
+
+			if err := NewEmailNotifier(config).Send("Test", "Test Message"); err != nil {
+				t.Fatalf("Send failed: %v", err)
+			}
+
+			session := <-sessions
+			if session.Auth != tt.expected {
+				t.Errorf("Expected authentication %q, got %q", tt.expected, session.Auth)
+			}
+			if !strings.Contains(session.Data, "Test Message") {
+				t.Error("Expected the message to be delivered")
+			}
+		})
+	}
+}
+
+func TestEmailNotifier_Send_NoAuth(t *testing.T) {
+	t.Setenv("SMTP_USERNAME", "")
+	t.Setenv("SMTP_PASSWORD", "")
+
+	port, sessions := startFakeSMTPServer(t, "")
+
+	config := newLocalEmailConfig(port)
+	config.To = []string{"ops@example.com", "dev@example.com"}
+	if err := NewEmailNotifier(config).Send("Test", "Test Message"); err != nil {
+		t.Fatalf("Send without credentials failed: %v", err)
+	}
+
+	session := <-sessions
+	if session.Auth != "" {
+		t.Errorf("Expected no authentication, got %q", session.Auth)
+	}
+	if strings.Join(session.Recipients, ",") != "ops@example.com,dev@example.com" {
+		t.Errorf("Unexpected recipients: %v", session.Recipients)
+	}
+}
+
+func TestEmailNotifier_Send_ExplicitNoAuth(t *testing.T) {
+	t.Setenv("SMTP_USERNAME", "alice")
+	t.Setenv("SMTP_PASSWORD", "secret")
+
+	port, sessions := startFakeSMTPServer(t, "PLAIN")
+
+	config := newLocalEmailConfig(port)
+	config.Auth = "none"
+	if err := NewEmailNotifier(config).Send("Test", "Test Message"); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	if session := <-sessions; session.Auth != "" {
+		t.Errorf("Expected no authentication, got %q", session.Auth)
+	}
+}
+
+func TestEmailNotifier_Send_CredentialFiles(t *testing.T) {
+	t.Setenv("SMTP_USERNAME", "")
+	t.Setenv("SMTP_PASSWORD", "")
+
+	dir := t.TempDir()
+	usernameFile := filepath.Join(dir, "username")
+	passwordFile := filepath.Join(dir, "password")
+	if err := os.WriteFile(usernameFile, []byte("bob\n"), 0600); err != nil {
+		t.Fatalf("Failed to write username file: %v", err)
+	}
+	if err := os.WriteFile(passwordFile, []byte("from-file\n"), 0600); err != nil {
+		t.Fatalf("Failed to write password file: %v", err)
+	}
+
+	port, sessions := startFakeSMTPServer(t, "LOGIN")
+
+	config := newLocalEmailConfig(port)
+	config.Auth = "login"
+	config.UsernameFile = usernameFile
+	config.PasswordFile = passwordFile
+	if err := NewEmailNotifier(config).Send("Test", "Test Message"); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	if session := <-sessions; session.Auth != "LOGIN bob from-file" {
+		t.Errorf("Unexpected authentication: %q", session.Auth)
+	}
+}
+
+func TestEmailNotifier_BuildAuth_Errors(t *testing.T) {
+	t.Setenv("SMTP_USERNAME", "alice")
+	t.Setenv("SMTP_PASSWORD", "secret")
+
+	tests := []struct {
+		name   string
+		config *configure.EmailConfig
+		errMsg string
+	}{
+		{
+			name:   "Unknown mechanism",
+			config: &configure.EmailConfig{Auth: "digest-md5"},
+			errMsg: "unknown SMTP authentication mechanism",
+		},
+		{
+			name:   "Missing credential file",
+			config: &configure.EmailConfig{PasswordFile: filepath.Join(t.TempDir(), "missing")},
+			errMsg: "failed to read SMTP credential file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewEmailNotifier(tt.config).buildAuth()
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Expected error containing %q, got %v", tt.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestLoginAuth_RefusesUnencryptedRemoteHost(t *testing.T) {
+	auth := &loginAuth{username: "alice", password: "secret", host: "smtp.example.com"}
+	if _, _, err := auth.Start(&smtp.ServerInfo{Name: "smtp.example.com"}); err == nil {
+		t.Error("Expected LOGIN to be refused on an unencrypted connection")
+	}
+	if _, _, err := auth.Start(&smtp.ServerInfo{Name: "smtp.example.com", TLS: true}); err != nil {
+		t.Errorf("Expected LOGIN to be allowed over TLS, got %v", err)
+	}
+}
@@ -0,0 +1,310 @@
+package channels
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/base64"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"log"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/default_config"
+	"github.com/wcy-dt/ponghub/internal/types/types/event_type"
+)
+
+// defaultEmailHTMLTemplate is the built-in HTML email body, it only uses inline styles
+// and tables so that it renders in Outlook and webmail clients
+//
+//go:embed templates/email.html
+var defaultEmailHTMLTemplate string
+
+type (
+	// emailTemplateData is the data passed to the email templates
+	emailTemplateData struct {
+		Title          string
+		Message        string
+		GeneratedAt    string
+		StatusPageURL  string
+		Services       []emailService
+		Certs          []notifier.Event
+		Events         []notifier.Event
+		OutageCount    int
+		RecoveryCount  int
+		CertCount      int
+		ReportAttached bool
+	}
+
+	// emailService groups the availability events of a single service
+	emailService struct {
+		Name   string
+		Events []notifier.Event
+	}
+)
+
+// buildMessage builds the complete email with headers. Unless plain_text is set, the
+// email is multipart/alternative with a text and an HTML part, wrapped in
+// multipart/mixed when the report is attached.
+func (e *EmailNotifier) buildMessage(notification *notifier.Notification) (string, error) {
+	report := e.readReport(notification)
+	data := newEmailTemplateData(notification, report != nil)
+
+	text, err := e.renderText(data)
+	if err != nil {
+		return "", err
+	}
+	if e.config.PlainText && report == nil {
+		return e.buildEmailBody(notification.Title, text), nil
+	}
+
+	var content bytes.Buffer
+	contentType := "text/plain; charset=UTF-8"
+	if e.config.PlainText {
+		if err := writeQuotedPrintable(&content, text); err != nil {
+			return "", err
+		}
+	} else {
+		html, err := e.renderHTML(data)
+		if err != nil {
+			return "", err
+		}
+		alternative := multipart.NewWriter(&content)
+		if err := writeTextPart(alternative, "text/plain; charset=UTF-8", text); err != nil {
+			return "", err
+		}
+		if err := writeTextPart(alternative, "text/html; charset=UTF-8", html); err != nil {
+			return "", err
+		}
+		if err := alternative.Close(); err != nil {
+			return "", fmt.Errorf("failed to close multipart writer: %w", err)
+		}
+		contentType = "multipart/alternative; boundary=" + alternative.Boundary()
+	}
+
+	if report == nil {
+		return e.buildHeaders(notification.Title, contentType) + "\r\n" + content.String(), nil
+	}
+
+	var mixedBody bytes.Buffer
+	mixed := multipart.NewWriter(&mixedBody)
+	contentHeader := textproto.MIMEHeader{"Content-Type": {contentType}}
+	if e.config.PlainText {
+		contentHeader.Set("Content-Transfer-Encoding", "quoted-printable")
+	}
+	part, err := mixed.CreatePart(contentHeader)
+	if err != nil {
+		return "", fmt.Errorf("failed to create email part: %w", err)
+	}
+	if _, err := part.Write(content.Bytes()); err != nil {
+		return "", fmt.Errorf("failed to write email part: %w", err)
+	}
+	if err := writeAttachment(mixed, filepath.Base(e.reportPath()), "text/html; charset=UTF-8", report); err != nil {
+		return "", err
+	}
+	if err := mixed.Close(); err != nil {
+		return "", fmt.Errorf("failed to close multipart writer: %w", err)
+	}
+
+	return e.buildHeaders(notification.Title, "multipart/mixed; boundary="+mixed.Boundary()) + "\r\n" + mixedBody.String(), nil
+}
+
+// newEmailTemplateData groups the notification events for the email templates
+func newEmailTemplateData(notification *notifier.Notification, reportAttached bool) emailTemplateData {
+	data := emailTemplateData{
+		Title:          notification.Title,
+		Message:        notification.Message,
+		GeneratedAt:    time.Now().Format("2006-01-02 15:04:05"),
+		StatusPageURL:  notification.StatusPageURL,
+		Events:         notification.Events,
+		ReportAttached: reportAttached,
+	}
+
+	serviceIndex := make(map[string]int)
+	for _, event := range notification.Events {
+		switch event.Type {
+		case event_type.OUTAGE:
+			data.OutageCount++
+		case event_type.RECOVERY:
+			data.RecoveryCount++
+		case event_type.CERT_RENEWED:
+			data.Certs = append(data.Certs, event)
+			continue
+		default:
+			data.CertCount++
+			data.Certs = append(data.Certs, event)
+			continue
+		}
+
+		index, exists := serviceIndex[event.ServiceName]
+		if !exists {
+			index = len(data.Services)
+			serviceIndex[event.ServiceName] = index
+			data.Services = append(data.Services, emailService{Name: event.ServiceName})
+		}
+		data.Services[index].Events = append(data.Services[index].Events, event)
+	}
+
+	return data
+}
+
+// renderText renders the plain text part, falling back to the notification message
+func (e *EmailNotifier) renderText(data emailTemplateData) (string, error) {
+	if e.config.TextTemplate == "" {
+		if data.Message != "" {
+			return data.Message, nil
+		}
+		return summarizeEvents(&notifier.Notification{Events: data.Events}), nil
+	}
+
+	tmpl, err := texttemplate.New(filepath.Base(e.config.TextTemplate)).
+		Funcs(emailTemplateFuncs()).
+		ParseFiles(e.config.TextTemplate)
+	if err != nil {
+		return "", fmt.Errorf("text template parsing failed: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("text template execution failed: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// renderHTML renders the HTML part with the configured or built-in template
+func (e *EmailNotifier) renderHTML(data emailTemplateData) (string, error) {
+	var tmpl *htmltemplate.Template
+	var err error
+	if e.config.HTMLTemplate != "" {
+		tmpl, err = htmltemplate.New(filepath.Base(e.config.HTMLTemplate)).
+			Funcs(emailTemplateFuncs()).
+			ParseFiles(e.config.HTMLTemplate)
+	} else {
+		tmpl, err = htmltemplate.New("email.html").
+			Funcs(emailTemplateFuncs()).
+			Parse(defaultEmailHTMLTemplate)
+	}
+	if err != nil {
+		return "", fmt.Errorf("HTML template parsing failed: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("HTML template execution failed: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// readReport reads the generated report if attach_report is set.
+// A missing report is logged and the email is sent without it.
+func (e *EmailNotifier) readReport(notification *notifier.Notification) []byte {
+	if !e.config.AttachReport {
+		return nil
+	}
+	if notification.ReportMissing {
+		log.Println("The report was not generated in this run, sending email without attachment")
+		return nil
+	}
+
+	report, err := os.ReadFile(e.reportPath())
+	if err != nil {
+		log.Printf("Error reading report %s, sending email without attachment: %v", e.reportPath(), err)
+		return nil
+	}
+	return report
+}
+
+// reportPath returns the configured report path or the default one
+func (e *EmailNotifier) reportPath() string {
+	if e.config.ReportPath != "" {
+		return e.config.ReportPath
+	}
+	return default_config.GetReportPath()
+}
+
+// emailTemplateFuncs defines custom template functions for the email templates
+func emailTemplateFuncs() map[string]any {
+	return map[string]any{
+		"badgeColor": func(eventType event_type.EventType) string {
+			switch eventType {
+			case event_type.OUTAGE, event_type.CERT_EXPIRED:
+				return "#ff4136"
+			case event_type.RECOVERY, event_type.CERT_RENEWED:
+				return "#2ecc40"
+			default:
+				return "#ffb700"
+			}
+		},
+		"badgeText": func(eventType event_type.EventType) string {
+			switch eventType {
+			case event_type.OUTAGE:
+				return "DOWN"
+			case event_type.RECOVERY:
+				return "UP"
+			case event_type.CERT_EXPIRED:
+				return "EXPIRED"
+			case event_type.CERT_RENEWED:
+				return "RENEWED"
+			default:
+				return "EXPIRES SOON"
+			}
+		},
+	}
+}
+
+// writeTextPart writes a quoted-printable encoded text part
+func writeTextPart(writer *multipart.Writer, contentType, content string) error {
+	part, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {contentType},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create email part: %w", err)
+	}
+	return writeQuotedPrintable(part, content)
+}
+
+// writeQuotedPrintable writes the content with quoted-printable encoding
+func writeQuotedPrintable(w io.Writer, content string) error {
+	qp := quotedprintable.NewWriter(w)
+	if _, err := qp.Write([]byte(content)); err != nil {
+		return fmt.Errorf("failed to write email part: %w", err)
+	}
+	if err := qp.Close(); err != nil {
+		return fmt.Errorf("failed to write email part: %w", err)
+	}
+	return nil
+}
+
+// writeAttachment writes a base64 encoded attachment with lines of 76 characters
+func writeAttachment(writer *multipart.Writer, filename, contentType string, content []byte) error {
+	part, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {fmt.Sprintf("%s; name=%q", contentType, filename)},
+		"Content-Disposition":       {fmt.Sprintf("attachment; filename=%q", filename)},
+		"Content-Transfer-Encoding": {"base64"},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create attachment part: %w", err)
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(content)
+	var lines strings.Builder
+	for len(encoded) > 76 {
+		lines.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	lines.WriteString(encoded + "\r\n")
+
+	if _, err := part.Write([]byte(lines.String())); err != nil {
+		return fmt.Errorf("failed to write attachment: %w", err)
+	}
+	return nil
+}
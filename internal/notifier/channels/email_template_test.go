@@ -0,0 +1,209 @@
+package channels
+
+import (
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/event_type"
+)
+
+// testEmailNotification returns a notification with an outage, a recovery and a certificate event
+func testEmailNotification() *notifier.Notification {
+	return &notifier.Notification{
+		Title:         "🚨 PongHub Service Status Alert",
+		Message:       "🔴 UNAVAILABLE SERVICES:\n=====",
+		StatusPageURL: "https://status.example.com",
+		Events: []notifier.Event{
+			{Type: event_type.OUTAGE, ServiceName: "API", URL: "https://api.example.com/health", Method: "GET", StatusCode: 503, Error: "unexpected status <503>"},
+			{Type: event_type.RECOVERY, ServiceName: "Web", URL: "https://www.example.com"},
+			{Type: event_type.CERT_WARNING, ServiceName: "Web", URL: "https://www.example.com", CertRemainingDays: 5},
+		},
+	}
+}
+
+// readEmailParts parses the email and returns the decoded parts by content type
+func readEmailParts(t *testing.T, message string) map[string]string {
+	t.Helper()
+
+	msg, err := mail.ReadMessage(strings.NewReader(message))
+	if err != nil {
+		t.Fatalf("Failed to parse email: %v", err)
+	}
+
+	parts := make(map[string]string)
+	var walk func(body io.Reader, contentType string)
+	walk = func(body io.Reader, contentType string) {
+		mediaType, params, err := mime.ParseMediaType(contentType)
+		if err != nil {
+			t.Fatalf("Failed to parse content type %q: %v", contentType, err)
+		}
+		if !strings.HasPrefix(mediaType, "multipart/") {
+			content, err := io.ReadAll(body)
+			if err != nil {
+				t.Fatalf("Failed to read part: %v", err)
+			}
+			parts[mediaType] = string(content)
+			return
+		}
+
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed to read multipart: %v", err)
+			}
+			if filename := part.FileName(); filename != "" {
+				content, _ := io.ReadAll(part)
+				parts["attachment:"+filename] = string(content)
+				continue
+			}
+			walk(part, part.Header.Get("Content-Type"))
+		}
+	}
+	walk(msg.Body, msg.Header.Get("Content-Type"))
+
+	return parts
+}
+
+func TestEmailNotifier_BuildMessage_Multipart(t *testing.T) {
+	emailNotifier := NewEmailNotifier(&configure.EmailConfig{
+		From: "sender@example.com",
+		To:   []string{"recipient@example.com"},
+	})
+
+	message, err := emailNotifier.buildMessage(testEmailNotification())
+	if err != nil {
+		t.Fatalf("buildMessage failed: %v", err)
+	}
+	if !strings.Contains(message, "Content-Type: multipart/alternative; boundary=") {
+		t.Error("Expected multipart/alternative content type")
+	}
+	if !strings.Contains(message, "Subject: =?UTF-8?q?") {
+		t.Error("Expected encoded subject")
+	}
+
+	parts := readEmailParts(t, message)
+	if strings.ReplaceAll(parts["text/plain"], "\r\n", "\n") != "🔴 UNAVAILABLE SERVICES:\n=====" {
+		t.Errorf("Unexpected text part: %q", parts["text/plain"])
+	}
+
+	html := parts["text/html"]
+	for _, want := range []string{"https://api.example.com/health", "unexpected status &lt;503&gt;", "DOWN", "UP", "EXPIRES SOON", "Certificates", `href="https://status.example.com"`} {
+		if !strings.Contains(html, want) {
+			t.Errorf("HTML part missing %q", want)
+		}
+	}
+}
+
+func TestEmailNotifier_BuildMessage_RecoveryOnly(t *testing.T) {
+	emailNotifier := NewEmailNotifier(&configure.EmailConfig{PlainText: true})
+
+	message, err := emailNotifier.buildMessage(&notifier.Notification{
+		Title:  "✅ PongHub Service Recovery",
+		Events: []notifier.Event{{Type: event_type.RECOVERY, ServiceName: "Web", URL: "https://www.example.com"}},
+	})
+	if err != nil {
+		t.Fatalf("buildMessage failed: %v", err)
+	}
+	if !strings.Contains(message, "Content-Type: text/plain; charset=UTF-8") {
+		t.Error("Expected a plain text email")
+	}
+	if !strings.Contains(message, "https://www.example.com recovered") {
+		t.Error("Expected the recovery summary in the text body")
+	}
+}
+
+func TestEmailNotifier_BuildMessage_AttachReport(t *testing.T) {
+	reportPath := filepath.Join(t.TempDir(), "index.html")
+	if err := os.WriteFile(reportPath, []byte("<html>report</html>"), 0644); err != nil {
+		t.Fatalf("Failed to write report: %v", err)
+	}
+
+	emailNotifier := NewEmailNotifier(&configure.EmailConfig{AttachReport: true, ReportPath: reportPath})
+	message, err := emailNotifier.buildMessage(testEmailNotification())
+	if err != nil {
+		t.Fatalf("buildMessage failed: %v", err)
+	}
+	if !strings.Contains(message, "Content-Type: multipart/mixed; boundary=") {
+		t.Error("Expected multipart/mixed content type")
+	}
+
+	parts := readEmailParts(t, message)
+	if _, ok := parts["attachment:index.html"]; !ok {
+		t.Error("Expected the report as attachment")
+	}
+	if !strings.Contains(parts["text/html"], "the full report is attached") {
+		t.Error("Expected the HTML part to mention the attachment")
+	}
+
+	// the report of a previous run is not attached
+	notification := testEmailNotification()
+	notification.ReportMissing = true
+	message, err = emailNotifier.buildMessage(notification)
+	if err != nil {
+		t.Fatalf("buildMessage failed: %v", err)
+	}
+	if !strings.Contains(message, "Content-Type: multipart/alternative; boundary=") {
+		t.Error("Expected the email to be sent without the report of a previous run")
+	}
+}
+
+func TestEmailNotifier_BuildMessage_MissingReport(t *testing.T) {
+	emailNotifier := NewEmailNotifier(&configure.EmailConfig{
+		AttachReport: true,
+		ReportPath:   filepath.Join(t.TempDir(), "missing.html"),
+	})
+
+	message, err := emailNotifier.buildMessage(testEmailNotification())
+	if err != nil {
+		t.Fatalf("buildMessage failed: %v", err)
+	}
+	if !strings.Contains(message, "Content-Type: multipart/alternative; boundary=") {
+		t.Error("Expected the email to be sent without attachment")
+	}
+}
+
+func TestEmailNotifier_BuildMessage_CustomTemplates(t *testing.T) {
+	dir := t.TempDir()
+	htmlPath := filepath.Join(dir, "email.html")
+	textPath := filepath.Join(dir, "email.txt")
+	if err := os.WriteFile(htmlPath, []byte(`<p>{{.OutageCount}} down{{range .Services}} {{.Name}}{{end}}</p>`), 0644); err != nil {
+		t.Fatalf("Failed to write HTML template: %v", err)
+	}
+	if err := os.WriteFile(textPath, []byte(`{{range .Events}}{{badgeText .Type}} {{.URL}};{{end}}`), 0644); err != nil {
+		t.Fatalf("Failed to write text template: %v", err)
+	}
+
+	emailNotifier := NewEmailNotifier(&configure.EmailConfig{HTMLTemplate: htmlPath, TextTemplate: textPath})
+	message, err := emailNotifier.buildMessage(testEmailNotification())
+	if err != nil {
+		t.Fatalf("buildMessage failed: %v", err)
+	}
+
+	parts := readEmailParts(t, message)
+	if parts["text/html"] != "<p>1 down API Web</p>" {
+		t.Errorf("Unexpected HTML part: %q", parts["text/html"])
+	}
+	if parts["text/plain"] != "DOWN https://api.example.com/health;UP https://www.example.com;EXPIRES SOON https://www.example.com;" {
+		t.Errorf("Unexpected text part: %q", parts["text/plain"])
+	}
+}
+
+func TestEmailNotifier_BuildMessage_InvalidTemplate(t *testing.T) {
+	emailNotifier := NewEmailNotifier(&configure.EmailConfig{HTMLTemplate: filepath.Join(t.TempDir(), "missing.html")})
+
+	if _, err := emailNotifier.buildMessage(testEmailNotification()); err == nil {
+		t.Error("Expected error for a missing HTML template")
+	}
+}
@@ -135,6 +135,7 @@ func TestEmailNotifier_Send_MissingCredentials(t *testing.T) {
 		From:     "test@example.com",
 		To:       []string{"recipient@example.com"},
 		UseTLS:   true,
+		Auth:     "plain",
 	}
 
 	notifier := NewEmailNotifier(config)
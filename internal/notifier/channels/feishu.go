@@ -0,0 +1,70 @@
+package channels
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/common/params"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+)
+
+// feishuMaxTextLength keeps Feishu/Lark text messages well below the 20KB request body limit
+const feishuMaxTextLength = 4000
+
+// FeishuNotifier implements Feishu/Lark custom bot notifications
+type FeishuNotifier struct {
+	config *configure.FeishuConfig
+}
+
+// NewFeishuNotifier creates a new Feishu/Lark notifier
+func NewFeishuNotifier(config *configure.FeishuConfig) *FeishuNotifier {
+	return &FeishuNotifier{config: config}
+}
+
+// Send sends the title and message, see SendContext
+func (f *FeishuNotifier) Send(title, message string) error {
+	return f.SendContext(context.Background(), &notifier.Notification{Title: title, Message: message})
+}
+
+// SendContext sends a text message through the Feishu/Lark bot webhook
+func (f *FeishuNotifier) SendContext(ctx context.Context, notification *notifier.Notification) error {
+	title, message := notification.Title, notification.Message
+
+	resolver := params.NewParameterResolver()
+
+	webhookURL := resolveWithEnvFallback(resolver, f.config.WebhookURL, "FEISHU_WEBHOOK_URL")
+	if webhookURL == "" {
+		return fmt.Errorf("feishu webhook URL not configured")
+	}
+
+	payload := map[string]interface{}{
+		"msg_type": "text",
+		"content": map[string]string{
+			"text": truncateText(title+"\n\n"+message, feishuMaxTextLength),
+		},
+	}
+
+	// Sign the request if the bot has the "signature verification" security setting enabled
+	secret := resolveWithEnvFallback(resolver, f.config.Secret, "FEISHU_SECRET")
+	if secret != "" {
+		timestamp, sign := f.sign(secret, time.Now())
+		payload["timestamp"] = timestamp
+		payload["sign"] = sign
+	}
+
+	body, err := sendHTTPRequestWithResponse(ctx, webhookURL, "POST", payload, nil, f.config.Retries, f.config.Timeout, false)
+	if err != nil {
+		return fmt.Errorf("feishu request failed: %w", err)
+	}
+
+	return checkBotResponse(body)
+}
+
+// sign computes the timestamp and signature required by Feishu/Lark,
+// which uses "timestamp\nsecret" as the HMAC key over an empty message
+func (f *FeishuNotifier) sign(secret string, now time.Time) (string, string) {
+	timestamp := fmt.Sprintf("%d", now.Unix())
+	return timestamp, signHMACSHA256(timestamp+"\n"+secret, "")
+}
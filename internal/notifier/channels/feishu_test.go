@@ -0,0 +1,62 @@
+package channels
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+)
+
+// TestFeishuNotifier_Send tests that signed text messages are sent to the bot webhook
+func TestFeishuNotifier_Send(t *testing.T) {
+	var receivedPayload map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &receivedPayload); err != nil {
+			t.Errorf("Failed to parse JSON: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"code": 0, "msg": "success"}`))
+	}))
+	defer server.Close()
+
+	config := &configure.FeishuConfig{
+		WebhookURL: server.URL,
+		Secret:     "feishu-secret",
+	}
+
+	if err := NewFeishuNotifier(config).Send("Service Down", "Database error"); err != nil {
+		t.Fatalf("Failed to send feishu message: %v", err)
+	}
+
+	if receivedPayload["msg_type"] != "text" {
+		t.Errorf("Expected msg_type 'text', got '%v'", receivedPayload["msg_type"])
+	}
+
+	// Verify the signature server-side
+	timestamp, _ := receivedPayload["timestamp"].(string)
+	expectedSign := signHMACSHA256(timestamp+"\nfeishu-secret", "")
+	if receivedPayload["sign"] != expectedSign {
+		t.Errorf("Expected sign '%s', got '%v'", expectedSign, receivedPayload["sign"])
+	}
+
+	content, _ := receivedPayload["content"].(map[string]interface{})
+	if content["text"] != "Service Down\n\nDatabase error" {
+		t.Errorf("Unexpected text: %v", content["text"])
+	}
+}
+
+// TestFeishuNotifier_APIError tests that a non-zero code is reported as failure
+func TestFeishuNotifier_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"code": 19021, "msg": "sign match fail or timestamp is not within one hour from current time"}`))
+	}))
+	defer server.Close()
+
+	err := NewFeishuNotifier(&configure.FeishuConfig{WebhookURL: server.URL}).Send("Test", "Test message")
+	if err == nil {
+		t.Fatal("Expected error for non-zero code")
+	}
+}
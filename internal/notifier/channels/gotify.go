@@ -0,0 +1,81 @@
+package channels
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/wcy-dt/ponghub/internal/common/params"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+)
+
+// GotifyNotifier implements Gotify push notifications
+type GotifyNotifier struct {
+	config *configure.GotifyConfig
+}
+
+// NewGotifyNotifier creates a new Gotify notifier
+func NewGotifyNotifier(config *configure.GotifyConfig) *GotifyNotifier {
+	return &GotifyNotifier{config: config}
+}
+
+// Send pushes a message with default priority
+func (g *GotifyNotifier) Send(title, message string) error {
+	return g.SendContext(context.Background(), &notifier.Notification{Title: title, Message: message})
+}
+
+// SendEvents sends the notification without a deadline, see SendContext
+func (g *GotifyNotifier) SendEvents(notification *notifier.Notification) error {
+	return g.SendContext(context.Background(), notification)
+}
+
+// SendContext pushes a short summary of the events, prioritized by the most severe event
+func (g *GotifyNotifier) SendContext(ctx context.Context, notification *notifier.Notification) error {
+	return g.push(ctx, notification.Title, summarizeEvents(notification), getPushPriority(notification), notification.StatusPageURL)
+}
+
+// push sends a message through the Gotify message API
+func (g *GotifyNotifier) push(ctx context.Context, title, message string, priority pushPriority, clickURL string) error {
+	resolver := params.NewParameterResolver()
+
+	serverURL := resolveWithEnvFallback(resolver, g.config.ServerURL, "GOTIFY_URL")
+	token := resolveWithEnvFallback(resolver, g.config.Token, "GOTIFY_TOKEN")
+	if serverURL == "" || token == "" {
+		return fmt.Errorf("gotify server URL or application token not configured")
+	}
+
+	extras := map[string]interface{}{
+		"client::display": map[string]string{"contentType": "text/plain"},
+	}
+	if clickURL != "" {
+		extras["client::notification"] = map[string]interface{}{
+			"click": map[string]string{"url": clickURL},
+		}
+	}
+
+	payload := map[string]interface{}{
+		"title":    title,
+		"message":  message,
+		"priority": g.mapPriority(priority),
+		"extras":   extras,
+	}
+	headers := map[string]string{"X-Gotify-Key": token}
+
+	if _, err := sendHTTPRequestWithResponse(ctx, strings.TrimRight(serverURL, "/")+"/message", "POST", payload, headers, g.config.Retries, g.config.Timeout, false); err != nil {
+		return fmt.Errorf("gotify request failed: %w", err)
+	}
+	return nil
+}
+
+// mapPriority maps the push priority to the Gotify priority scale (0-10)
+func (g *GotifyNotifier) mapPriority(priority pushPriority) int {
+	switch priority {
+	case pushPriorityHigh:
+		return 8
+	case pushPriorityLow:
+		return 2
+	default:
+		return 5
+	}
+}
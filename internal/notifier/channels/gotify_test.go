@@ -0,0 +1,55 @@
+package channels
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/event_type"
+)
+
+// TestGotifyNotifier_SendEvents tests priority mapping and click-through extras
+func TestGotifyNotifier_SendEvents(t *testing.T) {
+	var receivedPath, receivedKey string
+	var receivedPayload map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedPath = r.URL.Path
+		receivedKey = r.Header.Get("X-Gotify-Key")
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &receivedPayload); err != nil {
+			t.Errorf("Failed to parse JSON: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config := &configure.GotifyConfig{ServerURL: server.URL + "/", Token: "app-token"}
+	notification := &notifier.Notification{
+		Title:         "Certificate warning",
+		StatusPageURL: "https://status.example.com",
+		Events:        []notifier.Event{{Type: event_type.CERT_WARNING, ServiceName: "Web", URL: "https://web.example.com", CertRemainingDays: 5}},
+	}
+	if err := NewGotifyNotifier(config).SendEvents(notification); err != nil {
+		t.Fatalf("Failed to send gotify message: %v", err)
+	}
+
+	if receivedPath != "/message" {
+		t.Errorf("Expected path '/message', got '%s'", receivedPath)
+	}
+	if receivedKey != "app-token" {
+		t.Errorf("Expected X-Gotify-Key 'app-token', got '%s'", receivedKey)
+	}
+	if receivedPayload["priority"] != float64(2) {
+		t.Errorf("Expected low priority 2 for cert warning, got '%v'", receivedPayload["priority"])
+	}
+	extras, _ := receivedPayload["extras"].(map[string]interface{})
+	notificationExtras, _ := extras["client::notification"].(map[string]interface{})
+	click, _ := notificationExtras["click"].(map[string]interface{})
+	if click["url"] != "https://status.example.com" {
+		t.Errorf("Expected click URL in extras, got %v", extras)
+	}
+}
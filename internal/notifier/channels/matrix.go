@@ -0,0 +1,82 @@
+package channels
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/wcy-dt/ponghub/internal/common/params"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+)
+
+// MatrixNotifier implements Matrix room notifications
+type MatrixNotifier struct {
+	config *configure.MatrixConfig
+}
+
+// NewMatrixNotifier creates a new Matrix notifier
+func NewMatrixNotifier(config *configure.MatrixConfig) *MatrixNotifier {
+	return &MatrixNotifier{config: config}
+}
+
+// Send posts a text message to the configured room
+func (m *MatrixNotifier) Send(title, message string) error {
+	return m.SendContext(context.Background(), &notifier.Notification{Title: title, Message: message})
+}
+
+// SendEvents sends the notification without a deadline, see SendContext
+func (m *MatrixNotifier) SendEvents(notification *notifier.Notification) error {
+	return m.SendContext(context.Background(), notification)
+}
+
+// SendContext posts a short summary of the events to the configured room.
+// Low priority notifications are sent as m.notice, which clients usually do not alert on.
+func (m *MatrixNotifier) SendContext(ctx context.Context, notification *notifier.Notification) error {
+	return m.sendMessage(ctx, notification.Title, summarizeEvents(notification), getPushPriority(notification), notification.StatusPageURL)
+}
+
+// sendMessage sends a room message through the client-server API
+func (m *MatrixNotifier) sendMessage(ctx context.Context, title, message string, priority pushPriority, clickURL string) error {
+	resolver := params.NewParameterResolver()
+
+	homeserverURL := resolveWithEnvFallback(resolver, m.config.HomeserverURL, "MATRIX_HOMESERVER_URL")
+	accessToken := resolveWithEnvFallback(resolver, m.config.AccessToken, "MATRIX_ACCESS_TOKEN")
+	roomID := resolveWithEnvFallback(resolver, m.config.RoomID, "MATRIX_ROOM_ID")
+	if homeserverURL == "" || accessToken == "" || roomID == "" {
+		return fmt.Errorf("matrix homeserver URL, access token or room ID not configured")
+	}
+
+	msgType := "m.text"
+	if priority == pushPriorityLow {
+		msgType = "m.notice"
+	}
+
+	plainBody := title + "\n\n" + message
+	formattedBody := "<strong>" + html.EscapeString(title) + "</strong><br>" +
+		strings.ReplaceAll(html.EscapeString(message), "\n", "<br>")
+	if clickURL != "" {
+		plainBody += "\n\n" + clickURL
+		formattedBody += `<br><br><a href="` + html.EscapeString(clickURL) + `">Open status page</a>`
+	}
+
+	payload := map[string]interface{}{
+		"msgtype":        msgType,
+		"body":           plainBody,
+		"format":         "org.matrix.custom.html",
+		"formatted_body": formattedBody,
+	}
+
+	// The transaction ID makes retries of the same message idempotent
+	requestURL := fmt.Sprintf("%s/_matrix/client/v3/rooms/%s/send/m.room.message/%s",
+		strings.TrimRight(homeserverURL, "/"), url.PathEscape(roomID), uuid.New().String())
+	headers := map[string]string{"Authorization": "Bearer " + accessToken}
+
+	if _, err := sendHTTPRequestWithResponse(ctx, requestURL, "PUT", payload, headers, m.config.Retries, m.config.Timeout, false); err != nil {
+		return fmt.Errorf("matrix request failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package channels
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/event_type"
+)
+
+// TestMatrixNotifier_SendEvents tests that messages are sent to the room with the access token
+func TestMatrixNotifier_SendEvents(t *testing.T) {
+	var receivedMethod, receivedPath, receivedAuth string
+	var receivedPayload map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedMethod = r.Method
+		receivedPath = r.URL.EscapedPath()
+		receivedAuth = r.Header.Get("Authorization")
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &receivedPayload); err != nil {
+			t.Errorf("Failed to parse JSON: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"event_id": "$abc"}`))
+	}))
+	defer server.Close()
+
+	config := &configure.MatrixConfig{HomeserverURL: server.URL, AccessToken: "syt_token", RoomID: "!room:example.org"}
+	notification := &notifier.Notification{
+		Title:         "Certificate <warning>",
+		StatusPageURL: "https://status.example.com",
+		Events:        []notifier.Event{{Type: event_type.CERT_WARNING, ServiceName: "Web", URL: "https://web.example.com", CertRemainingDays: 5}},
+	}
+	if err := NewMatrixNotifier(config).SendEvents(notification); err != nil {
+		t.Fatalf("Failed to send matrix message: %v", err)
+	}
+
+	if receivedMethod != http.MethodPut {
+		t.Errorf("Expected PUT, got %s", receivedMethod)
+	}
+	if !strings.HasPrefix(receivedPath, "/_matrix/client/v3/rooms/%21room:example.org/send/m.room.message/") {
+		t.Errorf("Unexpected path '%s'", receivedPath)
+	}
+	if receivedAuth != "Bearer syt_token" {
+		t.Errorf("Expected bearer auth, got '%s'", receivedAuth)
+	}
+	if receivedPayload["msgtype"] != "m.notice" {
+		t.Errorf("Expected m.notice for low priority, got '%v'", receivedPayload["msgtype"])
+	}
+	formattedBody, _ := receivedPayload["formatted_body"].(string)
+	if !strings.Contains(formattedBody, "&lt;warning&gt;") || !strings.Contains(formattedBody, `href="https://status.example.com"`) {
+		t.Errorf("Unexpected formatted body: %s", formattedBody)
+	}
+}
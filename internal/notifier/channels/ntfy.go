@@ -0,0 +1,92 @@
+package channels
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/wcy-dt/ponghub/internal/common/params"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+)
+
+// ntfyServerURL is the default ntfy server
+const ntfyServerURL = "https://ntfy.sh"
+
+// NtfyNotifier implements ntfy push notifications
+type NtfyNotifier struct {
+	config *configure.NtfyConfig
+}
+
+// NewNtfyNotifier creates a new ntfy notifier
+func NewNtfyNotifier(config *configure.NtfyConfig) *NtfyNotifier {
+	return &NtfyNotifier{config: config}
+}
+
+// Send publishes a message with default priority to the configured topic
+func (n *NtfyNotifier) Send(title, message string) error {
+	return n.SendContext(context.Background(), &notifier.Notification{Title: title, Message: message})
+}
+
+// SendEvents sends the notification without a deadline, see SendContext
+func (n *NtfyNotifier) SendEvents(notification *notifier.Notification) error {
+	return n.SendContext(context.Background(), notification)
+}
+
+// SendContext publishes a short summary of the events, prioritized by the most severe event
+func (n *NtfyNotifier) SendContext(ctx context.Context, notification *notifier.Notification) error {
+	return n.publish(ctx, notification.Title, summarizeEvents(notification), getPushPriority(notification),
+		getPushTags(notification), notification.StatusPageURL)
+}
+
+// publish sends a message through the ntfy JSON publishing API
+func (n *NtfyNotifier) publish(ctx context.Context, title, message string, priority pushPriority, tags []string, clickURL string) error {
+	resolver := params.NewParameterResolver()
+
+	topic := resolveWithEnvFallback(resolver, n.config.Topic, "NTFY_TOPIC")
+	if topic == "" {
+		return fmt.Errorf("ntfy topic not configured")
+	}
+
+	serverURL := ntfyServerURL
+	if n.config.ServerURL != "" {
+		serverURL = strings.TrimRight(resolver.ResolveParameters(n.config.ServerURL), "/")
+	}
+
+	payload := map[string]interface{}{
+		"topic":    topic,
+		"title":    title,
+		"message":  message,
+		"priority": n.mapPriority(priority),
+		"tags":     append(tags, n.config.Tags...),
+	}
+	if clickURL != "" {
+		payload["click"] = clickURL
+	}
+
+	headers := make(map[string]string)
+	if token := resolveWithEnvFallback(resolver, n.config.Token, "NTFY_TOKEN"); token != "" {
+		headers["Authorization"] = "Bearer " + token
+	} else if n.config.Username != "" && n.config.Password != "" {
+		credentials := resolver.ResolveParameters(n.config.Username) + ":" + resolver.ResolveParameters(n.config.Password)
+		headers["Authorization"] = "Basic " + base64.StdEncoding.EncodeToString([]byte(credentials))
+	}
+
+	if _, err := sendHTTPRequestWithResponse(ctx, serverURL, "POST", payload, headers, n.config.Retries, n.config.Timeout, false); err != nil {
+		return fmt.Errorf("ntfy request failed: %w", err)
+	}
+	return nil
+}
+
+// mapPriority maps the push priority to the ntfy priority scale (1 = min, 5 = max)
+func (n *NtfyNotifier) mapPriority(priority pushPriority) int {
+	switch priority {
+	case pushPriorityHigh:
+		return 4
+	case pushPriorityLow:
+		return 2
+	default:
+		return 3
+	}
+}
@@ -0,0 +1,91 @@
+package channels
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/event_type"
+)
+
+// TestNtfyNotifier_SendEvents tests priority mapping, tags, click-through and authentication
+func TestNtfyNotifier_SendEvents(t *testing.T) {
+	var receivedAuth string
+	var receivedPayload map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedAuth = r.Header.Get("Authorization")
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &receivedPayload); err != nil {
+			t.Errorf("Failed to parse JSON: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config := &configure.NtfyConfig{
+		ServerURL: server.URL,
+		Topic:     "ponghub-alerts",
+		Token:     "tk_test",
+		Tags:      []string{"prod"},
+	}
+
+	notification := &notifier.Notification{
+		Title:         "Alert",
+		StatusPageURL: "https://status.example.com",
+		Events: []notifier.Event{
+			{Type: event_type.CERT_WARNING, ServiceName: "Web", URL: "https://web.example.com", CertRemainingDays: 5},
+			{Type: event_type.OUTAGE, ServiceName: "API", URL: "https://api.example.com"},
+		},
+	}
+	if err := NewNtfyNotifier(config).SendEvents(notification); err != nil {
+		t.Fatalf("Failed to send ntfy message: %v", err)
+	}
+
+	if receivedAuth != "Bearer tk_test" {
+		t.Errorf("Expected bearer auth, got '%s'", receivedAuth)
+	}
+	if receivedPayload["topic"] != "ponghub-alerts" {
+		t.Errorf("Expected topic 'ponghub-alerts', got '%v'", receivedPayload["topic"])
+	}
+	if receivedPayload["priority"] != float64(4) {
+		t.Errorf("Expected priority 4 for outage, got '%v'", receivedPayload["priority"])
+	}
+	if receivedPayload["click"] != "https://status.example.com" {
+		t.Errorf("Expected click URL, got '%v'", receivedPayload["click"])
+	}
+	tags, _ := receivedPayload["tags"].([]interface{})
+	if len(tags) != 3 || tags[0] != "lock" || tags[1] != "rotating_light" || tags[2] != "prod" {
+		t.Errorf("Unexpected tags: %v", receivedPayload["tags"])
+	}
+}
+
+// TestGetPushPriority tests the mapping of event types to push priorities
+func TestGetPushPriority(t *testing.T) {
+	tests := []struct {
+		name     string
+		events   []event_type.EventType
+		expected pushPriority
+	}{
+		{"Outage", []event_type.EventType{event_type.CERT_WARNING, event_type.OUTAGE}, pushPriorityHigh},
+		{"Cert warning", []event_type.EventType{event_type.CERT_WARNING}, pushPriorityLow},
+		{"Cert expired", []event_type.EventType{event_type.CERT_WARNING, event_type.CERT_EXPIRED}, pushPriorityDefault},
+		{"Recovery", []event_type.EventType{event_type.RECOVERY}, pushPriorityDefault},
+		{"No events", nil, pushPriorityDefault},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notification := &notifier.Notification{}
+			for _, eventType := range tt.events {
+				notification.Events = append(notification.Events, notifier.Event{Type: eventType})
+			}
+			if priority := getPushPriority(notification); priority != tt.expected {
+				t.Errorf("getPushPriority() = %v, want %v", priority, tt.expected)
+			}
+		})
+	}
+}
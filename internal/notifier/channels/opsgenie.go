@@ -0,0 +1,147 @@
+package channels
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/wcy-dt/ponghub/internal/common/params"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/event_type"
+)
+
+const (
+	// opsgenieAPIURL is the default Opsgenie API base URL (use https://api.eu.opsgenie.com for the EU instance)
+	opsgenieAPIURL = "https://api.opsgenie.com"
+
+	// opsgenieMaxMessageLength is the maximum length of an Opsgenie alert message
+	opsgenieMaxMessageLength = 130
+)
+
+// OpsgenieNotifier implements Opsgenie Alert API notifications
+type OpsgenieNotifier struct {
+	config *configure.OpsgenieConfig
+}
+
+// NewOpsgenieNotifier creates a new Opsgenie notifier
+func NewOpsgenieNotifier(config *configure.OpsgenieConfig) *OpsgenieNotifier {
+	return &OpsgenieNotifier{config: config}
+}
+
+// Send does nothing, as an alert for a summary message would never be closed.
+// Alerts are only created and closed for the events of a notification, see SendContext.
+func (o *OpsgenieNotifier) Send(_, _ string) error {
+	return nil
+}
+
+// SendEvents sends the notification without a deadline, see SendContext
+func (o *OpsgenieNotifier) SendEvents(notification *notifier.Notification) error {
+	return o.SendContext(context.Background(), notification)
+}
+
+// SendContext creates an alert for every problem event and closes it on recovery or once
+// the certificate is renewed.
+// The alias is derived from the service name and endpoint URL, so repeated runs
+// update the same alert instead of opening new ones.
+func (o *OpsgenieNotifier) SendContext(ctx context.Context, notification *notifier.Notification) error {
+	var errs []error
+	for _, event := range notification.Events {
+		var err error
+		switch event.Type {
+		case event_type.RECOVERY:
+			err = o.request(ctx, "/v2/alerts/"+url.PathEscape(event.DedupKey())+"/close?identifierType=alias", map[string]interface{}{
+				"source": "ponghub",
+				"note":   fmt.Sprintf("%s recovered at %s", event.URL, event.Time),
+			})
+		case event_type.CERT_RENEWED:
+			err = o.request(ctx, "/v2/alerts/"+url.PathEscape(event.CertDedupKey())+"/close?identifierType=alias", map[string]interface{}{
+				"source": "ponghub",
+				"note":   fmt.Sprintf("certificate of %s renewed, expires in %d day(s)", event.URL, event.CertRemainingDays),
+			})
+		default:
+			err = o.request(ctx, "/v2/alerts", o.buildAlert(event))
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s %s: %w", event.Type, event.URL, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// buildAlert converts a problem event into an Opsgenie alert
+func (o *OpsgenieNotifier) buildAlert(event notifier.Event) map[string]interface{} {
+	details := map[string]string{
+		"service": event.ServiceName,
+		"url":     event.URL,
+		"method":  event.Method,
+		"time":    event.Time,
+	}
+	if event.StatusCode > 0 {
+		details["status_code"] = fmt.Sprintf("%d", event.StatusCode)
+	}
+	if event.Error != "" {
+		details["error"] = event.Error
+	}
+
+	alias := event.DedupKey()
+	message := fmt.Sprintf("[%s] %s is unavailable", event.ServiceName, event.URL)
+	priority := o.priority()
+	if event.Type.IsCert() {
+		alias = event.CertDedupKey()
+		message = fmt.Sprintf("[%s] Certificate of %s expires in %d day(s)", event.ServiceName, event.URL, event.CertRemainingDays)
+		if event.Type == event_type.CERT_EXPIRED {
+			message = fmt.Sprintf("[%s] Certificate of %s has expired", event.ServiceName, event.URL)
+		}
+		priority = "P3"
+		details["cert_remaining_days"] = fmt.Sprintf("%d", event.CertRemainingDays)
+	}
+
+	alert := map[string]interface{}{
+		"message":     truncateText(message, opsgenieMaxMessageLength),
+		"alias":       alias,
+		"description": message,
+		"priority":    priority,
+		"source":      "ponghub",
+		"entity":      event.ServiceName,
+		"tags":        append([]string{event.Type.String()}, o.config.Tags...),
+		"details":     details,
+	}
+	if o.config.Team != "" {
+		alert["responders"] = []map[string]string{{"type": "team", "name": o.config.Team}}
+	}
+	return alert
+}
+
+// request sends a request to the Opsgenie API
+func (o *OpsgenieNotifier) request(ctx context.Context, path string, payload map[string]interface{}) error {
+	resolver := params.NewParameterResolver()
+
+	apiKey := resolveWithEnvFallback(resolver, o.config.APIKey, "OPSGENIE_API_KEY")
+	if apiKey == "" {
+		return fmt.Errorf("opsgenie API key not configured")
+	}
+
+	apiURL := opsgenieAPIURL
+	if o.config.APIURL != "" {
+		apiURL = strings.TrimRight(o.config.APIURL, "/")
+	}
+
+	headers := map[string]string{"Authorization": "GenieKey " + apiKey}
+	if _, err := sendHTTPRequestWithResponse(ctx, apiURL+path, "POST", payload, headers, o.config.Retries, o.config.Timeout, false); err != nil {
+		return fmt.Errorf("opsgenie request failed: %w", err)
+	}
+	return nil
+}
+
+// priority returns the configured priority for outages
+func (o *OpsgenieNotifier) priority() string {
+	switch strings.ToUpper(o.config.Priority) {
+	case "P1", "P2", "P3", "P4", "P5":
+		return strings.ToUpper(o.config.Priority)
+	default:
+		return "P1"
+	}
+}
@@ -0,0 +1,130 @@
+package channels
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/event_type"
+)
+
+// opsgenieRequest records a request received by the Opsgenie stand-in
+type opsgenieRequest struct {
+	Path    string
+	Query   string
+	Payload map[string]interface{}
+}
+
+// newOpsgenieStandIn creates a local stand-in for the Alert API that validates the request contract
+func newOpsgenieStandIn(received *[]opsgenieRequest) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "GenieKey api-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message": "Could not authenticate"}`))
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		var payload map[string]interface{}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+
+		if r.URL.Path == "/v2/alerts" {
+			message, _ := payload["message"].(string)
+			if message == "" || len([]rune(message)) > 130 {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				return
+			}
+		} else if !strings.HasSuffix(r.URL.Path, "/close") || r.URL.Query().Get("identifierType") != "alias" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		*received = append(*received, opsgenieRequest{Path: r.URL.Path, Query: r.URL.RawQuery, Payload: payload})
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"result": "Request will be processed", "took": 0.1, "requestId": "abc"}`))
+	}))
+}
+
+// TestOpsgenieNotifier_CreateAndClose tests that outages create and recoveries close the same alert
+func TestOpsgenieNotifier_CreateAndClose(t *testing.T) {
+	var received []opsgenieRequest
+	server := newOpsgenieStandIn(&received)
+	defer server.Close()
+
+	og := NewOpsgenieNotifier(&configure.OpsgenieConfig{APIKey: "api-key", APIURL: server.URL, Tags: []string{"ponghub"}})
+
+	outage := notifier.Event{Type: event_type.OUTAGE, ServiceName: "API", URL: "https://api.example.com/" + strings.Repeat("x", 200)}
+	recovery := notifier.Event{Type: event_type.RECOVERY, ServiceName: "API", URL: outage.URL}
+	if err := og.SendEvents(&notifier.Notification{Events: []notifier.Event{outage}}); err != nil {
+		t.Fatalf("Failed to send outage: %v", err)
+	}
+	if err := og.SendEvents(&notifier.Notification{Events: []notifier.Event{recovery}}); err != nil {
+		t.Fatalf("Failed to send recovery: %v", err)
+	}
+
+	if len(received) != 2 {
+		t.Fatalf("Expected 2 requests, got %d", len(received))
+	}
+	if received[0].Payload["alias"] != outage.DedupKey() {
+		t.Errorf("Expected alias '%s', got '%v'", outage.DedupKey(), received[0].Payload["alias"])
+	}
+	if received[0].Payload["priority"] != "P1" {
+		t.Errorf("Expected priority 'P1', got '%v'", received[0].Payload["priority"])
+	}
+	if received[1].Path != "/v2/alerts/"+outage.DedupKey()+"/close" {
+		t.Errorf("Expected close request for alias, got path '%s'", received[1].Path)
+	}
+}
+
+// TestOpsgenieNotifier_CertRenewal tests that certificate alerts are closed once the certificate is renewed,
+// and that summary messages do not create alerts, as they would never be closed
+func TestOpsgenieNotifier_CertRenewal(t *testing.T) {
+	var received []opsgenieRequest
+	server := newOpsgenieStandIn(&received)
+	defer server.Close()
+
+	og := NewOpsgenieNotifier(&configure.OpsgenieConfig{APIKey: "api-key", APIURL: server.URL})
+
+	warning := notifier.Event{Type: event_type.CERT_WARNING, ServiceName: "API", URL: "https://api.example.com", CertRemainingDays: 3}
+	renewal := notifier.Event{Type: event_type.CERT_RENEWED, ServiceName: "API", URL: warning.URL, CertRemainingDays: 90}
+	if err := og.SendEvents(&notifier.Notification{Events: []notifier.Event{warning}}); err != nil {
+		t.Fatalf("Failed to send cert warning: %v", err)
+	}
+	if err := og.SendEvents(&notifier.Notification{Events: []notifier.Event{renewal}}); err != nil {
+		t.Fatalf("Failed to send cert renewal: %v", err)
+	}
+	if err := og.Send("Test", "Test message"); err != nil {
+		t.Fatalf("Expected no error for a summary message, got %v", err)
+	}
+
+	if len(received) != 2 {
+		t.Fatalf("Expected 2 requests, got %d", len(received))
+	}
+	if received[0].Payload["alias"] != warning.CertDedupKey() {
+		t.Errorf("Expected alias '%s', got '%v'", warning.CertDedupKey(), received[0].Payload["alias"])
+	}
+	if received[1].Path != "/v2/alerts/"+warning.CertDedupKey()+"/close" {
+		t.Errorf("Expected close request for the certificate alias, got path '%s'", received[1].Path)
+	}
+}
+
+// TestOpsgenieNotifier_InvalidKey tests that authentication errors are reported
+func TestOpsgenieNotifier_InvalidKey(t *testing.T) {
+	var received []opsgenieRequest
+	server := newOpsgenieStandIn(&received)
+	defer server.Close()
+
+	og := NewOpsgenieNotifier(&configure.OpsgenieConfig{APIKey: "wrong", APIURL: server.URL})
+	err := og.SendEvents(&notifier.Notification{Events: []notifier.Event{{Type: event_type.OUTAGE, ServiceName: "API", URL: "x"}}})
+	if err == nil {
+		t.Fatal("Expected error for invalid API key")
+	}
+}
@@ -0,0 +1,151 @@
+package channels
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/wcy-dt/ponghub/internal/common/params"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/event_type"
+)
+
+// pagerDutyAPIURL is the default PagerDuty Events API v2 enqueue endpoint
+const pagerDutyAPIURL = "https://events.pagerduty.com/v2/enqueue"
+
+// PagerDutyNotifier implements PagerDuty Events API v2 notifications
+type PagerDutyNotifier struct {
+	config *configure.PagerDutyConfig
+}
+
+// NewPagerDutyNotifier creates a new PagerDuty notifier
+func NewPagerDutyNotifier(config *configure.PagerDutyConfig) *PagerDutyNotifier {
+	return &PagerDutyNotifier{config: config}
+}
+
+// Send does nothing, as an incident for a summary message would never be resolved.
+// Incidents are only triggered and resolved for the events of a notification, see SendContext.
+func (p *PagerDutyNotifier) Send(_, _ string) error {
+	return nil
+}
+
+// SendEvents sends the notification without a deadline, see SendContext
+func (p *PagerDutyNotifier) SendEvents(notification *notifier.Notification) error {
+	return p.SendContext(context.Background(), notification)
+}
+
+// SendContext triggers an incident for every problem event and resolves it on recovery or
+// once the certificate is renewed.
+// The dedup key is derived from the service name and endpoint URL, so repeated runs
+// update the same incident instead of opening new ones.
+func (p *PagerDutyNotifier) SendContext(ctx context.Context, notification *notifier.Notification) error {
+	var errs []error
+	for _, event := range notification.Events {
+		if err := p.enqueue(ctx, p.buildEvent(event)); err != nil {
+			errs = append(errs, fmt.Errorf("%s %s: %w", event.Type, event.URL, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// buildEvent converts a notification event into a PagerDuty event
+func (p *PagerDutyNotifier) buildEvent(event notifier.Event) map[string]interface{} {
+	switch event.Type {
+	case event_type.RECOVERY:
+		return map[string]interface{}{
+			"event_action": "resolve",
+			"dedup_key":    event.DedupKey(),
+		}
+	case event_type.CERT_RENEWED:
+		return map[string]interface{}{
+			"event_action": "resolve",
+			"dedup_key":    event.CertDedupKey(),
+		}
+	case event_type.CERT_WARNING, event_type.CERT_EXPIRED:
+		summary := fmt.Sprintf("[%s] Certificate of %s expires in %d day(s)", event.ServiceName, event.URL, event.CertRemainingDays)
+		if event.Type == event_type.CERT_EXPIRED {
+			summary = fmt.Sprintf("[%s] Certificate of %s has expired", event.ServiceName, event.URL)
+		}
+		return map[string]interface{}{
+			"event_action": "trigger",
+			"dedup_key":    event.CertDedupKey(),
+			"payload":      p.buildPayload(event, summary, "warning"),
+		}
+	default:
+		summary := fmt.Sprintf("[%s] %s is unavailable", event.ServiceName, event.URL)
+		return map[string]interface{}{
+			"event_action": "trigger",
+			"dedup_key":    event.DedupKey(),
+			"payload":      p.buildPayload(event, summary, p.severity()),
+		}
+	}
+}
+
+// buildPayload builds the payload of a trigger event
+func (p *PagerDutyNotifier) buildPayload(event notifier.Event, summary, severity string) map[string]interface{} {
+	details := map[string]interface{}{
+		"url":    event.URL,
+		"method": event.Method,
+	}
+	if event.StatusCode > 0 {
+		details["status_code"] = event.StatusCode
+	}
+	if event.Error != "" {
+		details["error"] = event.Error
+	}
+	if event.Type.IsCert() {
+		details["cert_remaining_days"] = event.CertRemainingDays
+	}
+
+	return map[string]interface{}{
+		"summary":        summary,
+		"source":         p.source(),
+		"severity":       severity,
+		"timestamp":      event.Time,
+		"component":      event.URL,
+		"group":          event.ServiceName,
+		"class":          event.Type.String(),
+		"custom_details": details,
+	}
+}
+
+// enqueue sends a single event to the Events API
+func (p *PagerDutyNotifier) enqueue(ctx context.Context, event map[string]interface{}) error {
+	resolver := params.NewParameterResolver()
+
+	routingKey := resolveWithEnvFallback(resolver, p.config.RoutingKey, "PAGERDUTY_ROUTING_KEY")
+	if routingKey == "" {
+		return fmt.Errorf("pagerduty routing key not configured")
+	}
+	event["routing_key"] = routingKey
+
+	apiURL := pagerDutyAPIURL
+	if p.config.APIURL != "" {
+		apiURL = p.config.APIURL
+	}
+
+	if _, err := sendHTTPRequestWithResponse(ctx, apiURL, "POST", event, nil, p.config.Retries, p.config.Timeout, false); err != nil {
+		return fmt.Errorf("pagerduty request failed: %w", err)
+	}
+	return nil
+}
+
+// source returns the configured event source
+func (p *PagerDutyNotifier) source() string {
+	if p.config.Source != "" {
+		return p.config.Source
+	}
+	return "ponghub"
+}
+
+// severity returns the configured severity for outages
+func (p *PagerDutyNotifier) severity() string {
+	switch strings.ToLower(p.config.Severity) {
+	case "critical", "error", "warning", "info":
+		return strings.ToLower(p.config.Severity)
+	default:
+		return "critical"
+	}
+}
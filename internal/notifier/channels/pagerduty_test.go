@@ -0,0 +1,151 @@
+package channels
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/event_type"
+)
+
+// newPagerDutyStandIn creates a local stand-in for the Events API v2 that validates the request contract
+func newPagerDutyStandIn(received *[]map[string]interface{}) *httptest.Server {
+	var mu sync.Mutex
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var event map[string]interface{}
+		if err := json.Unmarshal(body, &event); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"status": "invalid event", "message": "Event object is invalid"}`))
+			return
+		}
+
+		// Validate required fields like the real API does
+		if event["routing_key"] == "" || event["dedup_key"] == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch event["event_action"] {
+		case "trigger":
+			payload, ok := event["payload"].(map[string]interface{})
+			if !ok || payload["summary"] == nil || payload["source"] == nil || payload["severity"] == nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		case "resolve":
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		mu.Lock()
+		*received = append(*received, event)
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"status": "success", "message": "Event processed", "dedup_key": "` + event["dedup_key"].(string) + `"}`))
+	}))
+}
+
+// TestPagerDutyNotifier_TriggerAndResolve tests that outages trigger and recoveries resolve the same incident
+func TestPagerDutyNotifier_TriggerAndResolve(t *testing.T) {
+	var received []map[string]interface{}
+	server := newPagerDutyStandIn(&received)
+	defer server.Close()
+
+	pd := NewPagerDutyNotifier(&configure.PagerDutyConfig{RoutingKey: "routing-key", APIURL: server.URL})
+
+	outage := notifier.Event{Type: event_type.OUTAGE, ServiceName: "API", URL: "https://api.example.com", Error: "timeout"}
+	if err := pd.SendEvents(&notifier.Notification{Events: []notifier.Event{outage}}); err != nil {
+		t.Fatalf("Failed to send outage: %v", err)
+	}
+	// A repeated run must reuse the dedup key
+	if err := pd.SendEvents(&notifier.Notification{Events: []notifier.Event{outage}}); err != nil {
+		t.Fatalf("Failed to send repeated outage: %v", err)
+	}
+	recovery := notifier.Event{Type: event_type.RECOVERY, ServiceName: "API", URL: "https://api.example.com"}
+	if err := pd.SendEvents(&notifier.Notification{Events: []notifier.Event{recovery}}); err != nil {
+		t.Fatalf("Failed to send recovery: %v", err)
+	}
+
+	if len(received) != 3 {
+		t.Fatalf("Expected 3 events, got %d", len(received))
+	}
+	if received[0]["event_action"] != "trigger" || received[2]["event_action"] != "resolve" {
+		t.Errorf("Expected trigger then resolve, got %v and %v", received[0]["event_action"], received[2]["event_action"])
+	}
+	if received[0]["dedup_key"] != received[1]["dedup_key"] || received[0]["dedup_key"] != received[2]["dedup_key"] {
+		t.Errorf("Expected the same dedup key for all events, got %v, %v, %v",
+			received[0]["dedup_key"], received[1]["dedup_key"], received[2]["dedup_key"])
+	}
+
+	payload := received[0]["payload"].(map[string]interface{})
+	if payload["severity"] != "critical" {
+		t.Errorf("Expected severity 'critical', got '%v'", payload["severity"])
+	}
+	if payload["group"] != "API" {
+		t.Errorf("Expected group 'API', got '%v'", payload["group"])
+	}
+}
+
+// TestPagerDutyNotifier_CertWarning tests that certificate events use a separate incident with warning severity,
+// which is resolved once the certificate is renewed
+func TestPagerDutyNotifier_CertWarning(t *testing.T) {
+	var received []map[string]interface{}
+	server := newPagerDutyStandIn(&received)
+	defer server.Close()
+
+	pd := NewPagerDutyNotifier(&configure.PagerDutyConfig{RoutingKey: "routing-key", APIURL: server.URL})
+	event := notifier.Event{Type: event_type.CERT_WARNING, ServiceName: "API", URL: "https://api.example.com", CertRemainingDays: 3}
+	if err := pd.SendEvents(&notifier.Notification{Events: []notifier.Event{event}}); err != nil {
+		t.Fatalf("Failed to send cert warning: %v", err)
+	}
+	renewal := notifier.Event{Type: event_type.CERT_RENEWED, ServiceName: "API", URL: "https://api.example.com", CertRemainingDays: 90}
+	if err := pd.SendEvents(&notifier.Notification{Events: []notifier.Event{renewal}}); err != nil {
+		t.Fatalf("Failed to send cert renewal: %v", err)
+	}
+
+	if len(received) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(received))
+	}
+	if received[1]["event_action"] != "resolve" || received[1]["dedup_key"] != event.CertDedupKey() {
+		t.Errorf("Expected the renewal to resolve '%s', got %v of '%v'", event.CertDedupKey(), received[1]["event_action"], received[1]["dedup_key"])
+	}
+	if received[0]["dedup_key"] != event.CertDedupKey() {
+		t.Errorf("Expected dedup key '%s', got '%v'", event.CertDedupKey(), received[0]["dedup_key"])
+	}
+	if received[0]["payload"].(map[string]interface{})["severity"] != "warning" {
+		t.Errorf("Expected severity 'warning'")
+	}
+}
+
+// TestPagerDutyNotifier_Send tests that summary messages do not trigger incidents, as they would never be resolved
+func TestPagerDutyNotifier_Send(t *testing.T) {
+	var received []map[string]interface{}
+	server := newPagerDutyStandIn(&received)
+	defer server.Close()
+
+	pd := NewPagerDutyNotifier(&configure.PagerDutyConfig{RoutingKey: "routing-key", APIURL: server.URL})
+	if err := pd.Send("Test", "Test message"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(received) != 0 {
+		t.Errorf("Expected no events, got %d", len(received))
+	}
+}
+
+// TestPagerDutyNotifier_MissingRoutingKey tests that a missing routing key is rejected
+func TestPagerDutyNotifier_MissingRoutingKey(t *testing.T) {
+	t.Setenv("PAGERDUTY_ROUTING_KEY", "")
+
+	pd := NewPagerDutyNotifier(&configure.PagerDutyConfig{})
+	err := pd.SendEvents(&notifier.Notification{Events: []notifier.Event{{Type: event_type.OUTAGE, ServiceName: "API", URL: "x"}}})
+	if err == nil {
+		t.Fatal("Expected error for missing routing key")
+	}
+}
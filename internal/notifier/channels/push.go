@@ -0,0 +1,91 @@
+package channels
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/event_type"
+)
+
+// pushPriority is the platform-independent priority of a push notification
+type pushPriority int
+
+const (
+	// pushPriorityLow is used for early warnings such as certificates that expire soon
+	pushPriorityLow pushPriority = iota
+
+	// pushPriorityDefault is used for recoveries and expired certificates
+	pushPriorityDefault
+
+	// pushPriorityHigh is used for outages
+	pushPriorityHigh
+)
+
+// getPushPriority returns the priority of the most severe event in the notification
+func getPushPriority(notification *notifier.Notification) pushPriority {
+	if len(notification.Events) == 0 {
+		return pushPriorityDefault
+	}
+
+	priority := pushPriorityLow
+	for _, event := range notification.Events {
+		switch event.Type {
+		case event_type.OUTAGE:
+			return pushPriorityHigh
+		case event_type.CERT_EXPIRED, event_type.RECOVERY, event_type.CERT_RENEWED:
+			priority = pushPriorityDefault
+		}
+	}
+	return priority
+}
+
+// getPushTags returns the emoji tags describing the event types of the notification
+func getPushTags(notification *notifier.Notification) []string {
+	seen := make(map[string]bool)
+	var tags []string
+	for _, event := range notification.Events {
+		tag := "warning"
+		switch event.Type {
+		case event_type.OUTAGE:
+			tag = "rotating_light"
+		case event_type.RECOVERY, event_type.CERT_RENEWED:
+			tag = "white_check_mark"
+		case event_type.CERT_WARNING, event_type.CERT_EXPIRED:
+			tag = "lock"
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// summarizeEvents builds a short, phone-friendly message with one line per event
+func summarizeEvents(notification *notifier.Notification) string {
+	if len(notification.Events) == 0 {
+		return notification.Message
+	}
+
+	var lines []string
+	for _, event := range notification.Events {
+		switch event.Type {
+		case event_type.OUTAGE:
+			line := fmt.Sprintf("🔴 [%s] %s is unavailable", event.ServiceName, event.URL)
+			if event.Error != "" {
+				line += ": " + event.Error
+			}
+			lines = append(lines, line)
+		case event_type.RECOVERY:
+			lines = append(lines, fmt.Sprintf("✅ [%s] %s recovered", event.ServiceName, event.URL))
+		case event_type.CERT_EXPIRED:
+			lines = append(lines, fmt.Sprintf("❌ [%s] certificate of %s has expired", event.ServiceName, event.URL))
+		case event_type.CERT_WARNING:
+			lines = append(lines, fmt.Sprintf("⚠️ [%s] certificate of %s expires in %d day(s)", event.ServiceName, event.URL, event.CertRemainingDays))
+		case event_type.CERT_RENEWED:
+			lines = append(lines, fmt.Sprintf("✅ [%s] certificate of %s renewed, expires in %d day(s)", event.ServiceName, event.URL, event.CertRemainingDays))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
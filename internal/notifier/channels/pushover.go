@@ -0,0 +1,108 @@
+package channels
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/wcy-dt/ponghub/internal/common/params"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+)
+
+const (
+	// pushoverAPIURL is the default Pushover message API endpoint
+	pushoverAPIURL = "https://api.pushover.net/1/messages.json"
+
+	// pushoverMaxMessageLength is the maximum length of a Pushover message
+	pushoverMaxMessageLength = 1024
+)
+
+// PushoverNotifier implements Pushover push notifications
+type PushoverNotifier struct {
+	config *configure.PushoverConfig
+}
+
+// NewPushoverNotifier creates a new Pushover notifier
+func NewPushoverNotifier(config *configure.PushoverConfig) *PushoverNotifier {
+	return &PushoverNotifier{config: config}
+}
+
+// Send pushes a message with default priority
+func (p *PushoverNotifier) Send(title, message string) error {
+	return p.SendContext(context.Background(), &notifier.Notification{Title: title, Message: message})
+}
+
+// SendEvents sends the notification without a deadline, see SendContext
+func (p *PushoverNotifier) SendEvents(notification *notifier.Notification) error {
+	return p.SendContext(context.Background(), notification)
+}
+
+// SendContext pushes a short summary of the events, prioritized by the most severe event
+func (p *PushoverNotifier) SendContext(ctx context.Context, notification *notifier.Notification) error {
+	return p.push(ctx, notification.Title, summarizeEvents(notification), getPushPriority(notification), notification.StatusPageURL)
+}
+
+// push sends a message through the Pushover message API
+func (p *PushoverNotifier) push(ctx context.Context, title, message string, priority pushPriority, clickURL string) error {
+	resolver := params.NewParameterResolver()
+
+	token := resolveWithEnvFallback(resolver, p.config.Token, "PUSHOVER_TOKEN")
+	userKey := resolveWithEnvFallback(resolver, p.config.UserKey, "PUSHOVER_USER_KEY")
+	if token == "" || userKey == "" {
+		return fmt.Errorf("pushover application token or user key not configured")
+	}
+
+	payload := map[string]interface{}{
+		"token":    token,
+		"user":     userKey,
+		"title":    truncateText(title, 250),
+		"message":  truncateText(message, pushoverMaxMessageLength),
+		"priority": p.mapPriority(priority),
+	}
+	if clickURL != "" {
+		payload["url"] = clickURL
+		payload["url_title"] = "Open status page"
+	}
+	if p.config.Device != "" {
+		payload["device"] = p.config.Device
+	}
+	if p.config.Sound != "" {
+		payload["sound"] = p.config.Sound
+	}
+
+	apiURL := pushoverAPIURL
+	if p.config.APIURL != "" {
+		apiURL = p.config.APIURL
+	}
+
+	body, err := sendHTTPRequestWithResponse(ctx, apiURL, "POST", payload, nil, p.config.Retries, p.config.Timeout, false)
+	if err != nil {
+		return fmt.Errorf("pushover request failed: %w", err)
+	}
+
+	var resp struct {
+		Status int      `json:"status"`
+		Errors []string `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return fmt.Errorf("failed to parse pushover response: %w", err)
+	}
+	if resp.Status != 1 {
+		return fmt.Errorf("pushover API returned errors: %v", resp.Errors)
+	}
+	return nil
+}
+
+// mapPriority maps the push priority to the Pushover priority scale (-2 to 2),
+// emergency priority is never used since it requires acknowledgement
+func (p *PushoverNotifier) mapPriority(priority pushPriority) int {
+	switch priority {
+	case pushPriorityHigh:
+		return 1
+	case pushPriorityLow:
+		return -1
+	default:
+		return 0
+	}
+}
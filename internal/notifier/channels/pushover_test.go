@@ -0,0 +1,59 @@
+package channels
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/event_type"
+)
+
+// TestPushoverNotifier_SendEvents tests priority mapping and the status page link
+func TestPushoverNotifier_SendEvents(t *testing.T) {
+	var receivedPayload map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &receivedPayload); err != nil {
+			t.Errorf("Failed to parse JSON: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"status": 1, "request": "abc"}`))
+	}))
+	defer server.Close()
+
+	config := &configure.PushoverConfig{Token: "app", UserKey: "user", APIURL: server.URL, Sound: "siren"}
+	notification := &notifier.Notification{
+		Title:         "Alert",
+		StatusPageURL: "https://status.example.com",
+		Events:        []notifier.Event{{Type: event_type.OUTAGE, ServiceName: "API", URL: "https://api.example.com"}},
+	}
+	if err := NewPushoverNotifier(config).SendEvents(notification); err != nil {
+		t.Fatalf("Failed to send pushover message: %v", err)
+	}
+
+	if receivedPayload["priority"] != float64(1) {
+		t.Errorf("Expected high priority 1 for outage, got '%v'", receivedPayload["priority"])
+	}
+	if receivedPayload["url"] != "https://status.example.com" {
+		t.Errorf("Expected status page URL, got '%v'", receivedPayload["url"])
+	}
+	if receivedPayload["sound"] != "siren" {
+		t.Errorf("Expected sound 'siren', got '%v'", receivedPayload["sound"])
+	}
+}
+
+// TestPushoverNotifier_APIError tests that application-level errors are reported
+func TestPushoverNotifier_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status": 0, "errors": ["user identifier is invalid"]}`))
+	}))
+	defer server.Close()
+
+	config := &configure.PushoverConfig{Token: "app", UserKey: "invalid", APIURL: server.URL}
+	if err := NewPushoverNotifier(config).Send("Test", "Test message"); err == nil {
+		t.Fatal("Expected error for invalid user key")
+	}
+}
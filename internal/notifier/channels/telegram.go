@@ -0,0 +1,89 @@
+package channels
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/wcy-dt/ponghub/internal/common/params"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+)
+
+const (
+	// telegramAPIURL is the default Telegram Bot API base URL
+	telegramAPIURL = "https://api.telegram.org"
+
+	// telegramMaxTextLength is the maximum length of a Telegram message text
+	telegramMaxTextLength = 4096
+)
+
+// TelegramNotifier implements Telegram bot notifications
+type TelegramNotifier struct {
+	config *configure.TelegramConfig
+}
+
+// NewTelegramNotifier creates a new Telegram notifier
+func NewTelegramNotifier(config *configure.TelegramConfig) *TelegramNotifier {
+	return &TelegramNotifier{config: config}
+}
+
+// Send sends the title and message, see SendContext
+func (t *TelegramNotifier) Send(title, message string) error {
+	return t.SendContext(context.Background(), &notifier.Notification{Title: title, Message: message})
+}
+
+// SendContext sends a message to the configured Telegram chat via the Bot API
+func (t *TelegramNotifier) SendContext(ctx context.Context, notification *notifier.Notification) error {
+	title, message := notification.Title, notification.Message
+
+	resolver := params.NewParameterResolver()
+
+	botToken := resolveWithEnvFallback(resolver, t.config.BotToken, "TELEGRAM_BOT_TOKEN")
+	chatID := resolveWithEnvFallback(resolver, t.config.ChatID, "TELEGRAM_CHAT_ID")
+	if botToken == "" || chatID == "" {
+		return fmt.Errorf("telegram bot token or chat ID not configured")
+	}
+
+	apiURL := telegramAPIURL
+	if t.config.APIURL != "" {
+		apiURL = strings.TrimRight(t.config.APIURL, "/")
+	}
+	url := fmt.Sprintf("%s/bot%s/sendMessage", apiURL, botToken)
+
+	payload := map[string]interface{}{
+		"chat_id":                  chatID,
+		"text":                     truncateText(title+"\n\n"+message, telegramMaxTextLength),
+		"disable_web_page_preview": true,
+	}
+	if t.config.ParseMode != "" {
+		payload["parse_mode"] = t.config.ParseMode
+	}
+	if t.config.DisableNotification {
+		payload["disable_notification"] = true
+	}
+
+	body, err := sendHTTPRequestWithResponse(ctx, url, "POST", payload, nil, t.config.Retries, t.config.Timeout, false)
+	if err != nil {
+		// never leak the bot token through the request URL in error messages
+		return fmt.Errorf("telegram request failed: %s", strings.ReplaceAll(err.Error(), botToken, "***"))
+	}
+
+	return checkTelegramResponse(body)
+}
+
+// checkTelegramResponse checks the Bot API response for an application-level error
+func checkTelegramResponse(body []byte) error {
+	var resp struct {
+		OK          bool   `json:"ok"`
+		Description string `json:"description"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return fmt.Errorf("failed to parse telegram response: %w", err)
+	}
+	if !resp.OK {
+		return fmt.Errorf("telegram API returned error: %s", resp.Description)
+	}
+	return nil
+}
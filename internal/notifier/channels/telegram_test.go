@@ -0,0 +1,85 @@
+package channels
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+)
+
+// TestTelegramNotifier_Send tests that messages are posted to the sendMessage endpoint
+func TestTelegramNotifier_Send(t *testing.T) {
+	var receivedPath string
+	var receivedPayload map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedPath = r.URL.Path
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &receivedPayload); err != nil {
+			t.Errorf("Failed to parse JSON: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"ok": true, "result": {}}`))
+	}))
+	defer server.Close()
+
+	config := &configure.TelegramConfig{
+		BotToken:            "123:abc",
+		ChatID:              "-1001",
+		APIURL:              server.URL,
+		DisableNotification: true,
+	}
+
+	if err := NewTelegramNotifier(config).Send("Service Down", "Database error"); err != nil {
+		t.Fatalf("Failed to send telegram message: %v", err)
+	}
+
+	if receivedPath != "/bot123:abc/sendMessage" {
+		t.Errorf("Expected path '/bot123:abc/sendMessage', got '%s'", receivedPath)
+	}
+	if receivedPayload["chat_id"] != "-1001" {
+		t.Errorf("Expected chat_id '-1001', got '%v'", receivedPayload["chat_id"])
+	}
+	if receivedPayload["text"] != "Service Down\n\nDatabase error" {
+		t.Errorf("Unexpected text: %v", receivedPayload["text"])
+	}
+	if receivedPayload["disable_notification"] != true {
+		t.Errorf("Expected disable_notification to be true")
+	}
+}
+
+// TestTelegramNotifier_APIError tests that API errors are reported without leaking the bot token
+func TestTelegramNotifier_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"ok": false, "description": "Bad Request: chat not found"}`))
+	}))
+	defer server.Close()
+
+	config := &configure.TelegramConfig{
+		BotToken: "secret-token",
+		ChatID:   "42",
+		APIURL:   server.URL,
+	}
+
+	err := NewTelegramNotifier(config).Send("Test", "Test message")
+	if err == nil {
+		t.Fatal("Expected error for failed API call")
+	}
+	if strings.Contains(err.Error(), "secret-token") {
+		t.Errorf("Error message leaks bot token: %v", err)
+	}
+}
+
+// TestTelegramNotifier_MissingConfig tests that missing credentials are rejected
+func TestTelegramNotifier_MissingConfig(t *testing.T) {
+	t.Setenv("TELEGRAM_BOT_TOKEN", "")
+	t.Setenv("TELEGRAM_CHAT_ID", "")
+
+	err := NewTelegramNotifier(&configure.TelegramConfig{}).Send("Test", "Test message")
+	if err == nil {
+		t.Fatal("Expected error for missing bot token")
+	}
+}
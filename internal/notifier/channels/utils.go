@@ -2,12 +2,21 @@ package channels
 
 import (
 	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
 	"crypto/tls"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"sync/atomic"
 	"time"
+
+	"github.com/wcy-dt/ponghub/internal/common"
+	"github.com/wcy-dt/ponghub/internal/common/params"
 )
 
 // HTTPError represents an HTTP error with additional context
@@ -33,6 +42,40 @@ func (e *HTTPError) IsRetryable() bool {
 	return false
 }
 
+// attemptCounterKey is the context key of the delivery attempt counter
+type attemptCounterKey struct{}
+
+// WithAttemptCounter returns a context that counts the delivery attempts made with it,
+// e.g. every HTTP request including retries, and the counter itself
+func WithAttemptCounter(ctx context.Context) (context.Context, *atomic.Int32) {
+	counter := &atomic.Int32{}
+	return context.WithValue(ctx, attemptCounterKey{}, counter), counter
+}
+
+// countAttempt increments the attempt counter of the context, if any
+func countAttempt(ctx context.Context) {
+	if counter, ok := ctx.Value(attemptCounterKey{}).(*atomic.Int32); ok {
+		counter.Add(1)
+	}
+}
+
+// waitForRetry waits before the given retry attempt, it returns an error if the context is done first
+func waitForRetry(ctx context.Context, attempt int) error {
+	waitTime := time.Duration(attempt) * time.Second
+	if waitTime > 10*time.Second {
+		waitTime = 10 * time.Second
+	}
+
+	timer := time.NewTimer(waitTime)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
 // HTTPClient creates an HTTP client with optional TLS configuration
 func createHTTPClient(timeout int, skipTLSVerify bool) *http.Client {
 	if timeout <= 0 {
@@ -52,14 +95,20 @@ func createHTTPClient(timeout int, skipTLSVerify bool) *http.Client {
 }
 
 // SendHTTPRequest sends an HTTP request with retry logic
-func sendHTTPRequest(url string, method string, payload interface{}, headers map[string]string, maxRetries, timeout int, skipTLSVerify bool) error {
+func sendHTTPRequest(ctx context.Context, url string, method string, payload interface{}, headers map[string]string, maxRetries, timeout int, skipTLSVerify bool) error {
+	_, err := sendHTTPRequestWithResponse(ctx, url, method, payload, headers, maxRetries, timeout, skipTLSVerify)
+	return err
+}
+
+// sendHTTPRequestWithResponse sends an HTTP request with retry logic and returns the body of the successful response
+func sendHTTPRequestWithResponse(ctx context.Context, url string, method string, payload interface{}, headers map[string]string, maxRetries, timeout int, skipTLSVerify bool) ([]byte, error) {
 	client := createHTTPClient(timeout, skipTLSVerify)
 
 	var bodyReader io.Reader
 	if payload != nil {
 		jsonData, err := json.Marshal(payload)
 		if err != nil {
-			return fmt.Errorf("failed to marshal payload: %w", err)
+			return nil, fmt.Errorf("failed to marshal payload: %w", err)
 		}
 		bodyReader = bytes.NewBuffer(jsonData)
 	}
@@ -67,12 +116,10 @@ func sendHTTPRequest(url string, method string, payload interface{}, headers map
 	var lastErr error
 	for attempt := 0; attempt <= maxRetries; attempt++ {
 		if attempt > 0 {
-			// Wait before retry (exponential backoff)
-			waitTime := time.Duration(attempt) * time.Second
-			if waitTime > 10*time.Second {
-				waitTime = 10 * time.Second
+			// Wait before retry, unless the delivery deadline is reached
+			if err := waitForRetry(ctx, attempt); err != nil {
+				return nil, fmt.Errorf("request failed after %d attempt(s), last error: %w", attempt, lastErr)
 			}
-			time.Sleep(waitTime)
 
 			// Reset body reader for retry
 			if payload != nil {
@@ -85,11 +132,12 @@ func sendHTTPRequest(url string, method string, payload interface{}, headers map
 			}
 		}
 
-		req, err := http.NewRequest(method, url, bodyReader)
+		req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
 		if err != nil {
-			lastErr = fmt.Errorf("failed to create request: %w", err)
+			lastErr = fmt.Errorf("failed to create request: %w", common.RedactURLError(err))
 			continue
 		}
+		countAttempt(ctx)
 
 		// Set default content type if payload exists
 		if payload != nil && req.Header.Get("Content-Type") == "" {
@@ -103,7 +151,7 @@ func sendHTTPRequest(url string, method string, payload interface{}, headers map
 
 		resp, err := client.Do(req)
 		if err != nil {
-			lastErr = fmt.Errorf("request failed: %w", err)
+			lastErr = fmt.Errorf("request failed: %w", common.RedactURLError(err))
 			continue
 		}
 
@@ -115,7 +163,7 @@ func sendHTTPRequest(url string, method string, payload interface{}, headers map
 
 		// Check if request was successful
 		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-			return nil
+			return body, nil
 		}
 
 		// Handle specific status codes
@@ -125,33 +173,38 @@ func sendHTTPRequest(url string, method string, payload interface{}, headers map
 		case 500, 502, 503, 504: // Server errors - retry
 			lastErr = fmt.Errorf("server error (%d), response: %s", resp.StatusCode, string(body))
 		default: // Client errors - don't retry
-			return fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
 		}
 	}
 
-	return fmt.Errorf("request failed after %d retries, last error: %w", maxRetries+1, lastErr)
+	return nil, fmt.Errorf("request failed after %d retries, last error: %w", maxRetries+1, lastErr)
 }
 
 // SendHTTPRequestWithCustomBody sends an HTTP request with custom body content
-func sendHTTPRequestWithCustomBody(url string, method string, body io.Reader, contentType string, headers map[string]string, maxRetries, timeout int, skipTLSVerify bool) error {
+func sendHTTPRequestWithCustomBody(ctx context.Context, url string, method string, body []byte, contentType string, headers map[string]string, maxRetries, timeout int, skipTLSVerify bool) error {
 	client := createHTTPClient(timeout, skipTLSVerify)
 
 	var lastErr error
 	for attempt := 0; attempt <= maxRetries; attempt++ {
 		if attempt > 0 {
-			// Wait before retry
-			waitTime := time.Duration(attempt) * time.Second
-			if waitTime > 10*time.Second {
-				waitTime = 10 * time.Second
+			// Wait before retry, unless the delivery deadline is reached
+			if err := waitForRetry(ctx, attempt); err != nil {
+				return fmt.Errorf("request failed after %d attempt(s), last error: %w", attempt, lastErr)
 			}
-			time.Sleep(waitTime)
 		}
 
-		req, err := http.NewRequest(method, url, body)
+		// Create a new reader for every attempt, so that retries send the full body again
+		var bodyReader io.Reader
+		if body != nil {
+			bodyReader = bytes.NewReader(body)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
 		if err != nil {
-			lastErr = fmt.Errorf("failed to create request: %w", err)
+			lastErr = fmt.Errorf("failed to create request: %w", common.RedactURLError(err))
 			continue
 		}
+		countAttempt(ctx)
 
 		// Set content type
 		if contentType != "" {
@@ -165,7 +218,7 @@ func sendHTTPRequestWithCustomBody(url string, method string, body io.Reader, co
 
 		resp, err := client.Do(req)
 		if err != nil {
-			lastErr = fmt.Errorf("request failed: %w", err)
+			lastErr = fmt.Errorf("request failed: %w", common.RedactURLError(err))
 			continue
 		}
 
@@ -193,3 +246,58 @@ func sendHTTPRequestWithCustomBody(url string, method string, body io.Reader, co
 
 	return fmt.Errorf("request failed after %d retries, last error: %w", maxRetries+1, lastErr)
 }
+
+// botResponse represents the common response envelope returned by chat bot APIs
+// (DingTalk and WeCom use errcode/errmsg, Feishu/Lark uses code/msg)
+type botResponse struct {
+	ErrCode *int   `json:"errcode"`
+	ErrMsg  string `json:"errmsg"`
+	Code    *int   `json:"code"`
+	Msg     string `json:"msg"`
+}
+
+// checkBotResponse checks the response body of a chat bot API for an application-level error,
+// since these APIs report failures with HTTP 200 and a non-zero error code
+func checkBotResponse(body []byte) error {
+	if len(body) == 0 {
+		return nil
+	}
+
+	var resp botResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return fmt.Errorf("failed to parse bot response: %w", err)
+	}
+
+	if resp.ErrCode != nil && *resp.ErrCode != 0 {
+		return fmt.Errorf("bot API returned error %d: %s", *resp.ErrCode, resp.ErrMsg)
+	}
+	if resp.Code != nil && *resp.Code != 0 {
+		return fmt.Errorf("bot API returned error %d: %s", *resp.Code, resp.Msg)
+	}
+	return nil
+}
+
+// truncateText shortens text to at most maxRunes characters so it fits into message size limits
+func truncateText(text string, maxRunes int) string {
+	runes := []rune(text)
+	if len(runes) <= maxRunes {
+		return text
+	}
+	const suffix = "\n...(truncated)"
+	return string(runes[:maxRunes-len([]rune(suffix))]) + suffix
+}
+
+// resolveWithEnvFallback resolves Special Parameters in value, falling back to the given environment variable when empty
+func resolveWithEnvFallback(resolver *params.ParameterResolver, value, envName string) string {
+	if value == "" {
+		value = os.Getenv(envName)
+	}
+	return resolver.ResolveParameters(value)
+}
+
+// signHMACSHA256 computes a base64-encoded HMAC-SHA256 signature of message with key
+func signHMACSHA256(key, message string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(message))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
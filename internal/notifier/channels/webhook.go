@@ -2,18 +2,34 @@ package channels
 
 import (
 	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"io"
 	"os"
 	"regexp"
+	"strconv"
 	"strings"
 	"text/template"
 	"time"
 
 	"github.com/wcy-dt/ponghub/internal/common/params"
 	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+)
+
+const (
+	// webhookSignatureHeader is the default header carrying the HMAC-SHA256 signature
+	webhookSignatureHeader = "X-PongHub-Signature-256"
+
+	// webhookTimestampHeader is the default header carrying the signed Unix timestamp
+	webhookTimestampHeader = "X-PongHub-Timestamp"
+
+	// webhookIdempotencyHeader is the default header carrying the idempotency key of a notification
+	webhookIdempotencyHeader = "Idempotency-Key"
 )
 
 // WebhookNotifier implements generic webhook notifications
@@ -26,8 +42,15 @@ func NewWebhookNotifier(config *configure.WebhookConfig) *WebhookNotifier {
 	return &WebhookNotifier{config: config}
 }
 
-// Send sends a generic webhook notification with enhanced configuration support
+// Send sends the title and message, see SendContext
 func (w *WebhookNotifier) Send(title, message string) error {
+	return w.SendContext(context.Background(), &notifier.Notification{Title: title, Message: message})
+}
+
+// SendContext sends a generic webhook notification with enhanced configuration support
+func (w *WebhookNotifier) SendContext(ctx context.Context, notification *notifier.Notification) error {
+	title, message := notification.Title, notification.Message
+
 	// Create parameter resolver for processing Special Parameters
 	resolver := params.NewParameterResolver()
 
@@ -62,13 +85,20 @@ func (w *WebhookNotifier) Send(title, message string) error {
 		headers[key] = resolvedValue
 	}
 
-	// Set authentication if configured
-	if w.config.AuthType != "" {
-		w.setAuthentication(headers, resolver)
+	// Encode the payload once, so that signatures and retries use the same body
+	body, err := w.encodePayload(payload)
+	if err != nil {
+		return err
 	}
 
+	// Set authentication, signature and idempotency headers if configured
+	if err := w.setAuthentication(headers, resolver, body); err != nil {
+		return err
+	}
+	w.setIdempotencyKey(headers, notification)
+
 	// Execute request with retry logic
-	return w.sendWithRetry(url, method, payload, contentType, headers)
+	return w.sendWithRetry(ctx, url, method, body, contentType, headers)
 }
 
 // buildPayload constructs the webhook payload based on configuration
@@ -260,8 +290,8 @@ func (w *WebhookNotifier) resolveSpecialParametersOnly(templateStr string, resol
 	return result
 }
 
-// setAuthentication sets authentication headers based on configuration
-func (w *WebhookNotifier) setAuthentication(headers map[string]string, resolver *params.ParameterResolver) {
+// setAuthentication sets authentication and signature headers based on configuration
+func (w *WebhookNotifier) setAuthentication(headers map[string]string, resolver *params.ParameterResolver, body []byte) error {
 	switch strings.ToLower(w.config.AuthType) {
 	case "bearer":
 		if w.config.AuthToken != "" {
@@ -286,6 +316,56 @@ func (w *WebhookNotifier) setAuthentication(headers map[string]string, resolver
 			}
 		}
 	}
+
+	if w.config.SigningSecret != "" {
+		return w.setSignature(headers, resolver, body)
+	}
+	return nil
+}
+
+// setIdempotencyKey sets the idempotency key of the notification, which stays the same when the
+// notification is delivered again from the outbox, so that receivers can drop duplicates
+func (w *WebhookNotifier) setIdempotencyKey(headers map[string]string, notification *notifier.Notification) {
+	if w.config.SigningSecret == "" && w.config.IdempotencyHeader == "" {
+		return
+	}
+	idempotencyHeader := webhookIdempotencyHeader
+	if w.config.IdempotencyHeader != "" {
+		idempotencyHeader = w.config.IdempotencyHeader
+	}
+	headers[idempotencyHeader] = notification.IdempotencyKey()
+}
+
+// setSignature signs the timestamp and body with HMAC-SHA256 (GitHub/Stripe style), so that
+// the receiver can verify the sender and reject replayed requests with old timestamps.
+// It fails if the secret resolves to an empty value, e.g. an unset environment variable.
+func (w *WebhookNotifier) setSignature(headers map[string]string, resolver *params.ParameterResolver, body []byte) error {
+	secret := resolver.ResolveParameters(w.config.SigningSecret)
+	if secret == "" {
+		return fmt.Errorf("webhook signing secret is empty")
+	}
+
+	signatureHeader := webhookSignatureHeader
+	if w.config.SignatureHeader != "" {
+		signatureHeader = w.config.SignatureHeader
+	}
+	timestampHeader := webhookTimestampHeader
+	if w.config.TimestampHeader != "" {
+		timestampHeader = w.config.TimestampHeader
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	headers[timestampHeader] = timestamp
+	headers[signatureHeader] = computeWebhookSignature(secret, timestamp, body)
+	return nil
+}
+
+// computeWebhookSignature returns sha256=<hex HMAC-SHA256 of "<timestamp>.<body>">
+func computeWebhookSignature(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
 }
 
 // base64Encode encodes string to base64
@@ -293,8 +373,24 @@ func (w *WebhookNotifier) base64Encode(s string) string {
 	return base64.StdEncoding.EncodeToString([]byte(s))
 }
 
+// encodePayload serializes the payload, strings are sent as they are and everything else as JSON
+func (w *WebhookNotifier) encodePayload(payload interface{}) ([]byte, error) {
+	switch v := payload.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []byte(v), nil
+	default:
+		jsonData, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal payload: %w", err)
+		}
+		return jsonData, nil
+	}
+}
+
 // sendWithRetry sends the webhook with retry logic
-func (w *WebhookNotifier) sendWithRetry(url, method string, payload interface{}, contentType string, headers map[string]string) error {
+func (w *WebhookNotifier) sendWithRetry(ctx context.Context, url, method string, body []byte, contentType string, headers map[string]string) error {
 	maxRetries := 0
 	if w.config.Retries > 0 {
 		maxRetries = w.config.Retries
@@ -305,22 +401,7 @@ func (w *WebhookNotifier) sendWithRetry(url, method string, payload interface{},
 		timeout = w.config.Timeout
 	}
 
-	// Handle different payload types
-	var body io.Reader
-	if payload != nil {
-		switch v := payload.(type) {
-		case string:
-			body = strings.NewReader(v)
-		default:
-			jsonData, err := json.Marshal(payload)
-			if err != nil {
-				return fmt.Errorf("failed to marshal payload: %w", err)
-			}
-			body = bytes.NewReader(jsonData)
-		}
-	}
-
-	return sendHTTPRequestWithCustomBody(url, method, body, contentType, headers, maxRetries, timeout, w.config.SkipTLSVerify)
+	return sendHTTPRequestWithCustomBody(ctx, url, method, body, contentType, headers, maxRetries, timeout, w.config.SkipTLSVerify)
 }
 
 // WebhookError represents a webhook-specific error
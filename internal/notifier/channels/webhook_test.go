@@ -1,14 +1,23 @@
 package channels
 
 import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/event_type"
 )
 
 // TestWebhookNotifier_BasicSend tests basic webhook functionality
@@ -432,3 +441,251 @@ func TestWebhookNotifier_SpecialParametersInAuth(t *testing.T) {
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && s[:len(substr)] == substr
 }
+
+// verifyWebhookSignature verifies a signed request the way a receiver would
+func verifyWebhookSignature(r *http.Request, body []byte, secret, signatureHeader, timestampHeader string) error {
+	timestamp := r.Header.Get(timestampHeader)
+	unixTime, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp %q", timestamp)
+	}
+	if age := time.Since(time.Unix(unixTime, 0)); age > 5*time.Minute || age < -5*time.Minute {
+		return fmt.Errorf("timestamp outside of tolerance: %v", age)
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + string(body)))
+	expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(expected), []byte(r.Header.Get(signatureHeader))) {
+		return fmt.Errorf("signature mismatch: expected %s, got %s", expected, r.Header.Get(signatureHeader))
+	}
+	return nil
+}
+
+// TestWebhookNotifier_Signing tests HMAC-SHA256 signing verified by the receiver
+func TestWebhookNotifier_Signing(t *testing.T) {
+	t.Setenv("TEST_WEBHOOK_SECRET", "s3cr3t")
+
+	tests := []struct {
+		name            string
+		signatureHeader string
+		timestampHeader string
+		idempotency     string
+	}{
+		{name: "Default headers"},
+		{name: "Custom headers", signatureHeader: "X-Hub-Signature-256", timestampHeader: "X-Hub-Timestamp", idempotency: "X-Request-Key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signatureHeader := tt.signatureHeader
+			if signatureHeader == "" {
+				signatureHeader = "X-PongHub-Signature-256"
+			}
+			timestampHeader := tt.timestampHeader
+			if timestampHeader == "" {
+				timestampHeader = "X-PongHub-Timestamp"
+			}
+			idempotencyHeader := tt.idempotency
+			if idempotencyHeader == "" {
+				idempotencyHeader = "Idempotency-Key"
+			}
+
+			var verifyErr error
+			var idempotencyKey string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				verifyErr = verifyWebhookSignature(r, body, "s3cr3t", signatureHeader, timestampHeader)
+				idempotencyKey = r.Header.Get(idempotencyHeader)
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			config := &configure.WebhookConfig{
+				URL:               server.URL,
+				AuthType:          "bearer",
+				AuthToken:         "token",
+				SigningSecret:     "{{env(TEST_WEBHOOK_SECRET)}}",
+				SignatureHeader:   tt.signatureHeader,
+				TimestampHeader:   tt.timestampHeader,
+				IdempotencyHeader: tt.idempotency,
+			}
+			if err := NewWebhookNotifier(config).Send("Signed Alert", "Signed message"); err != nil {
+				t.Fatalf("Failed to send webhook: %v", err)
+			}
+
+			if verifyErr != nil {
+				t.Errorf("Signature verification failed: %v", verifyErr)
+			}
+			if idempotencyKey == "" {
+				t.Errorf("Expected idempotency key in %s", idempotencyHeader)
+			}
+		})
+	}
+}
+
+// TestWebhookNotifier_IdempotencyKey tests that a notification delivered again keeps its idempotency key,
+// while the notification of another run gets a new one
+func TestWebhookNotifier_IdempotencyKey(t *testing.T) {
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	webhook := NewWebhookNotifier(&configure.WebhookConfig{URL: server.URL, IdempotencyHeader: "Idempotency-Key"})
+	outage := notifier.Event{Type: event_type.OUTAGE, ServiceName: "API", URL: "https://api.example.com", Time: "2025-01-01T00:00:00Z"}
+	first := &notifier.Notification{Title: "Alert", Message: "API is down", Events: []notifier.Event{outage}}
+	outage.Time = "2025-01-01T00:30:00Z"
+	next := &notifier.Notification{Title: "Alert", Message: "API is down", Events: []notifier.Event{outage}}
+
+	for _, notification := range []*notifier.Notification{first, first, next} {
+		if err := webhook.SendContext(context.Background(), notification); err != nil {
+			t.Fatalf("Failed to send webhook: %v", err)
+		}
+	}
+
+	if len(keys) != 3 || keys[0] == "" {
+		t.Fatalf("Expected 3 idempotency keys, got %v", keys)
+	}
+	if keys[0] != keys[1] {
+		t.Errorf("Expected the same key when the notification is delivered again, got %s and %s", keys[0], keys[1])
+	}
+	if keys[0] == keys[2] {
+		t.Errorf("Expected another key for the notification of another run, got %s", keys[2])
+	}
+}
+
+// TestWebhookNotifier_SigningWrongSecret tests that the receiver rejects signatures made with another secret
+func TestWebhookNotifier_SigningWrongSecret(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := verifyWebhookSignature(r, body, "expected-secret", "X-PongHub-Signature-256", "X-PongHub-Timestamp"); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config := &configure.WebhookConfig{URL: server.URL, SigningSecret: "other-secret"}
+	if err := NewWebhookNotifier(config).Send("Alert", "Message"); err == nil {
+		t.Error("Expected the receiver to reject the signature")
+	}
+}
+
+// TestWebhookNotifier_SigningEmptySecret tests that requests are not signed with a secret resolving to an empty value
+func TestWebhookNotifier_SigningEmptySecret(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	t.Setenv("TEST_WEBHOOK_SECRET", "")
+	config := &configure.WebhookConfig{URL: server.URL, SigningSecret: "{{env(TEST_WEBHOOK_SECRET)}}"}
+	if err := NewWebhookNotifier(config).Send("Alert", "Message"); err == nil {
+		t.Error("Expected an error for an empty signing secret")
+	}
+	if requests != 0 {
+		t.Errorf("Expected no request to be sent, got %d", requests)
+	}
+}
+
+// TestWebhookNotifier_SigningRetries tests that retries resend the same signed body and idempotency key
+func TestWebhookNotifier_SigningRetries(t *testing.T) {
+	var requests []*http.Request
+	var bodies [][]byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, r)
+		bodies = append(bodies, body)
+		if len(requests) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config := &configure.WebhookConfig{URL: server.URL, SigningSecret: "s3cr3t", Retries: 1, Timeout: 5}
+	if err := NewWebhookNotifier(config).Send("Alert", "Message"); err != nil {
+		t.Fatalf("Expected success after retry, got error: %v", err)
+	}
+
+	if len(requests) != 2 {
+		t.Fatalf("Expected 2 requests, got %d", len(requests))
+	}
+	if len(bodies[1]) == 0 || string(bodies[0]) != string(bodies[1]) {
+		t.Errorf("Expected the retry to resend the same body, got %q and %q", bodies[0], bodies[1])
+	}
+	if err := verifyWebhookSignature(requests[1], bodies[1], "s3cr3t", "X-PongHub-Signature-256", "X-PongHub-Timestamp"); err != nil {
+		t.Errorf("Signature verification of the retry failed: %v", err)
+	}
+	if key := requests[0].Header.Get("Idempotency-Key"); key == "" || key != requests[1].Header.Get("Idempotency-Key") {
+		t.Errorf("Expected the same idempotency key for retries, got %q and %q", key, requests[1].Header.Get("Idempotency-Key"))
+	}
+}
+
+// TestWebhookNotifier_NoSigning tests that no signature headers are sent without a secret
+func TestWebhookNotifier_NoSigning(t *testing.T) {
+	var headers http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header.Clone()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	if err := NewWebhookNotifier(&configure.WebhookConfig{URL: server.URL}).Send("Alert", "Message"); err != nil {
+		t.Fatalf("Failed to send webhook: %v", err)
+	}
+
+	for _, header := range []string{"X-PongHub-Signature-256", "X-PongHub-Timestamp", "Idempotency-Key"} {
+		if headers.Get(header) != "" {
+			t.Errorf("Expected no %s header without signing", header)
+		}
+	}
+}
+
+func TestWebhookNotifier_SendContext_CountsAttempts(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	webhook := NewWebhookNotifier(&configure.WebhookConfig{URL: server.URL, Retries: 2})
+	ctx, attempts := WithAttemptCounter(context.Background())
+	if err := webhook.SendContext(ctx, &notifier.Notification{Title: "Test", Message: "Test Message"}); err != nil {
+		t.Fatalf("SendContext failed: %v", err)
+	}
+	if attempts.Load() != 2 {
+		t.Errorf("Expected 2 attempts, got %d", attempts.Load())
+	}
+}
+
+func TestWebhookNotifier_SendContext_Deadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	webhook := NewWebhookNotifier(&configure.WebhookConfig{URL: server.URL, Retries: 5})
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := webhook.SendContext(ctx, &notifier.Notification{Title: "Test", Message: "Test Message"})
+	if err == nil {
+		t.Fatal("Expected an error when the deadline expires")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected retries to stop at the deadline, took %v", elapsed)
+	}
+}
@@ -0,0 +1,57 @@
+package channels
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/wcy-dt/ponghub/internal/common/params"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+)
+
+// weComMaxTextLength keeps WeCom text messages below the 2048 byte content limit,
+// even when every character takes four bytes in UTF-8
+const weComMaxTextLength = 512
+
+// WeComNotifier implements WeCom (WeChat Work) group robot notifications
+type WeComNotifier struct {
+	config *configure.WeComConfig
+}
+
+// NewWeComNotifier creates a new WeCom notifier
+func NewWeComNotifier(config *configure.WeComConfig) *WeComNotifier {
+	return &WeComNotifier{config: config}
+}
+
+// Send sends the title and message, see SendContext
+func (w *WeComNotifier) Send(title, message string) error {
+	return w.SendContext(context.Background(), &notifier.Notification{Title: title, Message: message})
+}
+
+// SendContext sends a text message through the WeCom group robot webhook
+func (w *WeComNotifier) SendContext(ctx context.Context, notification *notifier.Notification) error {
+	title, message := notification.Title, notification.Message
+
+	resolver := params.NewParameterResolver()
+
+	webhookURL := resolveWithEnvFallback(resolver, w.config.WebhookURL, "WECOM_WEBHOOK_URL")
+	if webhookURL == "" {
+		return fmt.Errorf("wecom webhook URL not configured")
+	}
+
+	payload := map[string]interface{}{
+		"msgtype": "text",
+		"text": map[string]interface{}{
+			"content":               truncateText(title+"\n\n"+message, weComMaxTextLength),
+			"mentioned_list":        w.config.MentionedList,
+			"mentioned_mobile_list": w.config.MentionedMobileList,
+		},
+	}
+
+	body, err := sendHTTPRequestWithResponse(ctx, webhookURL, "POST", payload, nil, w.config.Retries, w.config.Timeout, false)
+	if err != nil {
+		return fmt.Errorf("wecom request failed: %w", err)
+	}
+
+	return checkBotResponse(body)
+}
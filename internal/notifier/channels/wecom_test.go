@@ -0,0 +1,73 @@
+package channels
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+)
+
+// TestWeComNotifier_Send tests that text messages with mentions are sent to the robot webhook
+func TestWeComNotifier_Send(t *testing.T) {
+	var receivedPayload map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &receivedPayload); err != nil {
+			t.Errorf("Failed to parse JSON: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"errcode": 0, "errmsg": "ok"}`))
+	}))
+	defer server.Close()
+
+	config := &configure.WeComConfig{
+		WebhookURL:    server.URL + "/cgi-bin/webhook/send?key=abc",
+		MentionedList: []string{"@all"},
+	}
+
+	if err := NewWeComNotifier(config).Send("Service Down", "Database error"); err != nil {
+		t.Fatalf("Failed to send wecom message: %v", err)
+	}
+
+	text, _ := receivedPayload["text"].(map[string]interface{})
+	if text["content"] != "Service Down\n\nDatabase error" {
+		t.Errorf("Unexpected content: %v", text["content"])
+	}
+	mentioned, _ := text["mentioned_list"].([]interface{})
+	if len(mentioned) != 1 || mentioned[0] != "@all" {
+		t.Errorf("Expected mentioned_list ['@all'], got %v", text["mentioned_list"])
+	}
+}
+
+// TestWeComNotifier_Truncate tests that long messages are truncated to fit the content limit
+func TestWeComNotifier_Truncate(t *testing.T) {
+	var content string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Text struct {
+				Content string `json:"content"`
+			} `json:"text"`
+		}
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &payload)
+		content = payload.Text.Content
+		_, _ = w.Write([]byte(`{"errcode": 0, "errmsg": "ok"}`))
+	}))
+	defer server.Close()
+
+	err := NewWeComNotifier(&configure.WeComConfig{WebhookURL: server.URL}).Send("Title", strings.Repeat("🔴", 2000))
+	if err != nil {
+		t.Fatalf("Failed to send wecom message: %v", err)
+	}
+
+	if len(content) > 2048 {
+		t.Errorf("Expected content to fit 2048 bytes, got %d", len(content))
+	}
+	if utf8.RuneCountInString(content) != weComMaxTextLength {
+		t.Errorf("Expected %d characters, got %d", weComMaxTextLength, utf8.RuneCountInString(content))
+	}
+}
@@ -0,0 +1,51 @@
+package notifier
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+)
+
+// ReadDeliveryReport loads the report of the last notification delivery,
+// it returns nil if no notification has been delivered yet
+func ReadDeliveryReport(path string) (*notifier.DeliveryReport, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	report := new(notifier.DeliveryReport)
+	if err := json.Unmarshal(content, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// AddDigestReport adds the results of the digest delivery to the delivery report of the notifications,
+// it returns the digest report if no notification was sent
+func AddDigestReport(report, digestReport *notifier.DeliveryReport) *notifier.DeliveryReport {
+	if digestReport == nil {
+		return report
+	}
+	for i := range digestReport.Results {
+		digestReport.Results[i].Digest = true
+	}
+	if report == nil {
+		return digestReport
+	}
+	report.Results = append(report.Results, digestReport.Results...)
+	return report
+}
+
+// WriteDeliveryReport writes the delivery report to file
+func WriteDeliveryReport(report *notifier.DeliveryReport, path string) error {
+	content, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0644)
+}
@@ -0,0 +1,62 @@
+package notifier
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+)
+
+func TestDeliveryReport_ReadWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "delivery_report.json")
+
+	report, err := ReadDeliveryReport(path)
+	if err != nil || report != nil {
+		t.Fatalf("Expected no report before the first delivery, got %+v, %v", report, err)
+	}
+
+	expected := &notifier.DeliveryReport{
+		Time:  "2025-01-01T00:00:00Z",
+		Title: "🚨 PongHub Service Status Alert",
+		Results: []notifier.DeliveryResult{
+			{Channel: "email", Attempts: 1, LatencyMs: 120},
+			{Channel: "ops", Attempts: 3, LatencyMs: 6012, Error: "request failed after 3 attempts"},
+			{Channel: "telegram", Skipped: true},
+		},
+	}
+	if err := WriteDeliveryReport(expected, path); err != nil {
+		t.Fatalf("Failed to write delivery report: %v", err)
+	}
+
+	report, err = ReadDeliveryReport(path)
+	if err != nil {
+		t.Fatalf("Failed to read delivery report: %v", err)
+	}
+	if !reflect.DeepEqual(report, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, report)
+	}
+	if failed := report.FailedChannels(); !reflect.DeepEqual(failed, []string{"ops"}) {
+		t.Errorf("Expected ops to be the only failed channel, got %v", failed)
+	}
+}
+
+func TestAddDigestReport(t *testing.T) {
+	digestReport := &notifier.DeliveryReport{Title: "📊 PongHub Daily Digest", Results: []notifier.DeliveryResult{{Channel: "ops", Error: "timeout"}}}
+	if report := AddDigestReport(nil, nil); report != nil {
+		t.Errorf("Expected no report, got %+v", report)
+	}
+
+	report := &notifier.DeliveryReport{Title: "🚨 PongHub Service Status Alert", Results: []notifier.DeliveryResult{{Channel: "email"}}}
+	report = AddDigestReport(report, digestReport)
+	if len(report.Results) != 2 || !report.Results[1].Digest || report.Results[0].Digest {
+		t.Errorf("Expected the digest results to be added, got %+v", report.Results)
+	}
+	if !reflect.DeepEqual(report.FailedChannels(), []string{"ops"}) {
+		t.Errorf("Expected the failed digest channel to be reported, got %v", report.FailedChannels())
+	}
+
+	if report := AddDigestReport(nil, digestReport); report != digestReport {
+		t.Errorf("Expected the digest report if no notification was sent, got %+v", report)
+	}
+}
@@ -0,0 +1,306 @@
+package notifier
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/common/schedule"
+	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/structures/logger"
+	"github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/chk_result"
+	"github.com/wcy-dt/ponghub/internal/types/types/default_config"
+)
+
+// SendDigest sends the daily or weekly digest built from the log if it is due according to
+// its schedule, i.e. the schedule fired since the last digest was sent.
+// It returns the delivery report, or nil if no digest was sent.
+func SendDigest(logResult logger.Logger, checkResult []checker.Service, notificationConfig *configure.NotificationConfig) *notifier.DeliveryReport {
+	if notificationConfig == nil || !notificationConfig.Enabled || notificationConfig.Digest == nil || !notificationConfig.Digest.Enabled {
+		return nil
+	}
+	config := notificationConfig.Digest
+
+	statePath := default_config.GetDigestStatePath()
+	state, err := readDigestState(statePath)
+	if err != nil {
+		log.Println("Error loading digest state:", err)
+	}
+
+	now := time.Now()
+	due, err := isDigestDue(config, state, now)
+	if err != nil {
+		log.Println("Invalid digest schedule:", err)
+		return nil
+	}
+	if !due {
+		return nil
+	}
+
+	manager := NewNotificationManager(notificationConfig)
+	if !manager.IsEnabled() {
+		log.Println("Notification manager is not enabled or no services configured")
+		return nil
+	}
+	if len(config.Channels) > 0 {
+		manager.SetRouter(func(name string, notification *notifier.Notification) *notifier.Notification {
+			for _, channel := range config.Channels {
+				if strings.EqualFold(channel, name) {
+					return notification
+				}
+			}
+			return nil
+		})
+	}
+
+	digest := buildDigest(logResult, checkResult, config, now)
+	notification := &notifier.Notification{
+		Title:         "📊 PongHub Daily Digest",
+		Message:       generateDigestMessage(digest),
+		StatusPageURL: notificationConfig.StatusPageURL,
+	}
+	if digest.Weekly {
+		notification.Title = "📊 PongHub Weekly Digest"
+	}
+
+	log.Println("Sending digest")
+	report := manager.SendNotification(notification)
+
+	// the digest is sent again in the next run if it could not be delivered to any channel
+	if !report.Delivered() {
+		log.Println("The digest could not be delivered, it is sent again in the next run")
+		return report
+	}
+	state.LastSent = now.Format(time.RFC3339)
+	if err := writeDigestState(state, statePath); err != nil {
+		log.Println("Error writing digest state:", err)
+	}
+	return report
+}
+
+// readDigestState loads the digest state from file or returns an empty state if the file does not exist
+func readDigestState(path string) (notifier.DigestState, error) {
+	var state notifier.DigestState
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return state, err
+	}
+	err = json.Unmarshal(content, &state)
+	return state, err
+}
+
+// writeDigestState writes the digest state to file
+func writeDigestState(state notifier.DigestState, path string) error {
+	content, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0644)
+}
+
+// isDigestDue checks if the schedule of the digest fired within its period before now
+// and after the last digest was sent
+func isDigestDue(config *configure.DigestConfig, state notifier.DigestState, now time.Time) (bool, error) {
+	location, err := schedule.LoadLocation(config.Timezone)
+	if err != nil {
+		return false, err
+	}
+	expr := config.Schedule
+	if expr == "" {
+		expr = default_config.GetDefaultDigestSchedule(isWeeklyDigest(config))
+	}
+	cron, err := schedule.ParseCron(expr)
+	if err != nil {
+		return false, err
+	}
+
+	scheduled, found := cron.Previous(now.In(location), digestPeriod(config))
+	if !found {
+		return false, nil
+	}
+	lastSent, err := time.Parse(time.RFC3339, state.LastSent)
+	return err != nil || scheduled.After(lastSent), nil
+}
+
+// isWeeklyDigest checks if the digest covers a week instead of a day
+func isWeeklyDigest(config *configure.DigestConfig) bool {
+	return strings.EqualFold(config.Period, "weekly")
+}
+
+// digestPeriod returns the period covered by the digest
+func digestPeriod(config *configure.DigestConfig) time.Duration {
+	if isWeeklyDigest(config) {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// buildDigest summarizes the log over the period of the digest ending at now,
+// and the certificates of the current check results
+func buildDigest(logResult logger.Logger, checkResult []checker.Service, config *configure.DigestConfig, now time.Time) notifier.Digest {
+	digest := notifier.Digest{
+		Weekly: isWeeklyDigest(config),
+		From:   now.Add(-digestPeriod(config)),
+		To:     now,
+	}
+	slowest := config.Slowest
+	if slowest <= 0 {
+		slowest = default_config.GetDefaultDigestSlowest()
+	}
+	certDays := config.CertDays
+	if certDays <= 0 {
+		certDays = default_config.GetDefaultDigestCertDays()
+	}
+
+	var endpoints []notifier.DigestEndpoint
+	for _, serviceResult := range checkResult {
+		serviceLog := logResult[serviceResult.Name]
+		service := notifier.DigestService{
+			Name:         serviceResult.Name,
+			Availability: digestAvailability(serviceLog.ServiceHistory, digest.From, digest.To),
+		}
+
+		for _, endpointResult := range serviceResult.Endpoints {
+			history := serviceLog.Endpoints[endpointResult.URL]
+			for _, outage := range history.Outages(digest.From, digest.To) {
+				service.Incidents++
+				service.Downtime += outage.End.Sub(outage.Start)
+			}
+			if endpoint, ok := digestResponseTimes(history, digest.From, digest.To); ok {
+				endpoint.ServiceName = serviceResult.Name
+				endpoint.URL = endpointResult.URL
+				endpoints = append(endpoints, endpoint)
+			}
+
+			if serviceResult.Status != chk_result.MAINTENANCE && endpointResult.IsHTTPS &&
+				(endpointResult.IsCertExpired || endpointResult.CertRemainingDays <= certDays) {
+				digest.Certificates = append(digest.Certificates, notifier.DigestCertificate{
+					ServiceName:   serviceResult.Name,
+					URL:           endpointResult.URL,
+					RemainingDays: endpointResult.CertRemainingDays,
+					Expired:       endpointResult.IsCertExpired,
+				})
+			}
+		}
+		digest.Services = append(digest.Services, service)
+	}
+
+	sort.SliceStable(endpoints, func(i, j int) bool {
+		return endpoints[i].AvgResponseTime > endpoints[j].AvgResponseTime
+	})
+	digest.Slowest = endpoints[:min(slowest, len(endpoints))]
+	sort.SliceStable(digest.Certificates, func(i, j int) bool {
+		return digest.Certificates[i].RemainingDays < digest.Certificates[j].RemainingDays
+	})
+	return digest
+}
+
+// digestAvailability calculates the availability of the service history between from and to,
+// periods of maintenance are excluded
+func digestAvailability(history logger.History, from, to time.Time) float64 {
+	statusAllEntryNum := 0
+	checkedEntryNum := 0
+	for _, entry := range history {
+		entryTime, err := time.Parse(time.RFC3339, entry.Time)
+		if err != nil || entryTime.Before(from) || entryTime.After(to) || chk_result.IsMaintenance(entry.Status) {
+			continue
+		}
+		checkedEntryNum++
+		if chk_result.IsALL(entry.Status) {
+			statusAllEntryNum++
+		}
+	}
+	if checkedEntryNum == 0 {
+		return 1
+	}
+	return float64(statusAllEntryNum) / float64(checkedEntryNum)
+}
+
+// digestResponseTimes calculates the average and maximum response times of the endpoint history
+// between from and to, it returns false if no response times were logged
+func digestResponseTimes(history logger.History, from, to time.Time) (notifier.DigestEndpoint, bool) {
+	var endpoint notifier.DigestEndpoint
+	total, count := 0, 0
+	for _, entry := range history {
+		entryTime, err := time.Parse(time.RFC3339, entry.Time)
+		if err != nil || entryTime.Before(from) || entryTime.After(to) || entry.ResponseTime <= 0 {
+			continue
+		}
+		total += entry.ResponseTime
+		count++
+		endpoint.MaxResponseTime = max(endpoint.MaxResponseTime, entry.ResponseTime)
+	}
+	if count == 0 {
+		return endpoint, false
+	}
+	endpoint.AvgResponseTime = total / count
+	return endpoint, true
+}
+
+// generateDigestMessage creates the plain text message of the digest
+func generateDigestMessage(digest notifier.Digest) string {
+	var message strings.Builder
+
+	message.WriteString(fmt.Sprintf("Period: %s - %s\n\n", digest.From.Format("2006-01-02 15:04"), digest.To.Format("2006-01-02 15:04")))
+
+	totalIncidents := 0
+	var totalDowntime time.Duration
+	message.WriteString("📈 AVAILABILITY:\n")
+	message.WriteString(strings.Repeat("=", 30) + "\n")
+	for _, service := range digest.Services {
+		line := fmt.Sprintf("  • %s: %.2f%%", service.Name, service.Availability*100)
+		if service.Incidents > 0 {
+			line += fmt.Sprintf(" (%d incident(s), %s downtime)", service.Incidents, formatDowntime(service.Downtime))
+		}
+		message.WriteString(line + "\n")
+		totalIncidents += service.Incidents
+		totalDowntime += service.Downtime
+	}
+
+	if len(digest.Slowest) > 0 {
+		message.WriteString("\n🐢 SLOWEST ENDPOINTS:\n")
+		message.WriteString(strings.Repeat("=", 30) + "\n")
+		for _, endpoint := range digest.Slowest {
+			message.WriteString(fmt.Sprintf("  • [%s] %s: avg %dms, max %dms\n", endpoint.ServiceName, endpoint.URL, endpoint.AvgResponseTime, endpoint.MaxResponseTime))
+		}
+	}
+
+	if len(digest.Certificates) > 0 {
+		message.WriteString("\n🔐 UPCOMING CERTIFICATE EXPIRATIONS:\n")
+		message.WriteString(strings.Repeat("=", 30) + "\n")
+		for _, certificate := range digest.Certificates {
+			if certificate.Expired {
+				message.WriteString(fmt.Sprintf("  • [%s] %s: EXPIRED\n", certificate.ServiceName, certificate.URL))
+			} else {
+				message.WriteString(fmt.Sprintf("  • [%s] %s: expires in %d day(s)\n", certificate.ServiceName, certificate.URL, certificate.RemainingDays))
+			}
+		}
+	}
+
+	message.WriteString("\n📊 SUMMARY:\n")
+	message.WriteString(strings.Repeat("=", 30) + "\n")
+	message.WriteString(fmt.Sprintf("Services: %d\n", len(digest.Services)))
+	message.WriteString(fmt.Sprintf("Incidents: %d\n", totalIncidents))
+	message.WriteString(fmt.Sprintf("Downtime: %s\n", formatDowntime(totalDowntime)))
+	message.WriteString(fmt.Sprintf("Certificates Expiring: %d\n", len(digest.Certificates)))
+
+	return message.String()
+}
+
+// formatDowntime formats a downtime rounded to minutes, e.g. "1h35m"
+func formatDowntime(downtime time.Duration) string {
+	downtime = downtime.Round(time.Minute)
+	if downtime < time.Hour {
+		return fmt.Sprintf("%dm", int(downtime.Minutes()))
+	}
+	return fmt.Sprintf("%dh%dm", int(downtime.Hours()), int(downtime.Minutes())%60)
+}
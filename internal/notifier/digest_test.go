@@ -0,0 +1,183 @@
+package notifier
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/structures/logger"
+	"github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/chk_result"
+	"github.com/wcy-dt/ponghub/internal/types/types/default_config"
+)
+
+// newTestHistory creates a history with an entry every 30 minutes ending at end,
+// with the given statuses and response times
+func newTestHistory(end time.Time, statuses []string, responseTime int) logger.History {
+	var history logger.History
+	for i, status := range statuses {
+		history = append(history, logger.HistoryEntry{
+			Time:         end.Add(time.Duration(i-len(statuses)+1) * 30 * time.Minute).Format(time.RFC3339),
+			Status:       status,
+			ResponseTime: responseTime,
+		})
+	}
+	return history
+}
+
+func TestIsDigestDue(t *testing.T) {
+	now, _ := time.Parse(time.RFC3339, "2025-03-05T10:00:00Z")
+
+	tests := []struct {
+		name     string
+		config   configure.DigestConfig
+		lastSent string
+		expected bool
+	}{
+		{name: "Never sent", config: configure.DigestConfig{Timezone: "UTC"}, expected: true},
+		{name: "Sent before the schedule", config: configure.DigestConfig{Timezone: "UTC"}, lastSent: "2025-03-04T09:10:00Z", expected: true},
+		{name: "Sent after the schedule", config: configure.DigestConfig{Timezone: "UTC"}, lastSent: "2025-03-05T09:10:00Z", expected: false},
+		{name: "Other time zone", config: configure.DigestConfig{Timezone: "Asia/Shanghai"}, lastSent: "2025-03-05T01:30:00Z", expected: false},
+		{name: "Weekly on Monday", config: configure.DigestConfig{Period: "weekly", Timezone: "UTC"}, lastSent: "2025-03-03T09:30:00Z", expected: false},
+		{name: "Custom schedule", config: configure.DigestConfig{Schedule: "30 9 * * *", Timezone: "UTC"}, lastSent: "2025-03-05T09:10:00Z", expected: true},
+		{name: "Not within the period", config: configure.DigestConfig{Schedule: "0 9 1 * *", Timezone: "UTC"}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			due, err := isDigestDue(&tt.config, notifier.DigestState{LastSent: tt.lastSent}, now)
+			if err != nil {
+				t.Fatalf("isDigestDue failed: %v", err)
+			}
+			if due != tt.expected {
+				t.Errorf("Expected due to be %v, got %v", tt.expected, due)
+			}
+		})
+	}
+
+	if _, err := isDigestDue(&configure.DigestConfig{Schedule: "every day"}, notifier.DigestState{}, now); err == nil {
+		t.Error("Expected an error for an invalid schedule")
+	}
+}
+
+func TestBuildDigest(t *testing.T) {
+	now := time.Now().Truncate(time.Minute)
+	apiStatuses := []string{"all", "none", "none", "all", "maintenance", "all", "none"}
+	logResult := logger.Logger{
+		"api": {
+			ServiceHistory: newTestHistory(now, apiStatuses, 0),
+			Endpoints: logger.Endpoints{
+				"https://api.example.com":        newTestHistory(now, apiStatuses, 800),
+				"https://api.example.com/health": newTestHistory(now, []string{"all", "all"}, 100),
+			},
+		},
+		"web": {
+			ServiceHistory: newTestHistory(now, []string{"all", "all"}, 0),
+			Endpoints:      logger.Endpoints{"https://web.example.com": newTestHistory(now, []string{"all", "all"}, 300)},
+		},
+	}
+	checkResult := []checker.Service{
+		{Name: "api", Status: chk_result.NONE, Endpoints: []checker.Endpoint{
+			{URL: "https://api.example.com", IsHTTPS: true, CertRemainingDays: 90},
+			{URL: "https://api.example.com/health", IsHTTPS: true, CertRemainingDays: 10},
+		}},
+		{Name: "web", Status: chk_result.ALL, Endpoints: []checker.Endpoint{
+			{URL: "https://web.example.com", IsHTTPS: true, IsCertExpired: true},
+		}},
+	}
+
+	digest := buildDigest(logResult, checkResult, &configure.DigestConfig{Slowest: 2}, now)
+
+	if len(digest.Services) != 2 {
+		t.Fatalf("Expected 2 services, got %d", len(digest.Services))
+	}
+	api := digest.Services[0]
+	if api.Availability != 0.5 {
+		t.Errorf("Expected an availability of 50%% excluding maintenance, got %v", api.Availability)
+	}
+	if api.Incidents != 2 || api.Downtime != time.Hour {
+		t.Errorf("Expected 2 incidents with 1h of downtime, got %d and %v", api.Incidents, api.Downtime)
+	}
+	if len(digest.Slowest) != 2 || digest.Slowest[0].URL != "https://api.example.com" || digest.Slowest[1].AvgResponseTime != 300 {
+		t.Errorf("Unexpected slowest endpoints: %+v", digest.Slowest)
+	}
+	if len(digest.Certificates) != 2 || !digest.Certificates[0].Expired || digest.Certificates[1].RemainingDays != 10 {
+		t.Errorf("Unexpected certificates: %+v", digest.Certificates)
+	}
+
+	message := generateDigestMessage(digest)
+	for _, expected := range []string{
+		"api: 50.00% (2 incident(s), 1h0m downtime)",
+		"web: 100.00%",
+		"[api] https://api.example.com: avg 800ms, max 800ms",
+		"[web] https://web.example.com: EXPIRED",
+		"[api] https://api.example.com/health: expires in 10 day(s)",
+		"Incidents: 2",
+	} {
+		if !strings.Contains(message, expected) {
+			t.Errorf("Expected the message to contain %q, got:\n%s", expected, message)
+		}
+	}
+}
+
+func TestHistory_Outages(t *testing.T) {
+	end, _ := time.Parse(time.RFC3339, "2025-03-05T12:00:00Z")
+	history := newTestHistory(end, []string{"none", "none", "all", "none", "maintenance", "impacted", "all", "none"}, 0)
+
+	outages := history.Outages(end.Add(-3*time.Hour), end)
+	if len(outages) != 3 {
+		t.Fatalf("Expected 3 outages, got %+v", outages)
+	}
+	if !outages[0].Start.Equal(end.Add(-3*time.Hour)) || !outages[0].End.Equal(end.Add(-150*time.Minute)) {
+		t.Errorf("Expected the first outage to be clipped to the period, got %+v", outages[0])
+	}
+	if outages[1].End.Sub(outages[1].Start) != 90*time.Minute {
+		t.Errorf("Expected maintenance and impacted checks not to end the second outage, got %+v", outages[1])
+	}
+	if !outages[2].Ongoing || !outages[2].End.Equal(end) {
+		t.Errorf("Expected the last outage to be ongoing, got %+v", outages[2])
+	}
+}
+
+func TestSendDigest_NotDelivered(t *testing.T) {
+	default_config.SetDataDir(t.TempDir())
+	t.Cleanup(func() { default_config.SetDataDir("") })
+
+	status := http.StatusServiceUnavailable
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	notificationConfig := &configure.NotificationConfig{
+		Enabled:        true,
+		Methods:        []string{"webhook"},
+		ChannelConfigs: configure.ChannelConfigs{Webhook: &configure.WebhookConfig{URL: server.URL}},
+		Digest:         &configure.DigestConfig{Enabled: true, Schedule: "* * * * *", Timezone: "UTC"},
+	}
+
+	// the digest is sent again in the next run if no channel received it
+	report := SendDigest(logger.Logger{}, nil, notificationConfig)
+	if report == nil || report.Delivered() {
+		t.Fatalf("Expected the digest not to be delivered, got %+v", report)
+	}
+	state, err := readDigestState(default_config.GetDigestStatePath())
+	if err != nil || state.LastSent != "" {
+		t.Fatalf("Expected the digest not to be marked as sent, got %+v (%v)", state, err)
+	}
+
+	status = http.StatusOK
+	if report := SendDigest(logger.Logger{}, nil, notificationConfig); !report.Delivered() {
+		t.Fatalf("Expected the digest to be delivered, got %+v", report)
+	}
+	if state, _ := readDigestState(default_config.GetDigestStatePath()); state.LastSent == "" {
+		t.Error("Expected the digest to be marked as sent")
+	}
+	if report := SendDigest(logger.Logger{}, nil, notificationConfig); report != nil {
+		t.Errorf("Expected the digest not to be sent twice, got %+v", report)
+	}
+}
@@ -0,0 +1,234 @@
+package notifier
+
+import (
+	"bufio"
+	"encoding/json"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/chk_result"
+)
+
+// escalationStep is a parsed escalation step
+type escalationStep struct {
+	after    time.Duration
+	channels []string
+}
+
+// escalation routes the notifications of outages to the channels of the escalation steps
+// that are due, based on the open incidents
+type escalation struct {
+	steps     []escalationStep
+	incidents notifier.Incidents
+	now       time.Time
+}
+
+// ReadIncidents loads the open incidents from file or returns no incidents if the file does not exist
+func ReadIncidents(path string) (notifier.Incidents, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return notifier.Incidents{}, nil
+		}
+		return nil, err
+	}
+
+	incidents := notifier.Incidents{}
+	if err := json.Unmarshal(content, &incidents); err != nil {
+		return nil, err
+	}
+	return incidents, nil
+}
+
+// WriteIncidents writes the open incidents to file
+func WriteIncidents(incidents notifier.Incidents, path string) error {
+	if incidents == nil {
+		incidents = notifier.Incidents{}
+	}
+	content, err := json.MarshalIndent(incidents, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0644)
+}
+
+// readAcks reads the acknowledged incidents from the ack file, one service name, endpoint URL or
+// incident key per line. Empty lines and lines starting with # are ignored.
+func readAcks(path string) []string {
+	f, err := os.Open(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Println("Error reading ack file:", err)
+		}
+		return nil
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.Println("Error closing ack file:", err)
+		}
+	}()
+
+	var acks []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			acks = append(acks, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		log.Println("Error reading ack file:", err)
+	}
+	return acks
+}
+
+// newEscalation parses the escalation policy, steps with an invalid duration are ignored
+func newEscalation(config *configure.EscalationConfig, incidents notifier.Incidents, now time.Time) *escalation {
+	e := &escalation{incidents: incidents, now: now}
+	for i, step := range config.Steps {
+		var after time.Duration
+		if step.After != "" {
+			var err error
+			if after, err = time.ParseDuration(step.After); err != nil || after < 0 {
+				log.Printf("Ignoring escalation step %d, invalid duration %q", i, step.After)
+				continue
+			}
+		}
+		e.steps = append(e.steps, escalationStep{after: after, channels: step.Channels})
+	}
+	return e
+}
+
+// open starts an incident for every unavailable endpoint that has no open incident yet
+func (e *escalation) open(statusNoneEndpoints map[string][]checker.Endpoint) {
+	for serviceName, endpoints := range statusNoneEndpoints {
+		for _, endpoint := range endpoints {
+			key := incidentKey(serviceName, endpoint)
+			if _, exists := e.incidents[key]; exists {
+				continue
+			}
+			e.incidents[key] = &notifier.Incident{
+				ServiceName: serviceName,
+				URL:         endpoint.URL,
+				Started:     e.now.Format(time.RFC3339),
+			}
+		}
+	}
+}
+
+// acknowledge marks the incidents matching an ack entry as acknowledged, which stops their escalation
+func (e *escalation) acknowledge(acks []string) {
+	for _, ack := range acks {
+		matched := false
+		for key, incident := range e.incidents {
+			if ack != key && ack != incident.URL && !strings.EqualFold(ack, incident.ServiceName) {
+				continue
+			}
+			matched = true
+			if incident.Acknowledged == "" {
+				incident.Acknowledged = e.now.Format(time.RFC3339)
+				log.Printf("Incident of %s %s acknowledged", incident.ServiceName, incident.URL)
+			}
+		}
+		if !matched {
+			log.Printf("Ack entry %q matches no open incident and can be removed", ack)
+		}
+	}
+}
+
+// close resolves the incidents of endpoints that are available again or no longer checked.
+// Incidents of endpoints under maintenance or impacted by a service they depend on stay open.
+func (e *escalation) close(checkResult []checker.Service) {
+	statuses := make(map[string]chk_result.CheckResult)
+	for _, serviceResult := range checkResult {
+		for _, endpoint := range serviceResult.Endpoints {
+			statuses[incidentKey(serviceResult.Name, endpoint)] = endpoint.Status
+		}
+	}
+
+	for key, incident := range e.incidents {
+		status, exists := statuses[key]
+		if exists && (status == chk_result.NONE || status == chk_result.MAINTENANCE || status == chk_result.IMPACTED) {
+			continue
+		}
+		log.Printf("Incident of %s %s resolved", incident.ServiceName, incident.URL)
+		delete(e.incidents, key)
+	}
+}
+
+// route returns the endpoints the named channel is notified about. Outages are sent once the
+// channel's step is due and from then on until they are resolved, recoveries only to the channels
+// the outage was escalated to and certificate issues and renewals only to channels of immediate steps.
+// Channels that are not part of any step are notified about everything.
+func (e *escalation) route(channel string, statusNoneEndpoints, certProblemEndpoints, recoveredEndpoints, renewedEndpoints map[string][]checker.Endpoint) (map[string][]checker.Endpoint, map[string][]checker.Endpoint, map[string][]checker.Endpoint, map[string][]checker.Endpoint) {
+	after, escalated := e.delay(channel)
+	if !escalated {
+		return statusNoneEndpoints, certProblemEndpoints, recoveredEndpoints, renewedEndpoints
+	}
+
+	outages := filterEndpoints(statusNoneEndpoints, func(serviceName string, endpoint checker.Endpoint) bool {
+		incident := e.incidents[incidentKey(serviceName, endpoint)]
+		if incident == nil || slices.Contains(incident.Escalated, channel) {
+			return true
+		}
+		started, err := time.Parse(time.RFC3339, incident.Started)
+		if err != nil || (after > 0 && (incident.Acknowledged != "" || e.now.Sub(started) < after)) {
+			return false
+		}
+		if after > 0 {
+			log.Printf("Escalating incident of %s %s to %s", serviceName, endpoint.URL, channel)
+		}
+		incident.Escalated = append(incident.Escalated, channel)
+		return true
+	})
+	recoveries := filterEndpoints(recoveredEndpoints, func(serviceName string, endpoint checker.Endpoint) bool {
+		if incident := e.incidents[incidentKey(serviceName, endpoint)]; incident != nil {
+			return slices.Contains(incident.Escalated, channel)
+		}
+		return after == 0
+	})
+	certProblems, renewals := certProblemEndpoints, renewedEndpoints
+	if after > 0 {
+		certProblems, renewals = nil, nil
+	}
+	return outages, certProblems, recoveries, renewals
+}
+
+// delay returns the earliest escalation delay of the named channel and whether it is part of any step
+func (e *escalation) delay(channel string) (time.Duration, bool) {
+	var after time.Duration
+	found := false
+	for _, step := range e.steps {
+		for _, name := range step.channels {
+			if strings.EqualFold(name, channel) && (!found || step.after < after) {
+				after = step.after
+				found = true
+			}
+		}
+	}
+	return after, found
+}
+
+// incidentKey returns the key of the incident of the given endpoint
+func incidentKey(serviceName string, endpoint checker.Endpoint) string {
+	return notifier.Event{ServiceName: serviceName, URL: endpoint.URL}.DedupKey()
+}
+
+// filterEndpoints returns the endpoints of the map for which keep returns true
+func filterEndpoints(endpointsMap map[string][]checker.Endpoint, keep func(serviceName string, endpoint checker.Endpoint) bool) map[string][]checker.Endpoint {
+	filtered := make(map[string][]checker.Endpoint)
+	for _, serviceName := range sortedServiceNames(endpointsMap) {
+		for _, endpoint := range endpointsMap[serviceName] {
+			if keep(serviceName, endpoint) {
+				filtered[serviceName] = append(filtered[serviceName], endpoint)
+			}
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,198 @@
+package notifier
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/chk_result"
+	"github.com/wcy-dt/ponghub/internal/types/types/event_type"
+)
+
+// newTestEscalation returns an escalation notifying chat immediately, pager after 15 minutes
+// and manager after an hour
+func newTestEscalation(incidents notifier.Incidents, now time.Time) *escalation {
+	return newEscalation(&configure.EscalationConfig{
+		Steps: []configure.EscalationStep{
+			{Channels: []string{"chat"}},
+			{After: "15m", Channels: []string{"Pager"}},
+			{After: "1h", Channels: []string{"manager"}},
+			{After: "soon", Channels: []string{"chat"}},
+		},
+	}, incidents, now)
+}
+
+func TestEscalation_Route(t *testing.T) {
+	now := time.Now()
+	down := checker.Endpoint{URL: "https://api.example.com", Status: chk_result.NONE}
+	recovered := checker.Endpoint{URL: "https://web.example.com", Status: chk_result.ALL}
+	statusNoneEndpoints := map[string][]checker.Endpoint{"api": {down}}
+	certProblemEndpoints := map[string][]checker.Endpoint{"web": {recovered}}
+	recoveredEndpoints := map[string][]checker.Endpoint{"web": {recovered}}
+
+	e := newTestEscalation(notifier.Incidents{
+		incidentKey("api", down): {ServiceName: "api", URL: down.URL, Started: now.Add(-20 * time.Minute).Format(time.RFC3339)},
+		incidentKey("web", recovered): {ServiceName: "web", URL: recovered.URL, Started: now.Add(-2 * time.Hour).Format(time.RFC3339),
+			Escalated: []string{"chat", "manager"}},
+	}, now)
+	if len(e.steps) != 3 {
+		t.Fatalf("Expected the invalid step to be ignored, got %d steps", len(e.steps))
+	}
+
+	tests := []struct {
+		channel   string
+		outages   int
+		certs     int
+		recovered int
+	}{
+		{channel: "log", outages: 1, certs: 1, recovered: 1},
+		{channel: "chat", outages: 1, certs: 1, recovered: 1},
+		{channel: "pager", outages: 1, certs: 0, recovered: 0},
+		{channel: "manager", outages: 0, certs: 0, recovered: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.channel, func(t *testing.T) {
+			outages, certs, recoveries, renewals := e.route(tt.channel, statusNoneEndpoints, certProblemEndpoints, recoveredEndpoints, certProblemEndpoints)
+			if countEndpoints(outages) != tt.outages || countEndpoints(certs) != tt.certs || countEndpoints(recoveries) != tt.recovered {
+				t.Errorf("Expected %d/%d/%d endpoints, got %d/%d/%d", tt.outages, tt.certs, tt.recovered,
+					countEndpoints(outages), countEndpoints(certs), countEndpoints(recoveries))
+			}
+			if countEndpoints(renewals) != tt.certs {
+				t.Errorf("Expected renewals to be routed like certificate issues, got %d", countEndpoints(renewals))
+			}
+		})
+	}
+
+	if escalated := e.incidents[incidentKey("api", down)].Escalated; !reflect.DeepEqual(escalated, []string{"chat", "pager"}) {
+		t.Errorf("Expected the outage to be escalated to chat and pager, got %v", escalated)
+	}
+}
+
+func TestEscalation_Acknowledge(t *testing.T) {
+	now := time.Now()
+	down := checker.Endpoint{URL: "https://api.example.com", Status: chk_result.NONE}
+	statusNoneEndpoints := map[string][]checker.Endpoint{"api": {down}}
+
+	e := newTestEscalation(notifier.Incidents{
+		incidentKey("api", down): {ServiceName: "api", URL: down.URL, Started: now.Add(-2 * time.Hour).Format(time.RFC3339),
+			Escalated: []string{"chat", "pager"}},
+	}, now)
+	e.acknowledge([]string{"API", "unknown"})
+
+	incident := e.incidents[incidentKey("api", down)]
+	if incident.Acknowledged == "" {
+		t.Fatal("Expected the incident to be acknowledged by service name")
+	}
+	if outages, _, _, _ := e.route("pager", statusNoneEndpoints, nil, nil, nil); countEndpoints(outages) != 1 {
+		t.Error("Expected channels the incident was escalated to before to keep being notified")
+	}
+	if outages, _, _, _ := e.route("manager", statusNoneEndpoints, nil, nil, nil); countEndpoints(outages) != 0 {
+		t.Error("Expected the acknowledged incident not to be escalated further")
+	}
+}
+
+func TestEscalation_OpenAndClose(t *testing.T) {
+	now := time.Now()
+	down := checker.Endpoint{URL: "https://api.example.com", Status: chk_result.NONE}
+	paused := checker.Endpoint{URL: "https://admin.example.com", Status: chk_result.MAINTENANCE}
+	up := checker.Endpoint{URL: "https://web.example.com", Status: chk_result.ALL}
+
+	e := newTestEscalation(notifier.Incidents{}, now)
+	e.open(map[string][]checker.Endpoint{"api": {down}, "admin": {paused}, "web": {up}})
+	if len(e.incidents) != 3 {
+		t.Fatalf("Expected 3 incidents, got %d", len(e.incidents))
+	}
+	if started := e.incidents[incidentKey("api", down)].Started; started != now.Format(time.RFC3339) {
+		t.Errorf("Expected the incident to start now, got %s", started)
+	}
+
+	// opening again keeps the start time
+	e.now = now.Add(time.Hour)
+	e.open(map[string][]checker.Endpoint{"api": {down}})
+	if started := e.incidents[incidentKey("api", down)].Started; started != now.Format(time.RFC3339) {
+		t.Errorf("Expected the incident to keep its start time, got %s", started)
+	}
+
+	e.close([]checker.Service{
+		{Name: "api", Endpoints: []checker.Endpoint{down}},
+		{Name: "admin", Endpoints: []checker.Endpoint{paused}},
+		{Name: "web", Endpoints: []checker.Endpoint{up}},
+	})
+	if len(e.incidents) != 2 || e.incidents[incidentKey("web", up)] != nil {
+		t.Errorf("Expected only the incident of the available endpoint to be resolved, got %+v", e.incidents)
+	}
+
+	e.close(nil)
+	if len(e.incidents) != 0 {
+		t.Errorf("Expected the incidents of removed endpoints to be resolved, got %+v", e.incidents)
+	}
+}
+
+func TestIncidentsAndAcks_ReadWrite(t *testing.T) {
+	dir := t.TempDir()
+	incidentsPath := filepath.Join(dir, "incidents.json")
+
+	incidents, err := ReadIncidents(incidentsPath)
+	if err != nil || len(incidents) != 0 {
+		t.Fatalf("Expected no incidents for a missing file, got %v, %v", incidents, err)
+	}
+
+	incidents["key"] = &notifier.Incident{ServiceName: "api", URL: "https://api.example.com", Started: "2025-01-01T00:00:00Z", Escalated: []string{"chat"}}
+	if err := WriteIncidents(incidents, incidentsPath); err != nil {
+		t.Fatalf("WriteIncidents failed: %v", err)
+	}
+	read, err := ReadIncidents(incidentsPath)
+	if err != nil || !reflect.DeepEqual(read, incidents) {
+		t.Errorf("Expected %+v, got %+v, %v", incidents, read, err)
+	}
+
+	ackPath := filepath.Join(dir, "ack.txt")
+	if acks := readAcks(ackPath); acks != nil {
+		t.Errorf("Expected no acks for a missing file, got %v", acks)
+	}
+	if err := os.WriteFile(ackPath, []byte("# acknowledged incidents\napi\n\n  https://web.example.com  \n"), 0644); err != nil {
+		t.Fatalf("Failed to write ack file: %v", err)
+	}
+	if acks := readAcks(ackPath); !reflect.DeepEqual(acks, []string{"api", "https://web.example.com"}) {
+		t.Errorf("Unexpected acks: %v", acks)
+	}
+}
+
+func TestNotificationManager_SetRouter(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	manager := NewNotificationManager(&configure.NotificationConfig{
+		Enabled: true,
+		Channels: []*configure.ChannelConfig{
+			{Name: "chat", Type: "ntfy", ChannelConfigs: configure.ChannelConfigs{Ntfy: &configure.NtfyConfig{ServerURL: server.URL, Topic: "ops"}}},
+			{Name: "pager", Type: "ntfy", ChannelConfigs: configure.ChannelConfigs{Ntfy: &configure.NtfyConfig{ServerURL: server.URL, Topic: "oncall"}}},
+		},
+	})
+	manager.SetRouter(func(name string, notification *notifier.Notification) *notifier.Notification {
+		if name == "pager" {
+			return nil
+		}
+		return notification
+	})
+
+	report := manager.SendWithOutbox(newTestNotification(event_type.OUTAGE), &notifier.Outbox{})
+	if len(report.Results) != 1 || report.Results[0].Channel != "chat" || report.Results[0].Error != "" {
+		t.Errorf("Expected only chat to be notified, got %+v", report.Results)
+	}
+	if requests.Load() != 1 {
+		t.Errorf("Expected a single request, got %d", requests.Load())
+	}
+}
@@ -1,18 +1,35 @@
 package notifier
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/wcy-dt/ponghub/internal/common"
 	"github.com/wcy-dt/ponghub/internal/notifier/channels"
 	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/default_config"
 )
 
+// errUnknownType is returned when a notification method or channel has an unknown type
+var errUnknownType = errors.New("unknown notification type")
+
 // NotificationManager manages multiple notification services
 type NotificationManager struct {
-	services []NotificationService
-	config   *configure.NotificationConfig
+	services   []NotificationService
+	names      []string
+	config     *configure.NotificationConfig
+	quietHours []quietHours
+
+	// route returns the notification the named service receives, or nil to skip it
+	route func(name string, notification *notifier.Notification) *notifier.Notification
 }
 
 // NewNotificationManager creates a new notification manager
@@ -27,11 +44,11 @@ func NewNotificationManager(config *configure.NotificationConfig) *NotificationM
 		log.Println("No notification configuration found, using default GitHub Actions notification")
 		defaultConfig := &configure.DefaultConfig{Enabled: true}
 		manager.config = &configure.NotificationConfig{
-			Enabled: true,
-			Methods: []string{"default"},
-			Default: defaultConfig,
+			Enabled:        true,
+			Methods:        []string{"default"},
+			ChannelConfigs: configure.ChannelConfigs{Default: defaultConfig},
 		}
-		manager.services = append(manager.services, channels.NewDefaultNotifier(defaultConfig))
+		manager.addService("default", channels.NewDefaultNotifier(defaultConfig))
 		return manager
 	}
 
@@ -40,71 +57,416 @@ func NewNotificationManager(config *configure.NotificationConfig) *NotificationM
 		return &NotificationManager{}
 	}
 
-	// If no methods are specified but notifications are enabled, use default
-	if len(config.Methods) == 0 {
+	manager.quietHours = newQuietHours(config.QuietHours)
+
+	// If neither methods nor channels are specified but notifications are enabled, use default
+	if len(config.Methods) == 0 && len(config.Channels) == 0 {
 		log.Println("Notifications enabled but no methods specified, using default GitHub Actions notification")
 		if config.Default == nil {
 			config.Default = &configure.DefaultConfig{Enabled: true}
 		}
 		config.Methods = []string{"default"}
-		manager.services = append(manager.services, channels.NewDefaultNotifier(config.Default))
+		manager.addService("default", channels.NewDefaultNotifier(config.Default))
 		return manager
 	}
 
 	// Initialize notification services based on configured methods
 	for _, method := range config.Methods {
-		switch strings.ToLower(method) {
-		case "default":
-			if config.Default == nil {
-				config.Default = &configure.DefaultConfig{Enabled: true}
-			}
-			manager.services = append(manager.services, channels.NewDefaultNotifier(config.Default))
-		case "email":
-			if config.Email != nil {
-				manager.services = append(manager.services, channels.NewEmailNotifier(config.Email))
-			}
-		case "webhook":
-			if config.Webhook != nil {
-				manager.services = append(manager.services, channels.NewWebhookNotifier(config.Webhook))
+		method = strings.ToLower(method)
+		if method == "default" && config.Default == nil {
+			config.Default = &configure.DefaultConfig{Enabled: true}
+		}
+
+		service, err := newService(method, &config.ChannelConfigs)
+		if err != nil {
+			log.Printf("Skipping notification method %s: %v", method, err)
+			continue
+		}
+		manager.addService(method, service)
+
+		// additional email configurations are sent together with the email method
+		if method == "email" {
+			for i, emailConfig := range config.Emails {
+				if emailConfig != nil {
+					manager.addService(fmt.Sprintf("emails[%d]", i), channels.NewEmailNotifier(emailConfig))
+				}
 			}
-		default:
-			log.Printf("Unknown notification method: %s", method)
 		}
 	}
 
+	// Initialize named channel instances
+	for i, channel := range config.Channels {
+		if channel == nil {
+			continue
+		}
+
+		name := channel.Name
+		if name == "" {
+			name = fmt.Sprintf("channels[%d]", i)
+		}
+
+		channelType := strings.ToLower(channel.Type)
+		if channelType == "default" && channel.Default == nil {
+			channel.Default = &configure.DefaultConfig{Enabled: true}
+		}
+
+		service, err := newService(channelType, &channel.ChannelConfigs)
+		if err != nil {
+			log.Printf("Skipping notification channel %s: %v", name, err)
+			continue
+		}
+		manager.addService(name, service)
+	}
+
 	return manager
 }
 
-// SendNotification sends notification through all configured services
-func (nm *NotificationManager) SendNotification(title, message string) {
+// newService creates the notification service of the given channel type from its settings
+func newService(channelType string, configs *configure.ChannelConfigs) (NotificationService, error) {
+	switch channelType {
+	case "default":
+		if configs.Default != nil {
+			return channels.NewDefaultNotifier(configs.Default), nil
+		}
+	case "email":
+		if configs.Email != nil {
+			return channels.NewEmailNotifier(configs.Email), nil
+		}
+	case "webhook":
+		if configs.Webhook != nil {
+			return channels.NewWebhookNotifier(configs.Webhook), nil
+		}
+	case "telegram":
+		if configs.Telegram != nil {
+			return channels.NewTelegramNotifier(configs.Telegram), nil
+		}
+	case "dingtalk":
+		if configs.DingTalk != nil {
+			return channels.NewDingTalkNotifier(configs.DingTalk), nil
+		}
+	case "feishu", "lark":
+		if configs.Feishu != nil {
+			return channels.NewFeishuNotifier(configs.Feishu), nil
+		}
+	case "wecom":
+		if configs.WeCom != nil {
+			return channels.NewWeComNotifier(configs.WeCom), nil
+		}
+	case "pagerduty":
+		if configs.PagerDuty != nil {
+			return channels.NewPagerDutyNotifier(configs.PagerDuty), nil
+		}
+	case "opsgenie":
+		if configs.Opsgenie != nil {
+			return channels.NewOpsgenieNotifier(configs.Opsgenie), nil
+		}
+	case "ntfy":
+		if configs.Ntfy != nil {
+			return channels.NewNtfyNotifier(configs.Ntfy), nil
+		}
+	case "gotify":
+		if configs.Gotify != nil {
+			return channels.NewGotifyNotifier(configs.Gotify), nil
+		}
+	case "pushover":
+		if configs.Pushover != nil {
+			return channels.NewPushoverNotifier(configs.Pushover), nil
+		}
+	case "matrix":
+		if configs.Matrix != nil {
+			return channels.NewMatrixNotifier(configs.Matrix), nil
+		}
+	case "command":
+		if configs.Command != nil {
+			return channels.NewCommandNotifier(configs.Command), nil
+		}
+	default:
+		return nil, fmt.Errorf("%w %q", errUnknownType, channelType)
+	}
+
+	settingsKey := channelType
+	if channelType == "lark" {
+		settingsKey = "feishu"
+	}
+	return nil, fmt.Errorf("no %s settings configured", settingsKey)
+}
+
+// addService registers a notification service under the given name
+func (nm *NotificationManager) addService(name string, service NotificationService) {
+	nm.services = append(nm.services, service)
+	nm.names = append(nm.names, name)
+}
+
+// SetRouter sets a function that returns the notification each service receives instead of the
+// notification being sent, e.g. with only the events the service is responsible for.
+// Services for which it returns nil only retry their queued notifications.
+func (nm *NotificationManager) SetRouter(route func(name string, notification *notifier.Notification) *notifier.Notification) {
+	nm.route = route
+}
+
+// SendNotification sends notification through all configured services concurrently and
+// returns a report of the delivery. Services implementing EventNotificationService receive
+// the structured events, all other services receive the plain text message if there is one.
+// Services that have not finished when the delivery deadline expires are reported as failed.
+func (nm *NotificationManager) SendNotification(notification *notifier.Notification) *notifier.DeliveryReport {
+	return nm.SendWithOutbox(notification, nil)
+}
+
+// SendWithOutbox works like SendNotification, but first retries the due notifications of the
+// outbox and queues the notification in the outbox for every service it could not be delivered to.
+// Each service receives its notifications in order, a notification is queued without being sent
+// while an earlier one is still pending. The notification may be nil to only retry the outbox.
+func (nm *NotificationManager) SendWithOutbox(notification *notifier.Notification, outbox *notifier.Outbox) *notifier.DeliveryReport {
 	if nm.config == nil || !nm.config.Enabled || len(nm.services) == 0 {
 		log.Println("Notifications are disabled or no services configured")
-		return
+		return nil
 	}
 
 	log.Printf("Sending notifications through %d service(s)", len(nm.services))
 
-	var failedServices []string
-	for i, service := range nm.services {
-		if err := service.Send(title, message); err != nil {
-			serviceName := nm.getServiceName(i)
-			log.Printf("Failed to send notification via %s: %v", serviceName, err)
-			failedServices = append(failedServices, serviceName)
-		} else {
-			serviceName := nm.getServiceName(i)
-			log.Printf("Successfully sent notification via %s", serviceName)
+	timeout := nm.config.Timeout
+	if timeout <= 0 {
+		timeout = default_config.GetDefaultDeliveryTimeout()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	report := &notifier.DeliveryReport{Time: now.Format(time.RFC3339)}
+	if notification != nil {
+		report.Title = notification.Title
+	} else {
+		report.Title = "Retry of pending notifications"
+	}
+
+	// split the outbox into the queues of the services, entries of unknown services are kept as they are
+	queues := make([]notifier.Outbox, len(nm.services))
+	var unknown notifier.Outbox
+	if outbox != nil {
+		*outbox = pruneOutbox(*outbox, nm.config.Outbox, now)
+		for i := range nm.services {
+			queues[i] = outbox.Pending(nm.getServiceName(i))
+		}
+		for _, entry := range *outbox {
+			if !slices.Contains(nm.names, entry.Channel) {
+				unknown = append(unknown, entry)
+			}
 		}
 	}
 
-	if len(failedServices) > 0 {
+	// the progress of the services is shared so that what they delivered is known at the deadline,
+	// the channel is buffered so that services finishing after the deadline do not block
+	finished := make(chan int, len(nm.services))
+	progress := make([]*deliveryProgress, len(nm.services))
+	attempts := make([]*atomic.Int32, len(nm.services))
+	routed := make([]*notifier.Notification, len(nm.services))
+	for i := range nm.services {
+		var serviceCtx context.Context
+		serviceCtx, attempts[i] = channels.WithAttemptCounter(ctx)
+		quietUntil := nm.quietUntil(nm.getServiceName(i), now)
+		routed[i] = notification
+		if notification != nil && nm.route != nil {
+			routed[i] = nm.route(nm.getServiceName(i), notification)
+		}
+		progress[i] = &deliveryProgress{queue: queues[i]}
+		go func(i int) {
+			nm.deliverInOrder(serviceCtx, i, routed[i], progress[i], outbox != nil, quietUntil, attempts[i])
+			finished <- i
+		}(i)
+	}
+
+	results := make([][]notifier.DeliveryResult, len(nm.services))
+	pending := make(map[int]bool, len(nm.services))
+	for i := range nm.services {
+		pending[i] = true
+	}
+	for len(pending) > 0 {
+		select {
+		case i := <-finished:
+			results[i], queues[i], _ = progress[i].snapshot()
+			delete(pending, i)
+		case <-ctx.Done():
+			// entries delivered before the deadline are not queued again, a delivery still running
+			// is considered failed and retried in a later run
+			for i := range pending {
+				var sent bool
+				results[i], queues[i], sent = progress[i].snapshot()
+				result := notifier.DeliveryResult{
+					Channel:   nm.getServiceName(i),
+					Attempts:  max(1, int(attempts[i].Load())),
+					LatencyMs: time.Since(now).Milliseconds(),
+					Error:     fmt.Sprintf("delivery deadline of %ds exceeded", timeout),
+					Retry:     routed[i] == nil || sent,
+				}
+				if routed[i] != nil && !sent && outbox != nil {
+					queues[i] = enqueue(queues[i], result.Channel, routed[i], int(attempts[i].Load()), result.Error, now, nextAttempt(int(attempts[i].Load()), now))
+					result.Queued = true
+				}
+				log.Printf("Failed to send notification via %s: delivery deadline exceeded", result.Channel)
+				results[i] = append(results[i], result)
+				delete(pending, i)
+			}
+		}
+	}
+
+	for i := range nm.services {
+		report.Results = append(report.Results, results[i]...)
+		unknown = append(unknown, queues[i]...)
+	}
+	if outbox != nil {
+		*outbox = unknown
+	}
+
+	if failedServices := report.FailedChannels(); len(failedServices) > 0 {
 		log.Printf("Failed to send notifications via: %s", strings.Join(failedServices, ", "))
 	}
+	return report
+}
+
+// deliveryProgress is the state of the delivery to one service, it is updated as notifications are
+// delivered so that it is still consistent when the delivery deadline expires before the service is done
+type deliveryProgress struct {
+	mu      sync.Mutex
+	results []notifier.DeliveryResult
+	queue   notifier.Outbox
+
+	// done is set once the notification was delivered, queued or muted
+	done bool
+}
+
+// snapshot returns the delivery results so far, the queue of the notifications that were not delivered
+// and whether the notification was delivered, queued or muted
+func (p *deliveryProgress) snapshot() ([]notifier.DeliveryResult, notifier.Outbox, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return slices.Clone(p.results), slices.Clone(p.queue), p.done
+}
+
+// deliverInOrder retries the due notifications in the outbox queue of the service at the given index,
+// then sends the notification unless an earlier one is still pending. The delivery results and the
+// remaining queue, which holds the notification if it was not delivered and queueing is enabled, are
+// recorded in the progress as the notifications are delivered.
+// Nothing is sent while the service is in quiet hours, i.e. quietUntil is not zero, the notification
+// is queued until they end instead.
+func (nm *NotificationManager) deliverInOrder(ctx context.Context, index int, notification *notifier.Notification, progress *deliveryProgress, queueing bool, quietUntil time.Time, attempts *atomic.Int32) {
+	name := nm.getServiceName(index)
+	now := time.Now()
+
+	if !quietUntil.IsZero() {
+		if notification == nil {
+			return
+		}
+		result := notifier.DeliveryResult{Channel: name, Muted: true}
+		log.Printf("Muting %s during quiet hours until %s", result.Channel, quietUntil.Format(time.RFC3339))
+		progress.mu.Lock()
+		defer progress.mu.Unlock()
+		if queueing {
+			progress.queue = enqueue(progress.queue, result.Channel, notification, 0, "", now, quietUntil)
+			result.Queued = true
+		}
+		progress.results = append(progress.results, result)
+		progress.done = true
+		return
+	}
+
+	progress.mu.Lock()
+	queue := slices.Clone(progress.queue)
+	progress.mu.Unlock()
+
+	blocked := false
+	for _, entry := range queue {
+		if blocked || !isDue(entry, now) {
+			blocked = true
+			continue
+		}
+
+		result := nm.deliver(ctx, index, &entry.Notification, attempts)
+		result.Retry = true
+		progress.mu.Lock()
+		i := slices.IndexFunc(progress.queue, func(queued notifier.OutboxEntry) bool { return queued.ID == entry.ID })
+		if result.Error != "" {
+			entry.Attempts += result.Attempts
+			entry.LastError = result.Error
+			entry.NextAttempt = nextAttempt(entry.Attempts, now).Format(time.RFC3339)
+			progress.queue[i] = entry
+			blocked = true
+		} else {
+			progress.queue = slices.Delete(progress.queue, i, i+1)
+		}
+		progress.results = append(progress.results, result)
+		progress.mu.Unlock()
+	}
+
+	if notification == nil {
+		return
+	}
+
+	if blocked {
+		progress.mu.Lock()
+		defer progress.mu.Unlock()
+		log.Printf("Queued notification for %s behind %d pending notification(s)", name, len(progress.queue))
+		progress.results = append(progress.results, notifier.DeliveryResult{
+			Channel: name,
+			Error:   fmt.Sprintf("queued behind %d pending notification(s)", len(progress.queue)),
+			Queued:  true,
+		})
+		progress.queue = enqueue(progress.queue, name, notification, 0, "", now, now)
+		progress.done = true
+		return
+	}
+
+	result := nm.deliver(ctx, index, notification, attempts)
+	progress.mu.Lock()
+	defer progress.mu.Unlock()
+	if result.Error != "" && queueing {
+		progress.queue = enqueue(progress.queue, result.Channel, notification, result.Attempts, result.Error, now, nextAttempt(result.Attempts, now))
+		result.Queued = true
+	}
+	progress.results = append(progress.results, result)
+	progress.done = true
+}
+
+// deliver sends the notification through the service at the given index and records the result
+func (nm *NotificationManager) deliver(ctx context.Context, index int, notification *notifier.Notification, attempts *atomic.Int32) notifier.DeliveryResult {
+	service := nm.services[index]
+	result := notifier.DeliveryResult{Channel: nm.getServiceName(index)}
+
+	_, isEventService := service.(EventNotificationService)
+	if !isEventService && notification.Message == "" {
+		log.Printf("Skipping %s, no plain text message to send", result.Channel)
+		result.Skipped = true
+		return result
+	}
+
+	start := time.Now()
+	previousAttempts := attempts.Load()
+	var err error
+	switch s := service.(type) {
+	case ContextNotificationService:
+		err = s.SendContext(ctx, notification)
+	case EventNotificationService:
+		err = s.SendEvents(notification)
+	default:
+		err = service.Send(notification.Title, notification.Message)
+	}
+	result.LatencyMs = time.Since(start).Milliseconds()
+	result.Attempts = max(1, int(attempts.Load()-previousAttempts))
+
+	if err != nil {
+		// the URL of a failed request is removed as the report is published with the status page
+		err = common.RedactURLError(err)
+		log.Printf("Failed to send notification via %s: %v", result.Channel, err)
+		result.Error = err.Error()
+	} else {
+		log.Printf("Successfully sent notification via %s", result.Channel)
+	}
+	return result
 }
 
 // getServiceName returns the name of the service at the given index
 func (nm *NotificationManager) getServiceName(index int) string {
-	if index < len(nm.config.Methods) {
-		return nm.config.Methods[index]
+	if index < len(nm.names) {
+		return nm.names[index]
 	}
 	return fmt.Sprintf("service_%d", index)
 }
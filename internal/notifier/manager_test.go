@@ -0,0 +1,249 @@
+package notifier
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/schema"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/event_type"
+	"gopkg.in/yaml.v3"
+)
+
+func TestNewNotificationManager_ServiceNames(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *configure.NotificationConfig
+		expected []string
+	}{
+		{
+			name: "Skipped method does not shift names",
+			config: &configure.NotificationConfig{
+				Enabled:        true,
+				Methods:        []string{"email", "webhook"},
+				ChannelConfigs: configure.ChannelConfigs{Webhook: &configure.WebhookConfig{URL: "https://example.com"}},
+			},
+			expected: []string{"webhook"},
+		},
+		{
+			name: "Additional emails",
+			config: &configure.NotificationConfig{
+				Enabled:        true,
+				Methods:        []string{"email"},
+				ChannelConfigs: configure.ChannelConfigs{Email: &configure.EmailConfig{}},
+				Emails:         []*configure.EmailConfig{{}, {}},
+			},
+			expected: []string{"email", "emails[0]", "emails[1]"},
+		},
+		{
+			name: "Named channels",
+			config: &configure.NotificationConfig{
+				Enabled: true,
+				Methods: []string{"webhook"},
+				ChannelConfigs: configure.ChannelConfigs{
+					Webhook: &configure.WebhookConfig{URL: "https://example.com"},
+				},
+				Channels: []*configure.ChannelConfig{
+					{Name: "ops", Type: "webhook", ChannelConfigs: configure.ChannelConfigs{Webhook: &configure.WebhookConfig{}}},
+					{Name: "missing-settings", Type: "telegram"},
+					{Name: "unknown-type", Type: "carrier-pigeon"},
+					{Type: "ntfy", ChannelConfigs: configure.ChannelConfigs{Ntfy: &configure.NtfyConfig{}}},
+				},
+			},
+			expected: []string{"webhook", "ops", "channels[3]"},
+		},
+		{
+			name: "Channels only",
+			config: &configure.NotificationConfig{
+				Enabled: true,
+				Channels: []*configure.ChannelConfig{
+					{Name: "alerts", Type: "default"},
+				},
+			},
+			expected: []string{"alerts"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := NewNotificationManager(tt.config)
+
+			var names []string
+			for i := range manager.services {
+				names = append(names, manager.getServiceName(i))
+			}
+			if !reflect.DeepEqual(names, tt.expected) {
+				t.Errorf("Expected services %v, got %v", tt.expected, names)
+			}
+		})
+	}
+}
+
+func TestNotificationManager_NamedWebhooks(t *testing.T) {
+	var mu sync.Mutex
+	received := make(map[string]string)
+	newServer := func(name string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			mu.Lock()
+			received[name] = string(body)
+			mu.Unlock()
+			w.WriteHeader(http.StatusOK)
+		}))
+	}
+	opsServer := newServer("ops")
+	defer opsServer.Close()
+	productServer := newServer("product")
+	defer productServer.Close()
+
+	var config configure.NotificationConfig
+	err := yaml.Unmarshal([]byte(`
+enabled: true
+channels:
+  - name: ops
+    type: webhook
+    webhook:
+      url: "`+opsServer.URL+`"
+  - name: product
+    type: webhook
+    webhook:
+      url: "`+productServer.URL+`"
+`), &config)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+
+	manager := NewNotificationManager(&config)
+	if !manager.IsEnabled() {
+		t.Fatal("Expected the manager to be enabled")
+	}
+	manager.SendNotification(&notifier.Notification{Title: "Alert", Message: "Something is down"})
+
+	for _, name := range []string{"ops", "product"} {
+		if !strings.Contains(received[name], "Something is down") {
+			t.Errorf("Expected webhook %s to receive the notification, got %q", name, received[name])
+		}
+	}
+}
+
+func TestNotificationConfig_InlineChannelSettings(t *testing.T) {
+	var config configure.NotificationConfig
+	err := yaml.Unmarshal([]byte(`
+enabled: true
+methods: ["webhook"]
+webhook:
+  url: "https://example.com/hook"
+`), &config)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+
+	if config.Webhook == nil || config.Webhook.URL != "https://example.com/hook" {
+		t.Errorf("Expected top-level webhook settings to be parsed, got %+v", config.Webhook)
+	}
+}
+
+func TestNotificationManager_DeliveryReport(t *testing.T) {
+	slowHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(500 * time.Millisecond):
+		case <-r.Context().Done():
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	slowServer := httptest.NewServer(slowHandler)
+	defer slowServer.Close()
+	otherServer := httptest.NewServer(slowHandler)
+	defer otherServer.Close()
+	release := make(chan struct{})
+	hangingServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer hangingServer.Close()
+	defer close(release)
+
+	manager := NewNotificationManager(&configure.NotificationConfig{
+		Enabled: true,
+		Timeout: 2,
+		Channels: []*configure.ChannelConfig{
+			{Name: "slow", Type: "ntfy", ChannelConfigs: configure.ChannelConfigs{Ntfy: &configure.NtfyConfig{ServerURL: slowServer.URL, Topic: "ops"}}},
+			{Name: "other", Type: "ntfy", ChannelConfigs: configure.ChannelConfigs{Ntfy: &configure.NtfyConfig{ServerURL: otherServer.URL, Topic: "ops"}}},
+			{Name: "hanging", Type: "ntfy", ChannelConfigs: configure.ChannelConfigs{Ntfy: &configure.NtfyConfig{ServerURL: hangingServer.URL, Topic: "ops", Timeout: 30}}},
+			{Name: "chat", Type: "telegram", ChannelConfigs: configure.ChannelConfigs{Telegram: &configure.TelegramConfig{}}},
+		},
+	})
+
+	start := time.Now()
+	report := manager.SendNotification(&notifier.Notification{
+		Title:  "Recovered",
+		Events: []notifier.Event{{Type: event_type.RECOVERY, ServiceName: "api", URL: "https://api.example.com"}},
+	})
+	if elapsed := time.Since(start); elapsed > 4*time.Second {
+		t.Errorf("Expected the delivery to stop at the deadline, took %v", elapsed)
+	}
+
+	if report == nil || len(report.Results) != 4 {
+		t.Fatalf("Expected a result for every channel, got %+v", report)
+	}
+	if report.Title != "Recovered" || report.Time == "" {
+		t.Errorf("Unexpected report header: %+v", report)
+	}
+	for _, result := range report.Results[:2] {
+		if result.Error != "" || result.Attempts != 1 || result.LatencyMs < 400 {
+			t.Errorf("Expected %s to succeed after one attempt, got %+v", result.Channel, result)
+		}
+	}
+	if hanging := report.Results[2]; hanging.Channel != "hanging" || hanging.Error == "" {
+		t.Errorf("Expected the hanging channel to fail at the deadline, got %+v", hanging)
+	}
+	if chat := report.Results[3]; !chat.Skipped || chat.Error != "" {
+		t.Errorf("Expected the plain text channel to be skipped without a message, got %+v", chat)
+	}
+	if failed := report.FailedChannels(); !reflect.DeepEqual(failed, []string{"hanging"}) {
+		t.Errorf("Expected only the hanging channel to fail, got %v", failed)
+	}
+}
+
+func TestNotificationManager_RedactsURLs(t *testing.T) {
+	closedServer := httptest.NewServer(http.NotFoundHandler())
+	closedServer.Close()
+
+	manager := NewNotificationManager(&configure.NotificationConfig{
+		Enabled: true,
+		Timeout: 1,
+		Channels: []*configure.ChannelConfig{
+			{Name: "push", Type: "ntfy", ChannelConfigs: configure.ChannelConfigs{Ntfy: &configure.NtfyConfig{ServerURL: closedServer.URL, Topic: "secret-topic-token"}}},
+		},
+	})
+
+	report := manager.SendNotification(&notifier.Notification{Title: "Down", Message: "api is down"})
+	if report == nil || len(report.Results) != 1 || report.Results[0].Error == "" {
+		t.Fatalf("Expected the delivery to fail, got %+v", report)
+	}
+	if message := report.Results[0].Error; strings.Contains(message, "secret-topic-token") {
+		t.Errorf("Expected the URL to be removed from the error, got %q", message)
+	}
+}
+
+func TestSchemaChannelTypes(t *testing.T) {
+	definitions := schema.Generate().Definitions
+	enums := [][]string{
+		definitions["NotificationConfig"].Properties["methods"].Items.Enum,
+		definitions["ChannelConfig"].Properties["type"].Enum,
+	}
+	for _, enum := range enums {
+		for _, channelType := range enum {
+			if _, err := newService(channelType, &configure.ChannelConfigs{}); errors.Is(err, errUnknownType) {
+				t.Errorf("Expected channel type %q of the schema to be supported", channelType)
+			}
+		}
+	}
+}
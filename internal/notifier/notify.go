@@ -1,16 +1,22 @@
 package notifier
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
 	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/structures/logger"
+	"github.com/wcy-dt/ponghub/internal/types/structures/notifier"
 	"github.com/wcy-dt/ponghub/internal/types/types/chk_result"
 	"github.com/wcy-dt/ponghub/internal/types/types/default_config"
+	"github.com/wcy-dt/ponghub/internal/types/types/event_type"
 )
 
 // NotificationService defines the interface for notification services
@@ -18,6 +24,18 @@ type NotificationService interface {
 	Send(title, message string) error
 }
 
+// EventNotificationService defines the interface for notification services that act on
+// individual endpoint events, e.g. to trigger and resolve incidents
+type EventNotificationService interface {
+	SendEvents(notification *notifier.Notification) error
+}
+
+// ContextNotificationService defines the interface for notification services that can be
+// cancelled, e.g. when the delivery deadline expires
+type ContextNotificationService interface {
+	SendContext(ctx context.Context, notification *notifier.Notification) error
+}
+
 // WriteNotifications sends notifications based on the service check results
 func WriteNotifications(checkResult []checker.Service, certNotifyDays int) {
 	statusNoneEndpoints := collectUnavailableEndpoints(checkResult)
@@ -47,29 +65,139 @@ func WriteNotifications(checkResult []checker.Service, certNotifyDays int) {
 	writeNotificationReport(f, statusNoneEndpoints, certProblemEndpoints)
 }
 
-// SendNotifications sends notifications through various channels using the notification manager
-func SendNotifications(checkResult []checker.Service, certNotifyDays int, notificationConfig *configure.NotificationConfig) {
+// SendNotifications sends notifications through various channels using the notification manager.
+// previousLog must hold the log of the previous run so that recovered endpoints can be detected.
+// Notifications that could not be delivered are queued in the outbox and retried in later runs.
+// If an escalation policy is configured, outages are tracked as incidents across runs.
+// reportWritten tells whether the report of this run was generated, so that it can be attached.
+// It returns the delivery report, or nil if nothing was sent.
+func SendNotifications(checkResult []checker.Service, certNotifyDays int, notificationConfig *configure.NotificationConfig, previousLog logger.Logger, reportWritten bool) *notifier.DeliveryReport {
 	statusNoneEndpoints := collectUnavailableEndpoints(checkResult)
 	certProblemEndpoints := collectCertProblemEndpoints(checkResult, certNotifyDays)
 
-	if len(statusNoneEndpoints) == 0 && len(certProblemEndpoints) == 0 {
+	recoveredEndpoints := collectRecoveredEndpoints(checkResult, previousLog)
+
+	// Track the endpoints notified about their certificates, so that their alerts are resolved once renewed
+	certAlertsPath := default_config.GetCertAlertsPath()
+	certAlerts, err := ReadCertAlerts(certAlertsPath)
+	if err != nil {
+		log.Println("Error loading certificate alerts, renewed certificates will not be notified:", err)
+	}
+	renewedEndpoints, openCertAlerts := collectRenewedEndpoints(checkResult, certNotifyDays, certAlerts)
+	hasIssues := len(statusNoneEndpoints) > 0 || len(certProblemEndpoints) > 0 || len(recoveredEndpoints) > 0 || len(renewedEndpoints) > 0
+
+	// Track the incidents of the escalation policy, they are resolved once the notifications are sent
+	var esc *escalation
+	if notificationConfig != nil && notificationConfig.Enabled && notificationConfig.Escalation != nil {
+		incidentsPath := default_config.GetIncidentsPath()
+		incidents, err := ReadIncidents(incidentsPath)
+		if err != nil {
+			log.Println("Error loading incidents, escalation starts over:", err)
+			incidents = notifier.Incidents{}
+		}
+		ackPath := notificationConfig.Escalation.AckFile
+		if ackPath == "" {
+			ackPath = default_config.GetAckPath()
+		}
+
+		esc = newEscalation(notificationConfig.Escalation, incidents, time.Now())
+		esc.open(statusNoneEndpoints)
+		esc.acknowledge(readAcks(ackPath))
+		defer func() {
+			esc.close(checkResult)
+			if err := WriteIncidents(esc.incidents, incidentsPath); err != nil {
+				log.Println("Error writing incidents:", err)
+			}
+		}()
+	}
+
+	// Load the notifications queued in previous runs
+	outboxPath := default_config.GetOutboxPath()
+	var outbox *notifier.Outbox
+	if notificationConfig == nil || notificationConfig.Outbox == nil || !notificationConfig.Outbox.Disabled {
+		pending, err := ReadOutbox(outboxPath)
+		if err != nil {
+			log.Println("Error loading notification outbox, queued notifications will not be retried:", err)
+		} else {
+			outbox = &pending
+		}
+	}
+
+	if !hasIssues && (outbox == nil || len(*outbox) == 0) {
 		log.Println("No service issues found, skipping notifications")
-		return
+		return nil
 	}
 
 	// Create notification manager
 	manager := NewNotificationManager(notificationConfig)
 	if !manager.IsEnabled() {
 		log.Println("Notification manager is not enabled or no services configured")
-		return
+		return nil
+	}
+	if !slices.Equal(certAlerts, openCertAlerts) {
+		if err := WriteCertAlerts(openCertAlerts, certAlertsPath); err != nil {
+			log.Println("Error writing certificate alerts:", err)
+		}
 	}
 
-	// Generate notification content
-	title := "🚨 PongHub Service Status Alert"
-	message := generateNotificationMessage(statusNoneEndpoints, certProblemEndpoints)
+	// Generate notification content, plain text channels are only notified about issues
+	var notification *notifier.Notification
+	if hasIssues {
+		notification = buildNotification(statusNoneEndpoints, certProblemEndpoints, recoveredEndpoints, renewedEndpoints, notificationConfig)
+		notification.ReportMissing = !reportWritten
+		filters := channelFilters(notificationConfig)
+		if esc != nil || len(filters) > 0 {
+			services := servicesByName(checkResult)
+			manager.SetRouter(func(name string, _ *notifier.Notification) *notifier.Notification {
+				outages, certProblems, recoveries, renewals := statusNoneEndpoints, certProblemEndpoints, recoveredEndpoints, renewedEndpoints
+
+				// restrict the channel to its groups and tags before escalating, so that only incidents
+				// the channel is notified about are marked as escalated to it
+				if channel := filters[strings.ToLower(name)]; channel != nil {
+					outages = filterByChannel(channel, services, outages)
+					certProblems = filterByChannel(channel, services, certProblems)
+					recoveries = filterByChannel(channel, services, recoveries)
+					renewals = filterByChannel(channel, services, renewals)
+				}
+				if esc != nil {
+					outages, certProblems, recoveries, renewals = esc.route(name, outages, certProblems, recoveries, renewals)
+				}
+				if len(outages) == 0 && len(certProblems) == 0 && len(recoveries) == 0 && len(renewals) == 0 {
+					log.Printf("No events to send to %s", name)
+					return nil
+				}
+				return buildNotification(outages, certProblems, recoveries, renewals, notificationConfig)
+			})
+		}
+	} else {
+		log.Printf("No service issues found, retrying %d queued notification(s)", len(*outbox))
+	}
 
 	// Send notifications
-	manager.SendNotification(title, message)
+	report := manager.SendWithOutbox(notification, outbox)
+	if outbox != nil {
+		if err := WriteOutbox(*outbox, outboxPath); err != nil {
+			log.Println("Error writing notification outbox:", err)
+		}
+	}
+	return report
+}
+
+// buildNotification creates the notification about the given endpoints, with a plain text
+// message only if there are unavailable endpoints or certificate issues
+func buildNotification(statusNoneEndpoints, certProblemEndpoints, recoveredEndpoints, renewedEndpoints map[string][]checker.Endpoint, notificationConfig *configure.NotificationConfig) *notifier.Notification {
+	notification := &notifier.Notification{
+		Title:  "✅ PongHub Service Recovery",
+		Events: buildEvents(statusNoneEndpoints, certProblemEndpoints, recoveredEndpoints, renewedEndpoints),
+	}
+	if notificationConfig != nil {
+		notification.StatusPageURL = notificationConfig.StatusPageURL
+	}
+	if len(statusNoneEndpoints) > 0 || len(certProblemEndpoints) > 0 {
+		notification.Title = "🚨 PongHub Service Status Alert"
+		notification.Message = generateNotificationMessage(statusNoneEndpoints, certProblemEndpoints)
+	}
+	return notification
 }
 
 // generateNotificationMessage creates a formatted message for notifications
@@ -132,7 +260,8 @@ func generateNotificationMessage(statusNoneEndpoints, certProblemEndpoints map[s
 	return message.String()
 }
 
-// collectUnavailableEndpoints finds all endpoints with status NONE
+// collectUnavailableEndpoints finds all endpoints with status NONE, endpoints impacted by a service
+// they depend on are left out so that only the service at the root is alerted on
 func collectUnavailableEndpoints(checkResult []checker.Service) map[string][]checker.Endpoint {
 	statusNoneEndpoints := make(map[string][]checker.Endpoint)
 	for _, serviceResult := range checkResult {
@@ -149,6 +278,9 @@ func collectUnavailableEndpoints(checkResult []checker.Service) map[string][]che
 func collectCertProblemEndpoints(checkResult []checker.Service, certNotifyDays int) map[string][]checker.Endpoint {
 	certProblemEndpoints := make(map[string][]checker.Endpoint)
 	for _, serviceResult := range checkResult {
+		if serviceResult.Status == chk_result.MAINTENANCE {
+			continue
+		}
 		for _, endpointResult := range serviceResult.Endpoints {
 			if endpointResult.IsHTTPS && (endpointResult.IsCertExpired || endpointResult.CertRemainingDays <= certNotifyDays) {
 				certProblemEndpoints[serviceResult.Name] = append(certProblemEndpoints[serviceResult.Name], endpointResult)
@@ -158,6 +290,112 @@ func collectCertProblemEndpoints(checkResult []checker.Service, certNotifyDays i
 	return certProblemEndpoints
 }
 
+// collectRecoveredEndpoints finds all endpoints that were unavailable in the last run before the current one
+// that was not under maintenance, but are available now. Endpoints impacted by a service they depend on
+// have not recovered yet.
+func collectRecoveredEndpoints(checkResult []checker.Service, previousLog logger.Logger) map[string][]checker.Endpoint {
+	recoveredEndpoints := make(map[string][]checker.Endpoint)
+	for _, serviceResult := range checkResult {
+		serviceLog, exists := previousLog[serviceResult.Name]
+		if !exists {
+			continue
+		}
+		for _, endpointResult := range serviceResult.Endpoints {
+			switch endpointResult.Status {
+			case chk_result.NONE, chk_result.MAINTENANCE, chk_result.IMPACTED:
+				continue
+			}
+			if lastCheckedStatus(serviceLog.Endpoints[endpointResult.URL]) == chk_result.NONE {
+				recoveredEndpoints[serviceResult.Name] = append(recoveredEndpoints[serviceResult.Name], endpointResult)
+			}
+		}
+	}
+	return recoveredEndpoints
+}
+
+// lastCheckedStatus returns the latest status of the history that was not logged during maintenance
+// or while the endpoint was impacted by a service it depends on, so that an endpoint that failed
+// before it was impacted is reported as recovered
+func lastCheckedStatus(history logger.History) chk_result.CheckResult {
+	for i := len(history) - 1; i >= 0; i-- {
+		switch status := chk_result.ParseCheckResult(history[i].Status); status {
+		case chk_result.MAINTENANCE, chk_result.IMPACTED:
+		default:
+			return status
+		}
+	}
+	return chk_result.UNKNOWN
+}
+
+// buildEvents converts the collected endpoints into notification events ordered by service name
+func buildEvents(statusNoneEndpoints, certProblemEndpoints, recoveredEndpoints, renewedEndpoints map[string][]checker.Endpoint) []notifier.Event {
+	var events []notifier.Event
+
+	for _, serviceName := range sortedServiceNames(statusNoneEndpoints) {
+		for _, endpoint := range statusNoneEndpoints[serviceName] {
+			event := newEvent(event_type.OUTAGE, serviceName, endpoint)
+			if len(endpoint.FailureDetails) > 0 {
+				event.Error = endpoint.FailureDetails[len(endpoint.FailureDetails)-1]
+			}
+			events = append(events, event)
+		}
+	}
+
+	for _, serviceName := range sortedServiceNames(recoveredEndpoints) {
+		for _, endpoint := range recoveredEndpoints[serviceName] {
+			events = append(events, newEvent(event_type.RECOVERY, serviceName, endpoint))
+		}
+	}
+
+	for _, serviceName := range sortedServiceNames(certProblemEndpoints) {
+		for _, endpoint := range certProblemEndpoints[serviceName] {
+			eventType := event_type.CERT_WARNING
+			if endpoint.IsCertExpired {
+				eventType = event_type.CERT_EXPIRED
+			}
+			event := newEvent(eventType, serviceName, endpoint)
+			event.CertRemainingDays = endpoint.CertRemainingDays
+			events = append(events, event)
+		}
+	}
+
+	for _, serviceName := range sortedServiceNames(renewedEndpoints) {
+		for _, endpoint := range renewedEndpoints[serviceName] {
+			event := newEvent(event_type.CERT_RENEWED, serviceName, endpoint)
+			event.CertRemainingDays = endpoint.CertRemainingDays
+			events = append(events, event)
+		}
+	}
+
+	return events
+}
+
+// newEvent creates a notification event for the given endpoint result
+func newEvent(eventType event_type.EventType, serviceName string, endpoint checker.Endpoint) notifier.Event {
+	eventTime := endpoint.StartTime
+	if eventTime == "" {
+		eventTime = time.Now().Format(time.RFC3339)
+	}
+	return notifier.Event{
+		Type:        eventType,
+		ServiceName: serviceName,
+		URL:         endpoint.URL,
+		Method:      endpoint.Method,
+		StatusCode:  endpoint.StatusCode,
+		Time:        eventTime,
+	}
+}
+
+// sortedServiceNames returns the service names of the map in alphabetical order
+func sortedServiceNames(endpointsMap map[string][]checker.Endpoint) []string {
+	names := make([]string, 0, len(endpointsMap))
+	for name := range endpointsMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // removeExistingNotifyFile removes the existing notify file if it exists
 func removeExistingNotifyFile(notifyPath string) error {
 	if err := os.Remove(notifyPath); err != nil && !os.IsNotExist(err) {
@@ -8,7 +8,9 @@ import (
 	"time"
 
 	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	"github.com/wcy-dt/ponghub/internal/types/structures/logger"
 	"github.com/wcy-dt/ponghub/internal/types/types/chk_result"
+	"github.com/wcy-dt/ponghub/internal/types/types/event_type"
 )
 
 //goland:noinspection HttpUrlsUsage,HttpUrlsUsage,HttpUrlsUsage,HttpUrlsUsage,HttpUrlsUsage
@@ -471,3 +473,135 @@ func TestWriteResponseBody(t *testing.T) {
 		t.Error("Expected no content for empty response body")
 	}
 }
+
+//goland:noinspection HttpUrlsUsage
+func TestCollectRecoveredEndpoints(t *testing.T) {
+	previousLog := logger.Logger{
+		"Service1": {
+			Endpoints: logger.Endpoints{
+				"http://recovered.com":  {{Time: "2025-01-01T10:00:00Z", Status: "none"}},
+				"http://still-down.com": {{Time: "2025-01-01T10:00:00Z", Status: "none"}},
+				"http://always-up.com":  {{Time: "2025-01-01T10:00:00Z", Status: "all"}},
+				"http://impacted.com":   {{Time: "2025-01-01T10:00:00Z", Status: "none"}},
+				"http://was-impacted.com": {
+					{Time: "2025-01-01T10:00:00Z", Status: "none"},
+					{Time: "2025-01-01T10:05:00Z", Status: "impacted"},
+				},
+			},
+		},
+	}
+
+	checkResult := []checker.Service{
+		{
+			Name: "Service1",
+			Endpoints: []checker.Endpoint{
+				{URL: "http://recovered.com", Status: chk_result.PART},
+				{URL: "http://still-down.com", Status: chk_result.NONE},
+				{URL: "http://always-up.com", Status: chk_result.ALL},
+				{URL: "http://new.com", Status: chk_result.ALL},
+				{URL: "http://impacted.com", Status: chk_result.IMPACTED},
+				{URL: "http://was-impacted.com", Status: chk_result.ALL},
+			},
+		},
+		{
+			Name:      "NewService",
+			Endpoints: []checker.Endpoint{{URL: "http://new.com", Status: chk_result.ALL}},
+		},
+	}
+
+	result := collectRecoveredEndpoints(checkResult, previousLog)
+
+	if len(result) != 1 || len(result["Service1"]) != 2 {
+		t.Fatalf("Expected 2 recovered endpoints, got %v", result)
+	}
+	if result["Service1"][0].URL != "http://recovered.com" {
+		t.Errorf("Expected URL http://recovered.com, got %s", result["Service1"][0].URL)
+	}
+	// an endpoint that failed before it was impacted recovers once it is available again
+	if result["Service1"][1].URL != "http://was-impacted.com" {
+		t.Errorf("Expected URL http://was-impacted.com, got %s", result["Service1"][1].URL)
+	}
+}
+
+//goland:noinspection HttpUrlsUsage
+func TestCollectEndpoints_Maintenance(t *testing.T) {
+	previousLog := logger.Logger{
+		"Service1": {
+			Endpoints: logger.Endpoints{
+				"http://after-maintenance.com": {
+					{Time: "2025-01-01T10:00:00Z", Status: "none"},
+					{Time: "2025-01-01T10:30:00Z", Status: "maintenance"},
+				},
+				"http://in-maintenance.com": {{Time: "2025-01-01T10:00:00Z", Status: "none"}},
+			},
+		},
+	}
+
+	checkResult := []checker.Service{
+		{
+			Name:   "Service1",
+			Status: chk_result.PART,
+			Endpoints: []checker.Endpoint{
+				{URL: "http://after-maintenance.com", Status: chk_result.ALL},
+				{URL: "http://in-maintenance.com", Status: chk_result.MAINTENANCE},
+			},
+		},
+		{
+			Name:      "Service2",
+			Status:    chk_result.MAINTENANCE,
+			Endpoints: []checker.Endpoint{{URL: "https://expired.com", Status: chk_result.MAINTENANCE, IsHTTPS: true, IsCertExpired: true}},
+		},
+	}
+
+	recovered := collectRecoveredEndpoints(checkResult, previousLog)
+	if len(recovered["Service1"]) != 1 || recovered["Service1"][0].URL != "http://after-maintenance.com" {
+		t.Errorf("Expected the outage before the maintenance to be recovered, got %v", recovered)
+	}
+	if unavailable := collectUnavailableEndpoints(checkResult); len(unavailable) != 0 {
+		t.Errorf("Expected no unavailable endpoints during maintenance, got %v", unavailable)
+	}
+	if certProblems := collectCertProblemEndpoints(checkResult, 7); len(certProblems) != 0 {
+		t.Errorf("Expected no certificate alerts during maintenance, got %v", certProblems)
+	}
+}
+
+//goland:noinspection HttpUrlsUsage
+func TestBuildEvents(t *testing.T) {
+	statusNoneEndpoints := map[string][]checker.Endpoint{
+		"B": {{URL: "http://b.com", FailureDetails: []string{"first", "last"}}},
+		"A": {{URL: "http://a.com"}},
+	}
+	certProblemEndpoints := map[string][]checker.Endpoint{
+		"A": {{URL: "https://a.com", IsHTTPS: true, IsCertExpired: true}},
+	}
+	recoveredEndpoints := map[string][]checker.Endpoint{
+		"C": {{URL: "http://c.com"}},
+	}
+	renewedEndpoints := map[string][]checker.Endpoint{
+		"D": {{URL: "https://d.com", IsHTTPS: true, CertRemainingDays: 90}},
+	}
+
+	events := buildEvents(statusNoneEndpoints, certProblemEndpoints, recoveredEndpoints, renewedEndpoints)
+
+	expected := []struct {
+		eventType   event_type.EventType
+		serviceName string
+	}{
+		{event_type.OUTAGE, "A"},
+		{event_type.OUTAGE, "B"},
+		{event_type.RECOVERY, "C"},
+		{event_type.CERT_EXPIRED, "A"},
+		{event_type.CERT_RENEWED, "D"},
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %d events, got %d", len(expected), len(events))
+	}
+	for i, e := range expected {
+		if events[i].Type != e.eventType || events[i].ServiceName != e.serviceName {
+			t.Errorf("Event %d: expected %s/%s, got %s/%s", i, e.eventType, e.serviceName, events[i].Type, events[i].ServiceName)
+		}
+	}
+	if events[1].Error != "last" {
+		t.Errorf("Expected last failure detail as error, got %q", events[1].Error)
+	}
+}
@@ -0,0 +1,121 @@
+package notifier
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/default_config"
+)
+
+const (
+	// outboxBaseBackoff is the delay before the first retry of a queued notification
+	outboxBaseBackoff = 5 * time.Minute
+
+	// outboxMaxBackoff is the maximum delay between two retries of a queued notification
+	outboxMaxBackoff = 4 * time.Hour
+)
+
+// ReadOutbox loads the pending notifications from file or returns an empty outbox
+func ReadOutbox(path string) (notifier.Outbox, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return notifier.Outbox{}, nil
+		}
+		return nil, err
+	}
+
+	var outbox notifier.Outbox
+	if err := json.Unmarshal(content, &outbox); err != nil {
+		return nil, err
+	}
+	return outbox, nil
+}
+
+// WriteOutbox writes the pending notifications to file
+func WriteOutbox(outbox notifier.Outbox, path string) error {
+	if outbox == nil {
+		outbox = notifier.Outbox{}
+	}
+	content, err := json.MarshalIndent(outbox, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0644)
+}
+
+// pruneOutbox drops the notifications that are older than the maximum age or have used up their attempts
+func pruneOutbox(outbox notifier.Outbox, config *configure.OutboxConfig, now time.Time) notifier.Outbox {
+	maxAge := default_config.GetDefaultOutboxMaxAge()
+	maxAttempts := default_config.GetDefaultOutboxMaxAttempts()
+	if config != nil {
+		if config.MaxAge > 0 {
+			maxAge = config.MaxAge
+		}
+		if config.MaxAttempts > 0 {
+			maxAttempts = config.MaxAttempts
+		}
+	}
+
+	var kept notifier.Outbox
+	for _, entry := range outbox {
+		created, err := time.Parse(time.RFC3339, entry.Created)
+		switch {
+		case err != nil:
+			log.Printf("Dropping queued notification %s for %s, invalid creation time: %v", entry.ID, entry.Channel, err)
+		case now.Sub(created) > time.Duration(maxAge)*time.Hour:
+			log.Printf("Dropping queued notification %s for %s, expired after %dh: %s", entry.ID, entry.Channel, maxAge, entry.LastError)
+		case entry.Attempts >= maxAttempts:
+			log.Printf("Dropping queued notification %s for %s after %d attempts: %s", entry.ID, entry.Channel, entry.Attempts, entry.LastError)
+		default:
+			kept = append(kept, entry)
+		}
+	}
+	return kept
+}
+
+// enqueue appends the notification to the outbox queue of a channel to be retried at next, unless
+// the last queued notification has the same content. attempts is the number of attempts already made.
+func enqueue(queue notifier.Outbox, channel string, notification *notifier.Notification, attempts int, lastError string, now, next time.Time) notifier.Outbox {
+	fingerprint := notification.Fingerprint()
+	if len(queue) > 0 && queue[len(queue)-1].Fingerprint == fingerprint {
+		log.Printf("Notification for %s is already queued", channel)
+		return queue
+	}
+
+	log.Printf("Queueing notification for %s to retry in a later run", channel)
+	return append(queue, notifier.OutboxEntry{
+		ID:           uuid.NewString(),
+		Channel:      channel,
+		Fingerprint:  fingerprint,
+		Notification: *notification,
+		Created:      now.Format(time.RFC3339),
+		Attempts:     attempts,
+		NextAttempt:  next.Format(time.RFC3339),
+		LastError:    lastError,
+	})
+}
+
+// nextAttempt returns the time of the next retry after the given number of attempts,
+// doubling the delay with every attempt
+func nextAttempt(attempts int, now time.Time) time.Time {
+	if attempts <= 0 {
+		return now
+	}
+	backoff := outboxMaxBackoff
+	if attempts <= 16 {
+		backoff = min(outboxBaseBackoff<<(attempts-1), outboxMaxBackoff)
+	}
+	return now.Add(backoff)
+}
+
+// isDue checks if the queued notification should be retried now
+func isDue(entry notifier.OutboxEntry, now time.Time) bool {
+	next, err := time.Parse(time.RFC3339, entry.NextAttempt)
+	return err != nil || !next.After(now)
+}
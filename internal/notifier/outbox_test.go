@@ -0,0 +1,210 @@
+package notifier
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/event_type"
+)
+
+// newTestNotification creates a notification with a single event of the given type
+func newTestNotification(eventType event_type.EventType) *notifier.Notification {
+	return &notifier.Notification{
+		Title:  string(eventType),
+		Events: []notifier.Event{{Type: eventType, ServiceName: "api", URL: "https://api.example.com", Time: time.Now().Format(time.RFC3339)}},
+	}
+}
+
+func TestNotificationManager_SendWithOutbox(t *testing.T) {
+	var failing atomic.Bool
+	var mu sync.Mutex
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var payload map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		mu.Lock()
+		received = append(received, payload["title"].(string))
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	manager := NewNotificationManager(&configure.NotificationConfig{
+		Enabled: true,
+		Channels: []*configure.ChannelConfig{
+			{Name: "phone", Type: "ntfy", ChannelConfigs: configure.ChannelConfigs{Ntfy: &configure.NtfyConfig{ServerURL: server.URL, Topic: "ops"}}},
+		},
+	})
+	outbox := notifier.Outbox{}
+
+	// the outage cannot be delivered and is queued
+	failing.Store(true)
+	report := manager.SendWithOutbox(newTestNotification(event_type.OUTAGE), &outbox)
+	if len(outbox) != 1 || outbox[0].Channel != "phone" || outbox[0].Attempts != 1 {
+		t.Fatalf("Expected the outage to be queued after one attempt, got %+v", outbox)
+	}
+	if result := report.Results[0]; !result.Queued || result.Error == "" {
+		t.Errorf("Expected the result to be marked as queued, got %+v", result)
+	}
+	if isDue(outbox[0], time.Now()) {
+		t.Error("Expected the retry to be delayed by the backoff")
+	}
+
+	// the recovery is queued behind the pending outage without being sent
+	failing.Store(false)
+	manager.SendWithOutbox(newTestNotification(event_type.RECOVERY), &outbox)
+	if len(outbox) != 2 || len(received) != 0 {
+		t.Fatalf("Expected the recovery to be queued behind the outage, got %d queued, %v received", len(outbox), received)
+	}
+
+	// once due, the queued notifications are delivered in order
+	for i := range outbox {
+		outbox[i].NextAttempt = time.Now().Add(-time.Minute).Format(time.RFC3339)
+	}
+	report = manager.SendWithOutbox(nil, &outbox)
+	if len(outbox) != 0 {
+		t.Errorf("Expected the outbox to be empty, got %+v", outbox)
+	}
+	if len(received) != 2 || received[0] != "outage" || received[1] != "recovery" {
+		t.Errorf("Expected outage and recovery in order, got %v", received)
+	}
+	for _, result := range report.Results {
+		if !result.Retry || result.Error != "" {
+			t.Errorf("Expected successful retries, got %+v", result)
+		}
+	}
+}
+
+func TestNotificationManager_SendWithOutbox_Deadline(t *testing.T) {
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		title, _ := payload["title"].(string)
+		if title == "hanging" {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+			return
+		}
+		mu.Lock()
+		received = append(received, title)
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	defer close(release)
+
+	manager := NewNotificationManager(&configure.NotificationConfig{
+		Enabled: true,
+		Timeout: 1,
+		Channels: []*configure.ChannelConfig{
+			{Name: "phone", Type: "ntfy", ChannelConfigs: configure.ChannelConfigs{Ntfy: &configure.NtfyConfig{ServerURL: server.URL, Topic: "ops", Timeout: 30}}},
+		},
+	})
+	now := time.Now()
+	outbox := notifier.Outbox{}
+	outbox = enqueue(outbox, "phone", &notifier.Notification{Title: "delivered", Message: "first"}, 1, "down", now, now)
+	outbox = enqueue(outbox, "phone", &notifier.Notification{Title: "hanging", Message: "second"}, 1, "down", now, now)
+
+	// the first entry is delivered before the deadline and must not be retried again, the hanging
+	// entry and the new notification behind it are kept in order
+	report := manager.SendWithOutbox(newTestNotification(event_type.OUTAGE), &outbox)
+	if len(received) != 1 || received[0] != "delivered" {
+		t.Fatalf("Expected the first entry to be delivered, got %v", received)
+	}
+	if len(outbox) != 2 || outbox[0].Notification.Title != "hanging" || outbox[1].Notification.Title != "outage" {
+		t.Fatalf("Expected the hanging entry and the outage to be queued, got %+v", outbox)
+	}
+	if len(report.Results) != 2 || report.Results[0].Error != "" || !report.Results[1].Queued {
+		t.Errorf("Expected the delivered entry and the deadline in the report, got %+v", report.Results)
+	}
+}
+
+func TestEnqueue_Dedup(t *testing.T) {
+	now := time.Now()
+	var queue notifier.Outbox
+	queue = enqueue(queue, "phone", newTestNotification(event_type.OUTAGE), 1, "down", now, now)
+	queue = enqueue(queue, "phone", newTestNotification(event_type.OUTAGE), 1, "down", now, now)
+	if len(queue) != 1 {
+		t.Fatalf("Expected a repeated outage to be deduplicated, got %d entries", len(queue))
+	}
+
+	queue = enqueue(queue, "phone", newTestNotification(event_type.RECOVERY), 1, "down", now, now)
+	queue = enqueue(queue, "phone", newTestNotification(event_type.OUTAGE), 1, "down", now, now)
+	if len(queue) != 3 {
+		t.Errorf("Expected outage, recovery and outage to be kept in order, got %d entries", len(queue))
+	}
+}
+
+func TestPruneOutbox(t *testing.T) {
+	now := time.Now()
+	outbox := notifier.Outbox{
+		{ID: "fresh", Created: now.Add(-time.Hour).Format(time.RFC3339), Attempts: 3},
+		{ID: "expired", Created: now.Add(-3 * time.Hour).Format(time.RFC3339), Attempts: 1},
+		{ID: "exhausted", Created: now.Format(time.RFC3339), Attempts: 5},
+		{ID: "invalid", Created: "yesterday"},
+	}
+
+	kept := pruneOutbox(outbox, &configure.OutboxConfig{MaxAge: 2, MaxAttempts: 5}, now)
+	if len(kept) != 1 || kept[0].ID != "fresh" {
+		t.Errorf("Expected only the fresh entry to be kept, got %+v", kept)
+	}
+}
+
+func TestNextAttempt_Backoff(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{attempts: 0, expected: 0},
+		{attempts: 1, expected: 5 * time.Minute},
+		{attempts: 3, expected: 20 * time.Minute},
+		{attempts: 10, expected: 4 * time.Hour},
+		{attempts: 100, expected: 4 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		if next := nextAttempt(tt.attempts, now); !next.Equal(now.Add(tt.expected)) {
+			t.Errorf("Expected retry after %v for %d attempts, got %s", tt.expected, tt.attempts, next)
+		}
+	}
+}
+
+func TestOutbox_ReadWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notification_outbox.json")
+
+	outbox, err := ReadOutbox(path)
+	if err != nil || len(outbox) != 0 {
+		t.Fatalf("Expected an empty outbox before the first run, got %+v, %v", outbox, err)
+	}
+
+	outbox = enqueue(outbox, "phone", newTestNotification(event_type.OUTAGE), 1, "down", time.Now(), time.Now())
+	if err := WriteOutbox(outbox, path); err != nil {
+		t.Fatalf("Failed to write outbox: %v", err)
+	}
+
+	loaded, err := ReadOutbox(path)
+	if err != nil {
+		t.Fatalf("Failed to read outbox: %v", err)
+	}
+	if len(loaded) != 1 || loaded[0].ID != outbox[0].ID || loaded[0].Notification.Title != "outage" {
+		t.Errorf("Expected the queued notification to be restored, got %+v", loaded)
+	}
+}
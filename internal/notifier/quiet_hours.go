@@ -0,0 +1,57 @@
+package notifier
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/common/schedule"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+)
+
+// quietHours is a parsed quiet hours period and the channels it mutes, all channels if empty
+type quietHours struct {
+	window   *schedule.Window
+	channels []string
+}
+
+// newQuietHours parses the quiet hours configuration, invalid periods are ignored
+func newQuietHours(configs []configure.QuietHoursConfig) []quietHours {
+	var periods []quietHours
+	for i, config := range configs {
+		window, err := schedule.NewDailyWindow(config.Start, config.End, config.Days, config.Timezone)
+		if err != nil {
+			log.Printf("Ignoring quiet hours %d: %v", i, err)
+			continue
+		}
+		periods = append(periods, quietHours{window: window, channels: config.Channels})
+	}
+	return periods
+}
+
+// quietUntil returns when the quiet hours of the named service end, or the zero time if it is not muted
+func (nm *NotificationManager) quietUntil(name string, now time.Time) time.Time {
+	var until time.Time
+	for _, period := range nm.quietHours {
+		if !period.mutes(name) {
+			continue
+		}
+		if active, end := period.window.ActiveAt(now); active && end.After(until) {
+			until = end
+		}
+	}
+	return until
+}
+
+// mutes checks if the quiet hours apply to the named service
+func (q quietHours) mutes(name string) bool {
+	if len(q.channels) == 0 {
+		return true
+	}
+	for _, channel := range q.channels {
+		if strings.EqualFold(channel, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package notifier
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/event_type"
+)
+
+func TestNotificationManager_QuietHours(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// quiet hours covering the current time for the phone channel only
+	now := time.Now().UTC()
+	manager := NewNotificationManager(&configure.NotificationConfig{
+		Enabled: true,
+		QuietHours: []configure.QuietHoursConfig{
+			{Start: now.Add(-time.Hour).Format("15:04"), End: now.Add(time.Hour).Format("15:04"), Timezone: "UTC", Channels: []string{"Phone"}},
+		},
+		Channels: []*configure.ChannelConfig{
+			{Name: "phone", Type: "ntfy", ChannelConfigs: configure.ChannelConfigs{Ntfy: &configure.NtfyConfig{ServerURL: server.URL, Topic: "ops"}}},
+			{Name: "pager", Type: "ntfy", ChannelConfigs: configure.ChannelConfigs{Ntfy: &configure.NtfyConfig{ServerURL: server.URL, Topic: "oncall"}}},
+		},
+	})
+
+	outbox := notifier.Outbox{}
+	report := manager.SendWithOutbox(newTestNotification(event_type.OUTAGE), &outbox)
+
+	if phone := report.Results[0]; !phone.Muted || !phone.Queued || phone.Error != "" {
+		t.Errorf("Expected the phone channel to be muted, got %+v", phone)
+	}
+	if pager := report.Results[1]; pager.Muted || pager.Error != "" {
+		t.Errorf("Expected the pager channel to be notified, got %+v", pager)
+	}
+	if requests.Load() != 1 {
+		t.Errorf("Expected a single request, got %d", requests.Load())
+	}
+
+	if len(outbox) != 1 || outbox[0].Channel != "phone" {
+		t.Fatalf("Expected the muted notification to be queued, got %+v", outbox)
+	}
+	next, _ := time.Parse(time.RFC3339, outbox[0].NextAttempt)
+	if next.Before(now.Add(59 * time.Minute)) {
+		t.Errorf("Expected the muted notification to be retried after the quiet hours, got %s", outbox[0].NextAttempt)
+	}
+}
+
+func TestNewQuietHours_InvalidPeriod(t *testing.T) {
+	periods := newQuietHours([]configure.QuietHoursConfig{
+		{Start: "22:00", End: "07:00"},
+		{Start: "late", End: "07:00"},
+	})
+	if len(periods) != 1 {
+		t.Errorf("Expected the invalid period to be ignored, got %d periods", len(periods))
+	}
+}
@@ -0,0 +1,47 @@
+package notifier
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wcy-dt/ponghub/internal/common"
+	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+)
+
+// channelFilters returns the channels restricted to groups or tags, keyed by their lower case name
+func channelFilters(config *configure.NotificationConfig) map[string]*configure.ChannelConfig {
+	filters := make(map[string]*configure.ChannelConfig)
+	if config == nil {
+		return filters
+	}
+	for i, channel := range config.Channels {
+		if channel == nil || (len(channel.Groups) == 0 && len(channel.Tags) == 0) {
+			continue
+		}
+		name := channel.Name
+		if name == "" {
+			name = fmt.Sprintf("channels[%d]", i)
+		}
+		filters[strings.ToLower(name)] = channel
+	}
+	return filters
+}
+
+// filterByChannel returns the endpoints of the services in the groups of the channel and of the services
+// and endpoints with one of its tags
+func filterByChannel(channel *configure.ChannelConfig, services map[string]checker.Service, endpointsMap map[string][]checker.Endpoint) map[string][]checker.Endpoint {
+	return filterEndpoints(endpointsMap, func(serviceName string, endpoint checker.Endpoint) bool {
+		service := services[serviceName]
+		return common.MatchesFilter(channel.Groups, channel.Tags, service.Group, service.Tags, endpoint.Tags)
+	})
+}
+
+// servicesByName returns the check results keyed by the name of their service
+func servicesByName(checkResult []checker.Service) map[string]checker.Service {
+	services := make(map[string]checker.Service, len(checkResult))
+	for _, service := range checkResult {
+		services[service.Name] = service
+	}
+	return services
+}
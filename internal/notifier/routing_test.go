@@ -0,0 +1,82 @@
+package notifier
+
+import (
+	"testing"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/types/chk_result"
+)
+
+func TestChannelFilters(t *testing.T) {
+	filters := channelFilters(&configure.NotificationConfig{
+		Channels: []*configure.ChannelConfig{
+			{Name: "Web-Team", Type: "ntfy", Groups: []string{"Public Web"}},
+			{Name: "all", Type: "ntfy"},
+			nil,
+			{Type: "ntfy", Tags: []string{"db"}},
+		},
+	})
+
+	if len(filters) != 2 {
+		t.Fatalf("Expected 2 restricted channels, got %d", len(filters))
+	}
+	if filters["web-team"] == nil || filters["channels[3]"] == nil {
+		t.Errorf("Expected the channels to be keyed by their lower case name, got %v", filters)
+	}
+}
+
+func TestFilterByChannel(t *testing.T) {
+	services := servicesByName([]checker.Service{
+		{Name: "website", Group: "Public Web", Tags: []string{"frontend"}},
+		{Name: "api", Group: "Internal APIs"},
+	})
+	endpoints := map[string][]checker.Endpoint{
+		"website": {{URL: "https://example.com", Status: chk_result.NONE}},
+		"api": {
+			{URL: "https://api.example.com", Status: chk_result.NONE, Tags: []string{"DB"}},
+			{URL: "https://api.example.com/health", Status: chk_result.NONE},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		channel  *configure.ChannelConfig
+		expected map[string]int
+	}{
+		{
+			name:     "Group",
+			channel:  &configure.ChannelConfig{Groups: []string{"public web"}},
+			expected: map[string]int{"website": 1},
+		},
+		{
+			name:     "Endpoint tag",
+			channel:  &configure.ChannelConfig{Tags: []string{"db"}},
+			expected: map[string]int{"api": 1},
+		},
+		{
+			name:     "Service tag",
+			channel:  &configure.ChannelConfig{Tags: []string{"frontend", "db"}},
+			expected: map[string]int{"website": 1, "api": 1},
+		},
+		{
+			name:     "Group and tag",
+			channel:  &configure.ChannelConfig{Groups: []string{"Public Web"}, Tags: []string{"db"}},
+			expected: map[string]int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filtered := filterByChannel(tt.channel, services, endpoints)
+			if len(filtered) != len(tt.expected) {
+				t.Fatalf("Expected %d services, got %v", len(tt.expected), filtered)
+			}
+			for serviceName, count := range tt.expected {
+				if len(filtered[serviceName]) != count {
+					t.Errorf("Expected %d endpoints of %s, got %d", count, serviceName, len(filtered[serviceName]))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package notifier
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/event_type"
+)
+
+const (
+	// syntheticServiceName and syntheticURL identify the endpoint of synthetic test events
+	syntheticServiceName = "PongHub Test"
+	syntheticURL         = "https://example.com/ponghub-test"
+)
+
+// SendTestNotification sends a synthetic notification with a single event of the given type through
+// all channels, or only the named channel if it is not empty, to verify the notification configuration.
+// Quiet hours are ignored and nothing is queued in the outbox.
+func SendTestNotification(notificationConfig *configure.NotificationConfig, eventType event_type.EventType, channel string) (*notifier.DeliveryReport, error) {
+	manager := NewNotificationManager(notificationConfig)
+	if !manager.IsEnabled() {
+		return nil, fmt.Errorf("notifications are disabled or no channels are configured")
+	}
+	manager.quietHours = nil
+
+	if channel != "" {
+		found := false
+		for _, name := range manager.names {
+			found = found || strings.EqualFold(name, channel)
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown notification channel %q, configured channels: %s", channel, strings.Join(manager.names, ", "))
+		}
+		manager.SetRouter(func(name string, notification *notifier.Notification) *notifier.Notification {
+			if strings.EqualFold(name, channel) {
+				return notification
+			}
+			return nil
+		})
+	}
+
+	notification := buildSyntheticNotification(eventType, notificationConfig)
+	return manager.SendNotification(notification), nil
+}
+
+// buildSyntheticNotification creates a notification about a synthetic endpoint with an event of the given type
+func buildSyntheticNotification(eventType event_type.EventType, notificationConfig *configure.NotificationConfig) *notifier.Notification {
+	now := time.Now()
+	endpoint := checker.Endpoint{
+		URL:        syntheticURL,
+		Method:     "GET",
+		StatusCode: 200,
+		AttemptNum: 1,
+		SuccessNum: 1,
+		IsHTTPS:    true,
+		StartTime:  now.Format(time.RFC3339),
+		EndTime:    now.Format(time.RFC3339),
+	}
+	endpoints := map[string][]checker.Endpoint{syntheticServiceName: {endpoint}}
+
+	var notification *notifier.Notification
+	switch eventType {
+	case event_type.RECOVERY:
+		notification = buildNotification(nil, nil, endpoints, nil, notificationConfig)
+	case event_type.CERT_RENEWED:
+		endpoint.CertRemainingDays = 90
+		notification = buildNotification(nil, nil, nil, map[string][]checker.Endpoint{syntheticServiceName: {endpoint}}, notificationConfig)
+	case event_type.CERT_WARNING, event_type.CERT_EXPIRED:
+		endpoint.CertRemainingDays = 3
+		if eventType == event_type.CERT_EXPIRED {
+			endpoint.CertRemainingDays = 0
+			endpoint.IsCertExpired = true
+		}
+		notification = buildNotification(nil, map[string][]checker.Endpoint{syntheticServiceName: {endpoint}}, nil, nil, notificationConfig)
+	default:
+		endpoint.StatusCode = 503
+		endpoint.SuccessNum = 0
+		endpoint.FailureDetails = []string{"synthetic outage sent by notify-test"}
+		notification = buildNotification(map[string][]checker.Endpoint{syntheticServiceName: {endpoint}}, nil, nil, nil, notificationConfig)
+	}
+
+	notification.Title = "[TEST] " + notification.Title
+	return notification
+}
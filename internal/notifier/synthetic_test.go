@@ -0,0 +1,63 @@
+package notifier
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/types/event_type"
+)
+
+func TestSendTestNotification(t *testing.T) {
+	var mu sync.Mutex
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		bodies = append(bodies, string(body))
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config := &configure.NotificationConfig{
+		Enabled: true,
+		QuietHours: []configure.QuietHoursConfig{
+			{Start: "00:00", End: "00:00"},
+		},
+		Channels: []*configure.ChannelConfig{
+			{Name: "phone", Type: "ntfy", ChannelConfigs: configure.ChannelConfigs{Ntfy: &configure.NtfyConfig{ServerURL: server.URL, Topic: "ops"}}},
+			{Name: "chat", Type: "webhook", ChannelConfigs: configure.ChannelConfigs{Webhook: &configure.WebhookConfig{URL: server.URL}}},
+		},
+	}
+
+	report, err := SendTestNotification(config, event_type.CERT_EXPIRED, "Phone")
+	if err != nil {
+		t.Fatalf("SendTestNotification failed: %v", err)
+	}
+	if len(report.Results) != 1 || report.Results[0].Channel != "phone" || report.Results[0].Error != "" || report.Results[0].Muted {
+		t.Fatalf("Expected only the phone channel to be notified despite quiet hours, got %+v", report.Results)
+	}
+	if !strings.HasPrefix(report.Title, "[TEST] ") || len(bodies) != 1 || !strings.Contains(bodies[0], syntheticURL) {
+		t.Errorf("Expected a synthetic test notification, got %q and %v", report.Title, bodies)
+	}
+
+	report, err = SendTestNotification(config, event_type.RECOVERY, "")
+	if err != nil {
+		t.Fatalf("SendTestNotification failed: %v", err)
+	}
+	if len(report.Results) != 2 || !report.Results[1].Skipped {
+		t.Errorf("Expected the plain text channel to skip the recovery, got %+v", report.Results)
+	}
+
+	if _, err := SendTestNotification(config, event_type.OUTAGE, "pager"); err == nil || !strings.Contains(err.Error(), "phone, chat") {
+		t.Errorf("Expected an error listing the configured channels, got %v", err)
+	}
+	if _, err := SendTestNotification(&configure.NotificationConfig{}, event_type.OUTAGE, ""); err == nil {
+		t.Error("Expected an error when notifications are disabled")
+	}
+}
@@ -0,0 +1,153 @@
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/wcy-dt/ponghub/internal/common"
+	"github.com/wcy-dt/ponghub/internal/types/structures/reporter"
+	"github.com/wcy-dt/ponghub/internal/types/types/chk_result"
+)
+
+// GroupServices groups the services of the report in the order their groups first appear
+func GroupServices(reportResult reporter.Reporter) []reporter.Group {
+	groups := make([]reporter.Group, 0)
+	for _, service := range reportResult {
+		i := slices.IndexFunc(groups, func(group reporter.Group) bool { return group.Name == service.Group })
+		if i < 0 {
+			groups = append(groups, reporter.Group{Name: service.Group})
+			i = len(groups) - 1
+		}
+		groups[i].Services = append(groups[i].Services, service)
+	}
+
+	for i := range groups {
+		groups[i].Status = getGroupStatus(groups[i].Services)
+	}
+	return groups
+}
+
+// getGroupStatus merges the latest statuses of the services, services under maintenance are ignored
+// unless all services of the group are under maintenance. A group whose only failing services are
+// impacted by services they depend on is impacted.
+func getGroupStatus(services reporter.Reporter) string {
+	hasAll, hasNone, hasPart, hasImpacted := false, false, false, false
+	for _, service := range services {
+		if len(service.ServiceHistory) == 0 {
+			continue
+		}
+		switch chk_result.ParseCheckResult(service.ServiceHistory[len(service.ServiceHistory)-1].Status) {
+		case chk_result.ALL:
+			hasAll = true
+		case chk_result.NONE:
+			hasNone = true
+		case chk_result.PART:
+			hasPart = true
+		case chk_result.IMPACTED:
+			hasImpacted = true
+		}
+	}
+
+	switch {
+	case hasPart || (hasAll && hasNone):
+		return chk_result.PART.String()
+	case hasNone:
+		return chk_result.NONE.String()
+	case hasImpacted:
+		return chk_result.IMPACTED.String()
+	case hasAll:
+		return chk_result.ALL.String()
+	case len(services) > 0:
+		return chk_result.MAINTENANCE.String()
+	default:
+		return chk_result.UNKNOWN.String()
+	}
+}
+
+// FilterServices returns the services in one of the groups, with all their endpoints if the service has
+// one of the tags and with only the endpoints with one of the tags otherwise. Empty filters select everything.
+func FilterServices(reportResult reporter.Reporter, groups, tags []string) reporter.Reporter {
+	var filtered reporter.Reporter
+	for _, service := range reportResult {
+		if !common.MatchesFilter(groups, nil, service.Group) {
+			continue
+		}
+		if common.MatchesFilter(nil, tags, "", service.Tags) {
+			filtered = append(filtered, service)
+			continue
+		}
+
+		var endpoints reporter.Endpoints
+		for _, endpoint := range service.Endpoints {
+			if common.MatchesFilter(nil, tags, "", endpoint.Tags) {
+				endpoints = append(endpoints, endpoint)
+			}
+		}
+		if len(endpoints) > 0 {
+			service.Endpoints = endpoints
+			filtered = append(filtered, service)
+		}
+	}
+	return filtered
+}
+
+// FilterStatus returns the status of the services selected by FilterServices, grouped again so that
+// the status of the groups only depends on the selected services
+func FilterStatus(status reporter.Status, groups, tags []string) reporter.Status {
+	var services reporter.Reporter
+	for _, group := range status.Groups {
+		services = append(services, group.Services...)
+	}
+	status.Groups = GroupServices(FilterServices(services, groups, tags))
+	return status
+}
+
+// getTags returns the tags of the services and endpoints of the report, sorted and without duplicates
+func getTags(reportResult reporter.Reporter) []string {
+	var tags []string
+	for _, service := range reportResult {
+		tags = append(tags, service.Tags...)
+		for _, endpoint := range service.Endpoints {
+			tags = append(tags, endpoint.Tags...)
+		}
+	}
+	slices.SortFunc(tags, func(a, b string) int { return strings.Compare(strings.ToLower(a), strings.ToLower(b)) })
+	return slices.CompactFunc(tags, strings.EqualFold)
+}
+
+// NewStatus returns the JSON status of the services of the report
+func NewStatus(reportResult reporter.Reporter) reporter.Status {
+	return reporter.Status{
+		UpdateTime: getLatestTime(reportResult),
+		Groups:     GroupServices(reportResult),
+	}
+}
+
+// ReadStatus reads the JSON status of the services
+func ReadStatus(statusPath string) (reporter.Status, error) {
+	var status reporter.Status
+	data, err := os.ReadFile(statusPath)
+	if err != nil {
+		return status, err
+	}
+	if err := json.Unmarshal(data, &status); err != nil {
+		return status, fmt.Errorf("error parsing %s: %w", statusPath, err)
+	}
+	return status, nil
+}
+
+// WriteStatus writes the JSON status of the services of the report, so that it can be published
+// together with the report
+func WriteStatus(reportResult reporter.Reporter, statusPath string) error {
+	data, err := json.MarshalIndent(NewStatus(reportResult), "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding status: %w", err)
+	}
+	if err := os.WriteFile(statusPath, data, 0644); err != nil {
+		return fmt.Errorf("error writing %s: %w", statusPath, err)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package reporter
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/reporter"
+)
+
+// newTestReport returns services in two groups and one service without group
+func newTestReport() reporter.Reporter {
+	history := func(status string) reporter.History {
+		return reporter.History{{Time: "2025-03-05T10:00:00Z", Status: status}}
+	}
+	return reporter.Reporter{
+		{Name: "website", Group: "Public Web", Tags: []string{"frontend"}, ServiceHistory: history("all"),
+			Endpoints: reporter.Endpoints{{URL: "https://example.com"}}},
+		{Name: "api", Group: "Internal APIs", ServiceHistory: history("none"),
+			Endpoints: reporter.Endpoints{{URL: "https://api.example.com", Tags: []string{"db"}}, {URL: "https://api.example.com/health"}}},
+		{Name: "docs", Group: "Public Web", ServiceHistory: history("maintenance"),
+			Endpoints: reporter.Endpoints{{URL: "https://docs.example.com", Tags: []string{"Frontend"}}}},
+		{Name: "payments", ServiceHistory: history("part"),
+			Endpoints: reporter.Endpoints{{URL: "https://pay.example.com"}}},
+	}
+}
+
+func TestGroupServices(t *testing.T) {
+	groups := GroupServices(newTestReport())
+
+	expected := []struct {
+		name     string
+		status   string
+		services []string
+	}{
+		{name: "Public Web", status: "all", services: []string{"website", "docs"}},
+		{name: "Internal APIs", status: "none", services: []string{"api"}},
+		{name: "", status: "part", services: []string{"payments"}},
+	}
+	if len(groups) != len(expected) {
+		t.Fatalf("Expected %d groups, got %d", len(expected), len(groups))
+	}
+	for i, group := range groups {
+		var names []string
+		for _, service := range group.Services {
+			names = append(names, service.Name)
+		}
+		if group.Name != expected[i].name || group.Status != expected[i].status || !reflect.DeepEqual(names, expected[i].services) {
+			t.Errorf("Expected group %q with status %s and services %v, got %q with status %s and services %v",
+				expected[i].name, expected[i].status, expected[i].services, group.Name, group.Status, names)
+		}
+	}
+}
+
+func TestGetGroupStatus_Impacted(t *testing.T) {
+	services := reporter.Reporter{
+		{Name: "orders", ServiceHistory: reporter.History{{Status: "impacted"}}},
+		{Name: "web", ServiceHistory: reporter.History{{Status: "all"}}},
+	}
+	if status := getGroupStatus(services); status != "impacted" {
+		t.Errorf("Expected the group to be impacted, got %s", status)
+	}
+
+	services = append(services, reporter.Service{Name: "auth", ServiceHistory: reporter.History{{Status: "none"}}})
+	if status := getGroupStatus(services); status != "part" {
+		t.Errorf("Expected an outage in the group to take precedence, got %s", status)
+	}
+}
+
+func TestFilterServices(t *testing.T) {
+	tests := []struct {
+		name      string
+		groups    []string
+		tags      []string
+		endpoints map[string]int
+	}{
+		{name: "No filter", endpoints: map[string]int{"website": 1, "api": 2, "docs": 1, "payments": 1}},
+		{name: "Group", groups: []string{"public web"}, endpoints: map[string]int{"website": 1, "docs": 1}},
+		{name: "Tag", tags: []string{"frontend"}, endpoints: map[string]int{"website": 1, "docs": 1}},
+		{name: "Endpoint tag", tags: []string{"db"}, endpoints: map[string]int{"api": 1}},
+		{name: "Group and tag", groups: []string{"Internal APIs"}, tags: []string{"frontend"}, endpoints: map[string]int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filtered := FilterServices(newTestReport(), tt.groups, tt.tags)
+			endpoints := make(map[string]int)
+			for _, service := range filtered {
+				endpoints[service.Name] = len(service.Endpoints)
+			}
+			if !reflect.DeepEqual(endpoints, tt.endpoints) {
+				t.Errorf("Expected endpoints %v, got %v", tt.endpoints, endpoints)
+			}
+		})
+	}
+}
+
+func TestGetTags(t *testing.T) {
+	if tags := getTags(newTestReport()); !reflect.DeepEqual(tags, []string{"db", "frontend"}) {
+		t.Errorf("Expected tags [db frontend], got %v", tags)
+	}
+}
+
+func TestStatus_ReadWrite(t *testing.T) {
+	statusPath := filepath.Join(t.TempDir(), "status.json")
+	if err := WriteStatus(newTestReport(), statusPath); err != nil {
+		t.Fatalf("Failed to write status: %v", err)
+	}
+
+	status, err := ReadStatus(statusPath)
+	if err != nil {
+		t.Fatalf("Failed to read status: %v", err)
+	}
+	if status.UpdateTime != "2025-03-05T10:00:00Z" || len(status.Groups) != 3 {
+		t.Errorf("Unexpected status: %+v", status)
+	}
+
+	filtered := FilterStatus(status, nil, []string{"db"})
+	if len(filtered.Groups) != 1 || filtered.Groups[0].Name != "Internal APIs" || filtered.Groups[0].Status != "none" {
+		t.Errorf("Expected only the Internal APIs group, got %+v", filtered.Groups)
+	}
+}
@@ -5,10 +5,14 @@ import (
 	"html/template"
 	"log"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/wcy-dt/ponghub/internal/common"
 	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
 	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/structures/incident"
+	"github.com/wcy-dt/ponghub/internal/types/structures/notifier"
 	"github.com/wcy-dt/ponghub/internal/types/structures/reporter"
 	"github.com/wcy-dt/ponghub/internal/types/types/chk_result"
 	"github.com/wcy-dt/ponghub/internal/types/types/default_config"
@@ -44,26 +48,38 @@ func GetReport(currentCheckResult []checker.Service, logPath string, cfg *config
 	return reportResult, nil
 }
 
-// getAvailability calculates and updates the availability for each service in the report
+// getAvailability calculates and updates the availability for each service in the report,
+// periods of maintenance are excluded
 func getAvailability(reportResult reporter.Reporter) reporter.Reporter {
 	for i := range reportResult {
 		if len(reportResult[i].ServiceHistory) == 0 {
 			continue
 		}
 		statusAllEntryNum := 0
+		checkedEntryNum := 0
 		for _, entry := range reportResult[i].ServiceHistory {
+			if chk_result.IsMaintenance(entry.Status) {
+				continue
+			}
+			checkedEntryNum++
 			if chk_result.IsALL(entry.Status) {
 				statusAllEntryNum++
 			}
 		}
-		availability := float64(statusAllEntryNum) / float64(len(reportResult[i].ServiceHistory))
+		if checkedEntryNum == 0 {
+			// the service was under maintenance for the whole period
+			reportResult[i].Availability = 1
+			continue
+		}
+		availability := float64(statusAllEntryNum) / float64(checkedEntryNum)
 		reportResult[i].Availability = availability
 	}
 
 	return reportResult
 }
 
-// getCertStatus updates the report with certificate status from the current check results
+// getCertStatus updates the report with certificate status from the current check results,
+// and with the services at the root of the dependencies of impacted services
 func getCertStatus(reportResult reporter.Reporter, currentCheckResult []checker.Service) reporter.Reporter {
 	for _, serviceResult := range currentCheckResult {
 		serviceName := serviceResult.Name
@@ -86,6 +102,7 @@ func getCertStatus(reportResult reporter.Reporter, currentCheckResult []checker.
 						}
 					}
 				}
+				reportResult[i].ImpactedBy = serviceResult.ImpactedBy
 				break
 			}
 		}
@@ -94,8 +111,10 @@ func getCertStatus(reportResult reporter.Reporter, currentCheckResult []checker.
 	return reportResult
 }
 
-// WriteReport generates an HTML report from the provided log data
-func WriteReport(reportResult reporter.Reporter, reportPath string, displayNum int) error {
+// WriteReport generates an HTML report from the provided log data, with the services listed by group
+// and buttons to filter them by tag. Failed channels of the last notification delivery are listed
+// if deliveryReport is not nil, followed by the incidents.
+func WriteReport(reportResult reporter.Reporter, reportPath string, displayNum int, deliveryReport *notifier.DeliveryReport, incidents incident.Incidents) error {
 	// Parse the HTML template
 	tmpl, err := template.New("report.html").
 		Funcs(createTemplateFunc()).
@@ -117,9 +136,14 @@ func WriteReport(reportResult reporter.Reporter, reportPath string, displayNum i
 
 	// Execute the template with the log data
 	if err := tmpl.Execute(reportFile, map[string]any{
-		"ReportResult": reportResult,
-		"UpdateTime":   getLatestTime(reportResult),
-		"DisplayNum":   displayNum,
+		"ReportResult":     reportResult,
+		"Groups":           GroupServices(reportResult),
+		"Tags":             getTags(reportResult),
+		"UpdateTime":       getLatestTime(reportResult),
+		"DisplayNum":       displayNum,
+		"Delivery":         deliveryReport,
+		"DeliveryFailures": deliveryReport.Failed(),
+		"Incidents":        incidents,
 	}); err != nil {
 		return fmt.Errorf("template execution failed: %w", err)
 	}
@@ -163,5 +187,32 @@ func createTemplateFunc() template.FuncMap {
 			}
 			return result
 		},
+		"duration": formatDuration,
+		"join":     strings.Join,
+	}
+}
+
+// formatDuration formats the time between two RFC 3339 times rounded to minutes, e.g. "2h 15m",
+// the duration lasts until now if end is empty
+func formatDuration(start, end string) string {
+	startTime, err := time.Parse(time.RFC3339, start)
+	if err != nil {
+		return ""
+	}
+	endTime := time.Now()
+	if end != "" {
+		if endTime, err = time.Parse(time.RFC3339, end); err != nil {
+			return ""
+		}
+	}
+
+	minutes := int(endTime.Sub(startTime).Round(time.Minute).Minutes())
+	switch {
+	case minutes < 60:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes < 24*60:
+		return fmt.Sprintf("%dh %dm", minutes/60, minutes%60)
+	default:
+		return fmt.Sprintf("%dd %dh", minutes/(24*60), minutes%(24*60)/60)
 	}
 }
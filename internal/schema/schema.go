@@ -0,0 +1,172 @@
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/structures/schema"
+	"github.com/wcy-dt/ponghub/internal/types/types/default_config"
+)
+
+const (
+	// draft is the JSON Schema version of the generated schema, the most widely supported by editors
+	draft = "http://json-schema.org/draft-07/schema#"
+
+	// definitionsRef is the prefix of the references to the definitions of the structs
+	definitionsRef = "#/definitions/"
+)
+
+// defaults maps the fields that default to a value of default_config, by struct and field name
+var defaults = map[string]func() any{
+	"Configure.Timeout":          func() any { return default_config.GetDefaultTimeout() },
+	"Configure.MaxRetryTimes":    func() any { return default_config.GetDefaultMaxRetryTimes() },
+	"Configure.MaxLogDays":       func() any { return default_config.GetDefaultMaxLogDays() },
+	"Configure.CertNotifyDays":   func() any { return default_config.GetDefaultCertNotifyDays() },
+	"Configure.DisplayNum":       func() any { return default_config.GetDisplayNum() },
+	"NotificationConfig.Timeout": func() any { return default_config.GetDefaultDeliveryTimeout() },
+	"OutboxConfig.MaxAge":        func() any { return default_config.GetDefaultOutboxMaxAge() },
+	"OutboxConfig.MaxAttempts":   func() any { return default_config.GetDefaultOutboxMaxAttempts() },
+	"DigestConfig.Slowest":       func() any { return default_config.GetDefaultDigestSlowest() },
+	"DigestConfig.CertDays":      func() any { return default_config.GetDefaultDigestCertDays() },
+	"EscalationConfig.AckFile":   func() any { return default_config.GetAckPath() },
+}
+
+// generated is the schema of the configuration, which is generated once
+var generated = sync.OnceValue(generate)
+
+// Generate returns the JSON Schema of the configuration file, generated from configure.Configure and
+// its nested structs. Descriptions, enums and bounds are read from the description, enum, minimum and
+// maximum tags of the fields, defaults from default_config.
+func Generate() *schema.Schema {
+	return generated()
+}
+
+// Marshal returns the JSON Schema of the configuration file as indented JSON
+func Marshal() ([]byte, error) {
+	data, err := json.MarshalIndent(Generate(), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// generator builds the definitions of the structs referenced by the schema
+type generator struct {
+	definitions map[string]*schema.Schema
+}
+
+// generate builds the schema of the configuration with the structs other than configure.Configure as definitions
+func generate() *schema.Schema {
+	g := &generator{definitions: make(map[string]*schema.Schema)}
+	root := g.object(reflect.TypeOf(configure.Configure{}))
+	root.Schema = draft
+	root.Title = "PongHub configuration"
+	root.Definitions = g.definitions
+	return root
+}
+
+// object returns the schema of a struct, whose properties are its fields including the fields of inlined structs
+func (g *generator) object(t reflect.Type) *schema.Schema {
+	object := &schema.Schema{
+		Type:                 schema.Types{"object"},
+		Properties:           make(map[string]*schema.Schema),
+		AdditionalProperties: false,
+	}
+	g.addProperties(object, t)
+	return object
+}
+
+// addProperties adds the fields of a struct to the properties of an object schema
+func (g *generator) addProperties(object *schema.Schema, t reflect.Type) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, options, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "-" {
+			continue
+		}
+		if strings.Contains(options, "inline") {
+			g.addProperties(object, field.Type)
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		object.Properties[name] = g.property(t, field)
+	}
+}
+
+// property returns the schema of a field, with the description, enum and bounds of its tags
+// applied to the field or to the items of a list
+func (g *generator) property(t reflect.Type, field reflect.StructField) *schema.Schema {
+	property := g.value(field.Type)
+	property.Description = field.Tag.Get("description")
+	if value, exists := defaults[t.Name()+"."+field.Name]; exists {
+		property.Default = value()
+	}
+
+	constrained := property
+	if property.Items != nil {
+		constrained = property.Items
+	}
+	if enum := field.Tag.Get("enum"); enum != "" {
+		constrained.Enum = strings.Split(enum, ",")
+	}
+	if minimum, err := strconv.Atoi(field.Tag.Get("minimum")); err == nil {
+		constrained.Minimum = &minimum
+	}
+	if maximum, err := strconv.Atoi(field.Tag.Get("maximum")); err == nil {
+		constrained.Maximum = &maximum
+	}
+	return property
+}
+
+// value returns the schema of a Go type. Structs are referenced from the definitions, and pointers,
+// slices and maps may be null as YAML decodes an empty value into them.
+func (g *generator) value(t reflect.Type) *schema.Schema {
+	nullable := false
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+		nullable = true
+	}
+
+	var value *schema.Schema
+	switch t.Kind() {
+	case reflect.Struct:
+		if _, exists := g.definitions[t.Name()]; !exists {
+			// register the definition before building it so that recursive structs terminate
+			g.definitions[t.Name()] = &schema.Schema{}
+			*g.definitions[t.Name()] = *g.object(t)
+		}
+		if nullable {
+			g.definitions[t.Name()].Type = schema.Types{"object", "null"}
+		}
+		return &schema.Schema{Ref: definitionsRef + t.Name()}
+	case reflect.Slice:
+		value = &schema.Schema{Type: schema.Types{"array"}, Items: g.value(t.Elem())}
+		nullable = true
+	case reflect.Map:
+		value = &schema.Schema{Type: schema.Types{"object"}, AdditionalProperties: g.value(t.Elem())}
+		nullable = true
+	case reflect.Bool:
+		value = &schema.Schema{Type: schema.Types{"boolean"}}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value = &schema.Schema{Type: schema.Types{"integer"}}
+	case reflect.Float32, reflect.Float64:
+		value = &schema.Schema{Type: schema.Types{"number"}}
+	default:
+		value = &schema.Schema{Type: schema.Types{"string"}}
+	}
+
+	if nullable {
+		value.Type = append(value.Type, "null")
+	}
+	return value
+}
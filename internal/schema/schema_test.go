@@ -0,0 +1,56 @@
+package schema
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/wcy-dt/ponghub/internal/types/types/default_config"
+)
+
+func TestGenerate(t *testing.T) {
+	generated := Generate()
+
+	if timeout := generated.Properties["timeout"]; timeout.Default != default_config.GetDefaultTimeout() {
+		t.Errorf("Expected the timeout to default to %d, got %v", default_config.GetDefaultTimeout(), timeout.Default)
+	}
+
+	endpoint := generated.Definitions["Endpoint"]
+	if endpoint == nil {
+		t.Fatal("Expected the endpoint to be defined")
+	}
+	if _, exists := endpoint.Properties["parsedurl"]; exists {
+		t.Error("Expected fields that are not read from YAML to be skipped")
+	}
+	if method := endpoint.Properties["method"]; len(method.Enum) != 3 || method.Description == "" {
+		t.Errorf("Expected the method to be described and enumerated, got %+v", method)
+	}
+	if statusCode := endpoint.Properties["status_code"]; statusCode.Minimum == nil || *statusCode.Maximum != 599 {
+		t.Errorf("Expected the status code to be bounded, got %+v", statusCode)
+	}
+
+	notifications := generated.Definitions["NotificationConfig"]
+	if notifications.Properties["webhook"] == nil {
+		t.Error("Expected the inlined channel settings to be properties of the notifications")
+	}
+	if methods := notifications.Properties["methods"]; methods.Items == nil || len(methods.Items.Enum) == 0 {
+		t.Error("Expected the notification methods to be enumerated")
+	}
+	if email := generated.Definitions["EmailConfig"]; len(email.Type) != 2 || email.Properties["auth"].Enum == nil {
+		t.Errorf("Expected the email settings to be nullable with enumerated auth types, got %+v", email)
+	}
+}
+
+func TestSchemaFileUpToDate(t *testing.T) {
+	expected, err := Marshal()
+	if err != nil {
+		t.Fatalf("Failed to marshal the schema: %v", err)
+	}
+	shipped, err := os.ReadFile("../../config.schema.json")
+	if err != nil {
+		t.Fatalf("Failed to read the shipped schema: %v", err)
+	}
+	if !bytes.Equal(shipped, expected) {
+		t.Error("config.schema.json is outdated, regenerate it with: go run ./cmd/ponghub schema --output config.schema.json")
+	}
+}
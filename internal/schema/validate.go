@@ -0,0 +1,158 @@
+package schema
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/structures/schema"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Validate checks a YAML document against the JSON Schema of the configuration and returns the keys,
+// types, enum values and bounds that do not match it at the line and column of their node.
+// Any scalar is accepted as a string as YAML decodes it into one, and enum values are matched
+// case-insensitively as PongHub does.
+func Validate(root *yaml.Node) configure.ValidationErrors {
+	var errs configure.ValidationErrors
+	validator{definitions: Generate().Definitions}.validate(&errs, root, Generate(), "")
+	return errs
+}
+
+// validator checks YAML nodes against schemas, resolving the references to the definitions
+type validator struct {
+	definitions map[string]*schema.Schema
+}
+
+// validate checks a node and its children against a schema
+func (v validator) validate(errs *configure.ValidationErrors, node *yaml.Node, s *schema.Schema, path string) {
+	switch node.Kind {
+	case 0:
+		return
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			v.validate(errs, child, s, path)
+		}
+		return
+	case yaml.AliasNode:
+		v.validate(errs, node.Alias, s, path)
+		return
+	}
+	for s.Ref != "" {
+		s = v.definitions[strings.TrimPrefix(s.Ref, definitionsRef)]
+	}
+
+	nodeType := getNodeType(node)
+	if !slices.ContainsFunc(s.Type, func(t string) bool { return matchesType(node, nodeType, t) }) {
+		addError(errs, node, path, fmt.Sprintf("expected %s, got %s", strings.Join(s.Type, " or "), nodeType))
+		return
+	}
+
+	switch node.Kind {
+	case yaml.ScalarNode:
+		v.validateScalar(errs, node, s, path)
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Value == "<<" {
+				// merge keys insert the keys of a mapping or a sequence of mappings
+				if value.Kind == yaml.SequenceNode {
+					for _, merged := range value.Content {
+						v.validate(errs, merged, s, path)
+					}
+				} else {
+					v.validate(errs, value, s, path)
+				}
+				continue
+			}
+
+			if property, exists := s.Properties[key.Value]; exists {
+				v.validate(errs, value, property, joinPath(path, key.Value))
+			} else if additional, ok := s.AdditionalProperties.(*schema.Schema); ok {
+				v.validate(errs, value, additional, joinPath(path, key.Value))
+			} else {
+				addError(errs, key, joinPath(path, key.Value), fmt.Sprintf("unknown key %q", key.Value))
+			}
+		}
+	case yaml.SequenceNode:
+		if s.Items == nil {
+			return
+		}
+		for i, child := range node.Content {
+			v.validate(errs, child, s.Items, fmt.Sprintf("%s[%d]", path, i))
+		}
+	}
+}
+
+// validateScalar checks that a scalar is one of the values of the enum and within the bounds of the schema
+func (v validator) validateScalar(errs *configure.ValidationErrors, node *yaml.Node, s *schema.Schema, path string) {
+	if len(s.Enum) > 0 && !slices.ContainsFunc(s.Enum, func(value string) bool { return strings.EqualFold(value, node.Value) }) {
+		addError(errs, node, path, fmt.Sprintf("value %q is not one of %s", node.Value, strings.Join(s.Enum, ", ")))
+	}
+
+	if s.Minimum == nil && s.Maximum == nil {
+		return
+	}
+	value, err := strconv.ParseFloat(node.Value, 64)
+	if err != nil {
+		return
+	}
+	switch {
+	case s.Minimum != nil && s.Maximum != nil && (value < float64(*s.Minimum) || value > float64(*s.Maximum)):
+		addError(errs, node, path, fmt.Sprintf("value %s is not between %d and %d", node.Value, *s.Minimum, *s.Maximum))
+	case s.Minimum != nil && s.Maximum == nil && value < float64(*s.Minimum):
+		addError(errs, node, path, fmt.Sprintf("value %s is less than %d", node.Value, *s.Minimum))
+	case s.Maximum != nil && s.Minimum == nil && value > float64(*s.Maximum):
+		addError(errs, node, path, fmt.Sprintf("value %s is greater than %d", node.Value, *s.Maximum))
+	}
+}
+
+// getNodeType returns the JSON type of a YAML node
+func getNodeType(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "array"
+	}
+	switch node.ShortTag() {
+	case "!!null":
+		return "null"
+	case "!!bool":
+		return "boolean"
+	case "!!int":
+		return "integer"
+	case "!!float":
+		return "number"
+	default:
+		return "string"
+	}
+}
+
+// matchesType reports whether a node of the given JSON type can be decoded into a value of type t
+func matchesType(node *yaml.Node, nodeType, t string) bool {
+	switch t {
+	case "number":
+		return nodeType == "number" || nodeType == "integer"
+	case "string":
+		return node.Kind == yaml.ScalarNode && nodeType != "null"
+	default:
+		return nodeType == t
+	}
+}
+
+// addError appends a validation error located at a node
+func addError(errs *configure.ValidationErrors, node *yaml.Node, path, message string) {
+	*errs = append(*errs, configure.ValidationError{Path: path, Message: message, Line: node.Line, Column: node.Column})
+}
+
+// joinPath appends a key to a validation path
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,94 @@
+package schema
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   string
+		expected []string
+	}{
+		{
+			name: "Valid config",
+			config: `
+timeout: 10
+services:
+  - name: "api"
+    endpoints:
+      - url: "https://example.com"
+        method: "post"
+        status_code: 201
+        headers:
+          X-Request-ID: 42
+  - name: "empty"
+    endpoints:
+notifications:
+  enabled: true
+  methods: ["Telegram"]
+  telegram:
+    chat_id: 123456
+`,
+		},
+		{
+			name: "Merge keys",
+			config: `
+anchors: &service
+  timeout: 10
+services:
+  - <<: *service
+    name: "api"
+    endpoints: []
+`,
+			expected: []string{`2:1: anchors: unknown key "anchors"`},
+		},
+		{
+			name: "Invalid config",
+			config: `
+timeout: "soon"
+services:
+  - name: "api"
+    retries: 3
+    endpoints:
+      - url: ["https://example.com"]
+        method: "PATCH"
+        status_code: 99
+notifications:
+  enabled: yes please
+  email:
+    auth: "kerberos"
+`,
+			expected: []string{
+				"2:10: timeout: expected integer, got string",
+				`5:5: services[0].retries: unknown key "retries"`,
+				"7:14: services[0].endpoints[0].url: expected string, got array",
+				`8:17: services[0].endpoints[0].method: value "PATCH" is not one of GET, POST, PUT`,
+				"9:22: services[0].endpoints[0].status_code: value 99 is not between 100 and 599",
+				"11:12: notifications.enabled: expected boolean, got string",
+				`13:11: notifications.email.auth: value "kerberos" is not one of plain, login, cram-md5, xoauth2, none`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var root yaml.Node
+			if err := yaml.Unmarshal([]byte(tt.config), &root); err != nil {
+				t.Fatalf("Failed to parse config: %v", err)
+			}
+
+			errs := Validate(&root)
+			if len(errs) != len(tt.expected) {
+				t.Fatalf("Expected %d errors, got %d:\n%v", len(tt.expected), len(errs), errs)
+			}
+			for i, expected := range tt.expected {
+				if got := errs[i].Error(); got != expected {
+					t.Errorf("Expected error %q, got %q", expected, got)
+				}
+			}
+		})
+	}
+}
@@ -12,12 +12,18 @@ type (
 	// Service defines the structure for the result of checking a service
 	Service struct {
 		Name       string                 `json:"name"`
+		Group      string                 `json:"group,omitempty"`
+		Tags       []string               `json:"tags,omitempty"`
+		DependsOn  []string               `json:"depends_on,omitempty"`
 		Status     chk_result.CheckResult `json:"status"`
 		Endpoints  []Endpoint             `json:"endpoints,omitempty"`
 		StartTime  string                 `json:"start_time"`
 		EndTime    string                 `json:"end_time"`
 		AttemptNum int                    `json:"attempt_num"`
 		SuccessNum int                    `json:"success_num"`
+
+		// ImpactedBy is the unavailable service at the root of the dependencies of an impacted service
+		ImpactedBy string `json:"impacted_by,omitempty"`
 	}
 
 	// Endpoint defines the structure for the result of checking a port
@@ -39,5 +45,6 @@ type (
 		IsCertExpired     bool                   `json:"is_cert_expired,omitempty"`
 		DisplayURL        string                 `json:"display_url,omitempty"`
 		HighlightSegments []highlight.Segment    `json:"highlight_segments,omitempty"`
+		Tags              []string               `json:"tags,omitempty"`
 	}
 )
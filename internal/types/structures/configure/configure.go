@@ -3,12 +3,23 @@ package configure
 type (
 	// Configure defines the overall configuration structure for the application
 	Configure struct {
-		Services       []Service           `yaml:"services"`
-		Timeout        int                 `yaml:"timeout,omitempty"`
-		MaxRetryTimes  int                 `yaml:"max_retry_times,omitempty"`
-		MaxLogDays     int                 `yaml:"max_log_days,omitempty"`
-		CertNotifyDays int                 `yaml:"cert_notify_days,omitempty"`
-		DisplayNum     int                 `yaml:"display_num,omitempty"`
-		Notifications  *NotificationConfig `yaml:"notifications,omitempty"`
+		Services       []Service           `yaml:"services" description:"Services to check"`
+		Timeout        int                 `yaml:"timeout,omitempty" description:"Timeout of each request in seconds"`
+		MaxRetryTimes  int                 `yaml:"max_retry_times,omitempty" description:"Number of retries of a failed request"`
+		MaxLogDays     int                 `yaml:"max_log_days,omitempty" description:"Number of days the log is kept"`
+		CertNotifyDays int                 `yaml:"cert_notify_days,omitempty" description:"Number of days before the expiration of a certificate from which it is reported"`
+		DisplayNum     int                 `yaml:"display_num,omitempty" description:"Number of checks per endpoint shown in the report"`
+		Defaults       *EndpointDefaults   `yaml:"defaults,omitempty" description:"Settings inherited by the endpoints of all services"`
+		Notifications  *NotificationConfig `yaml:"notifications,omitempty" description:"Notification channels and policies"`
+
+		// Include lists files, directories and glob patterns of configuration files relative to this file,
+		// whose services and global settings are merged into the configuration
+		Include []string `yaml:"include,omitempty" description:"Files, directories or glob patterns of configuration files merged into this one, relative to this file"`
+
+		// SecretsFile is the encrypted secrets file of the {{secret(store:name)}} parameters, relative to this file
+		SecretsFile string `yaml:"secrets_file,omitempty" description:"Encrypted secrets file read by the {{secret(store:name)}} parameters, relative to this file"`
+
+		// Files are the configuration files that were read, in the order they were read
+		Files []string `yaml:"-"`
 	}
 )
@@ -2,5 +2,5 @@ package configure
 
 // DefaultConfig defines default notification settings (e.g., GitHub Actions stderr)
 type DefaultConfig struct {
-	Enabled bool `yaml:"enabled,omitempty"`
+	Enabled bool `yaml:"enabled,omitempty" description:"Fail the GitHub Actions workflow when services are down"`
 }
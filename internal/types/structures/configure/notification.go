@@ -1,52 +1,288 @@
 package configure
 
 type (
-	// NotificationConfig defines the configuration for all notification channels
+	// NotificationConfig defines the configuration for all notification channels.
+	// The settings of each channel type configure a single instance used by Methods,
+	// Channels adds any number of named instances.
 	NotificationConfig struct {
-		Enabled bool           `yaml:"enabled,omitempty"`
-		Methods []string       `yaml:"methods,omitempty"`
-		Default *DefaultConfig `yaml:"default,omitempty"`
-		Email   *EmailConfig   `yaml:"email,omitempty"`
-		Webhook *WebhookConfig `yaml:"webhook,omitempty"`
+		Enabled        bool     `yaml:"enabled,omitempty" description:"Send notifications"`
+		Methods        []string `yaml:"methods,omitempty" description:"Channel types notified, each configured by the key of the same name" enum:"default,email,webhook,telegram,dingtalk,feishu,lark,wecom,pagerduty,opsgenie,ntfy,gotify,pushover,matrix,command"`
+		StatusPageURL  string   `yaml:"status_page_url,omitempty" description:"URL of the status page linked in notifications"`
+		ChannelConfigs `yaml:",inline"`
+		Emails         []*EmailConfig   `yaml:"emails,omitempty" description:"Additional email channels"`
+		Channels       []*ChannelConfig `yaml:"channels,omitempty" description:"Named channels, any number per type"`
+
+		// Timeout is the deadline in seconds for delivering a notification through all channels,
+		// which are notified concurrently
+		Timeout int `yaml:"timeout,omitempty" description:"Deadline in seconds for delivering a notification through all channels"`
+
+		Outbox     *OutboxConfig      `yaml:"outbox,omitempty" description:"Retries of notifications that could not be delivered"`
+		QuietHours []QuietHoursConfig `yaml:"quiet_hours,omitempty" description:"Daily periods during which channels are muted"`
+		Escalation *EscalationConfig  `yaml:"escalation,omitempty" description:"Escalation policy for outages"`
+		Digest     *DigestConfig      `yaml:"digest,omitempty" description:"Periodic summary of the availability"`
+	}
+
+	// DigestConfig defines a periodic summary of the availability, incidents, slowest endpoints
+	// and upcoming certificate expirations. Period is daily or weekly, Schedule is a cron
+	// expression evaluated in Timezone that defaults to 09:00 every day or every Monday.
+	// The digest is sent through the listed Channels, or all channels if empty.
+	DigestConfig struct {
+		Enabled  bool     `yaml:"enabled,omitempty" description:"Send the digest"`
+		Period   string   `yaml:"period,omitempty" description:"Period summarized by the digest" enum:"daily,weekly"`
+		Schedule string   `yaml:"schedule,omitempty" description:"Cron expression of the time the digest is sent, 09:00 every day or every Monday if empty"`
+		Timezone string   `yaml:"timezone,omitempty" description:"IANA time zone of the schedule, local time if empty"`
+		Channels []string `yaml:"channels,omitempty" description:"Channels the digest is sent through, all channels if empty"`
+
+		// Slowest is the number of slowest endpoints and CertDays the number of days
+		// before expiration from which certificates are listed
+		Slowest  int `yaml:"slowest,omitempty" description:"Number of slowest endpoints listed"`
+		CertDays int `yaml:"cert_days,omitempty" description:"Number of days before expiration from which certificates are listed"`
+	}
+
+	// EscalationConfig defines an escalation policy for outages. The channels of each step are
+	// notified once an outage has lasted for the step's After duration and has not been
+	// acknowledged in AckFile. Channels that are not part of any step receive every notification.
+	EscalationConfig struct {
+		Steps   []EscalationStep `yaml:"steps" description:"Channels notified as an outage lasts"`
+		AckFile string           `yaml:"ack_file,omitempty" description:"File listing the acknowledged incidents, which are not escalated"`
+	}
+
+	// EscalationStep defines the channels notified after an outage has lasted for After,
+	// a duration such as "15m" or "1h", or immediately if empty
+	EscalationStep struct {
+		After    string   `yaml:"after,omitempty" description:"Duration of the outage after which the channels are notified, e.g. 15m, immediately if empty"`
+		Channels []string `yaml:"channels" description:"Channels notified at this step"`
+	}
+
+	// QuietHoursConfig defines a daily period from Start to End in "15:04" format during which
+	// the listed Channels, or all channels if none are listed, are muted. Days limits the period
+	// to the given days of the week, e.g. ["sat", "sun"], on which it starts.
+	QuietHoursConfig struct {
+		Start    string   `yaml:"start" description:"Start of the period, e.g. 22:00"`
+		End      string   `yaml:"end" description:"End of the period, e.g. 07:00"`
+		Days     []string `yaml:"days,omitempty" description:"Days of the week on which the period starts, e.g. sat, every day if empty"`
+		Timezone string   `yaml:"timezone,omitempty" description:"IANA time zone of the period, local time if empty"`
+		Channels []string `yaml:"channels,omitempty" description:"Channels muted, all channels if empty"`
+	}
+
+	// OutboxConfig defines how notifications that could not be delivered are retried in later runs
+	OutboxConfig struct {
+		Disabled bool `yaml:"disabled,omitempty" description:"Drop notifications that could not be delivered instead of retrying them"`
+
+		// MaxAge is the number of hours and MaxAttempts the number of delivery attempts
+		// after which a queued notification is dropped
+		MaxAge      int `yaml:"max_age,omitempty" description:"Number of hours after which a queued notification is dropped"`
+		MaxAttempts int `yaml:"max_attempts,omitempty" description:"Number of delivery attempts after which a queued notification is dropped"`
+	}
+
+	// ChannelConfigs holds the settings of every notification channel type
+	ChannelConfigs struct {
+		Default   *DefaultConfig   `yaml:"default,omitempty" description:"GitHub Actions settings"`
+		Email     *EmailConfig     `yaml:"email,omitempty" description:"SMTP email settings"`
+		Webhook   *WebhookConfig   `yaml:"webhook,omitempty" description:"Generic webhook settings"`
+		Telegram  *TelegramConfig  `yaml:"telegram,omitempty" description:"Telegram bot settings"`
+		DingTalk  *DingTalkConfig  `yaml:"dingtalk,omitempty" description:"DingTalk robot settings"`
+		Feishu    *FeishuConfig    `yaml:"feishu,omitempty" description:"Feishu/Lark bot settings"`
+		WeCom     *WeComConfig     `yaml:"wecom,omitempty" description:"WeCom group robot settings"`
+		PagerDuty *PagerDutyConfig `yaml:"pagerduty,omitempty" description:"PagerDuty Events API v2 settings"`
+		Opsgenie  *OpsgenieConfig  `yaml:"opsgenie,omitempty" description:"Opsgenie Alert API settings"`
+		Ntfy      *NtfyConfig      `yaml:"ntfy,omitempty" description:"ntfy settings"`
+		Gotify    *GotifyConfig    `yaml:"gotify,omitempty" description:"Gotify settings"`
+		Pushover  *PushoverConfig  `yaml:"pushover,omitempty" description:"Pushover settings"`
+		Matrix    *MatrixConfig    `yaml:"matrix,omitempty" description:"Matrix room settings"`
+		Command   *CommandConfig   `yaml:"command,omitempty" description:"Local command settings"`
+	}
+
+	// ChannelConfig defines a named notification channel instance. Type selects the channel,
+	// whose settings are read from the key of the same name, e.g. type webhook uses webhook.
+	ChannelConfig struct {
+		Name string `yaml:"name" description:"Name of the channel used by quiet hours, escalation and the digest"`
+		Type string `yaml:"type" description:"Channel type, configured by the key of the same name" enum:"default,email,webhook,telegram,dingtalk,feishu,lark,wecom,pagerduty,opsgenie,ntfy,gotify,pushover,matrix,command"`
+
+		// Groups and Tags restrict the channel to the events of services in one of the groups
+		// and of services or endpoints with one of the tags
+		Groups []string `yaml:"groups,omitempty" description:"Only notify about services in one of these groups"`
+		Tags   []string `yaml:"tags,omitempty" description:"Only notify about services and endpoints with one of these tags"`
+
+		ChannelConfigs `yaml:",inline"`
 	}
 
 	// EmailConfig defines SMTP email notification settings
 	EmailConfig struct {
-		SMTPHost    string   `yaml:"smtp_host"`
-		SMTPPort    int      `yaml:"smtp_port"`
-		From        string   `yaml:"from"`
-		To          []string `yaml:"to"`
-		ReplyTo     string   `yaml:"reply_to,omitempty"`
-		UseTLS      bool     `yaml:"use_tls,omitempty"`
-		UseStartTLS bool     `yaml:"use_starttls,omitempty"`
-		SkipVerify  bool     `yaml:"skip_verify,omitempty"`
+		SMTPHost    string   `yaml:"smtp_host" description:"Host of the SMTP server"`
+		SMTPPort    int      `yaml:"smtp_port" description:"Port of the SMTP server"`
+		From        string   `yaml:"from" description:"Sender address"`
+		To          []string `yaml:"to" description:"Recipient addresses"`
+		ReplyTo     string   `yaml:"reply_to,omitempty" description:"Reply-To address"`
+		UseTLS      bool     `yaml:"use_tls,omitempty" description:"Connect with implicit TLS, usually on port 465"`
+		UseStartTLS bool     `yaml:"use_starttls,omitempty" description:"Upgrade the connection with STARTTLS, usually on port 587"`
+		SkipVerify  bool     `yaml:"skip_verify,omitempty" description:"Skip the verification of the certificate of the server"`
+
+		// Auth is the SMTP authentication mechanism: plain, login, cram-md5, xoauth2 or none.
+		// When empty, PLAIN is used if credentials are configured and no authentication otherwise.
+		Auth string `yaml:"auth,omitempty" description:"SMTP authentication mechanism, PLAIN if credentials are configured and none otherwise" enum:"plain,login,cram-md5,xoauth2,none"`
+
+		// Username and Password support Special Parameters and fall back to SMTP_USERNAME and
+		// SMTP_PASSWORD, the *File variants read them from files instead. For XOAUTH2 the
+		// password is the OAuth2 access token.
+		Username     string `yaml:"username,omitempty" description:"SMTP username, SMTP_USERNAME if empty"`
+		Password     string `yaml:"password,omitempty" description:"SMTP password or OAuth2 access token, SMTP_PASSWORD if empty"`
+		UsernameFile string `yaml:"username_file,omitempty" description:"File containing the SMTP username"`
+		PasswordFile string `yaml:"password_file,omitempty" description:"File containing the SMTP password"`
+
+		// PlainText disables the HTML part and sends text-only emails
+		PlainText bool `yaml:"plain_text,omitempty" description:"Send text-only emails"`
+
+		// HTMLTemplate and TextTemplate are paths to Go templates overriding the built-in email bodies
+		HTMLTemplate string `yaml:"html_template,omitempty" description:"Go template overriding the HTML body"`
+		TextTemplate string `yaml:"text_template,omitempty" description:"Go template overriding the text body"`
+
+		// AttachReport attaches the generated HTML report found at ReportPath
+		AttachReport bool   `yaml:"attach_report,omitempty" description:"Attach the HTML report"`
+		ReportPath   string `yaml:"report_path,omitempty" description:"Path of the attached report"`
 	}
 
 	// CustomPayloadConfig defines custom payload configuration for webhooks
 	CustomPayloadConfig struct {
-		Template       string            `yaml:"template,omitempty"`
-		ContentType    string            `yaml:"content_type,omitempty"`
-		Fields         map[string]string `yaml:"fields,omitempty"`
-		IncludeTitle   bool              `yaml:"include_title,omitempty"`
-		IncludeMessage bool              `yaml:"include_message,omitempty"`
-		TitleField     string            `yaml:"title_field,omitempty"`
-		MessageField   string            `yaml:"message_field,omitempty"`
+		Template       string            `yaml:"template,omitempty" description:"Go template of the payload"`
+		ContentType    string            `yaml:"content_type,omitempty" description:"Content type of the payload"`
+		Fields         map[string]string `yaml:"fields,omitempty" description:"Additional fields of the JSON payload"`
+		IncludeTitle   bool              `yaml:"include_title,omitempty" description:"Include the title in the payload"`
+		IncludeMessage bool              `yaml:"include_message,omitempty" description:"Include the message in the payload"`
+		TitleField     string            `yaml:"title_field,omitempty" description:"Name of the title field"`
+		MessageField   string            `yaml:"message_field,omitempty" description:"Name of the message field"`
 	}
 
 	// WebhookConfig defines generic webhook notification settings
 	WebhookConfig struct {
-		URL           string               `yaml:"url,omitempty"`
-		Method        string               `yaml:"method,omitempty"`
-		Headers       map[string]string    `yaml:"headers,omitempty"`
-		ContentType   string               `yaml:"content_type,omitempty"`
-		CustomPayload *CustomPayloadConfig `yaml:"custom_payload,omitempty"`
-		AuthType      string               `yaml:"auth_type,omitempty"`
-		AuthToken     string               `yaml:"auth_token,omitempty"`
-		AuthUsername  string               `yaml:"auth_username,omitempty"`
-		AuthPassword  string               `yaml:"auth_password,omitempty"`
-		AuthHeader    string               `yaml:"auth_header,omitempty"`
-		Retries       int                  `yaml:"retries,omitempty"`
-		Timeout       int                  `yaml:"timeout,omitempty"`
-		SkipTLSVerify bool                 `yaml:"skip_tls_verify,omitempty"`
+		URL           string               `yaml:"url,omitempty" description:"URL of the webhook"`
+		Method        string               `yaml:"method,omitempty" description:"HTTP method of the request, POST if empty"`
+		Headers       map[string]string    `yaml:"headers,omitempty" description:"HTTP headers of the request"`
+		ContentType   string               `yaml:"content_type,omitempty" description:"Content type of the request"`
+		CustomPayload *CustomPayloadConfig `yaml:"custom_payload,omitempty" description:"Custom payload of the request"`
+		AuthType      string               `yaml:"auth_type,omitempty" description:"Authentication of the request" enum:"bearer,basic,apikey"`
+		AuthToken     string               `yaml:"auth_token,omitempty" description:"Token of bearer and apikey authentication"`
+		AuthUsername  string               `yaml:"auth_username,omitempty" description:"Username of basic authentication"`
+		AuthPassword  string               `yaml:"auth_password,omitempty" description:"Password of basic authentication"`
+		AuthHeader    string               `yaml:"auth_header,omitempty" description:"Header of apikey authentication, X-API-Key if empty"`
+
+		// SigningSecret enables HMAC-SHA256 signing of "<timestamp>.<body>", the signature is sent
+		// as sha256=<hex> in SignatureHeader and the Unix timestamp in TimestampHeader
+		SigningSecret     string `yaml:"signing_secret,omitempty" description:"Secret signing the requests with HMAC-SHA256"`
+		SignatureHeader   string `yaml:"signature_header,omitempty" description:"Header of the signature"`
+		TimestampHeader   string `yaml:"timestamp_header,omitempty" description:"Header of the timestamp of the signature"`
+		IdempotencyHeader string `yaml:"idempotency_header,omitempty" description:"Header of the idempotency key"`
+
+		Retries       int  `yaml:"retries,omitempty" description:"Number of retries of a failed request"`
+		Timeout       int  `yaml:"timeout,omitempty" description:"Timeout of each request in seconds"`
+		SkipTLSVerify bool `yaml:"skip_tls_verify,omitempty" description:"Skip the verification of the certificate of the server"`
+	}
+
+	// TelegramConfig defines Telegram bot notification settings
+	TelegramConfig struct {
+		BotToken            string `yaml:"bot_token,omitempty" description:"Token of the bot"`
+		ChatID              string `yaml:"chat_id,omitempty" description:"ID of the chat"`
+		ParseMode           string `yaml:"parse_mode,omitempty" description:"Parse mode of the message" enum:"HTML,Markdown,MarkdownV2"`
+		DisableNotification bool   `yaml:"disable_notification,omitempty" description:"Send the message silently"`
+		APIURL              string `yaml:"api_url,omitempty" description:"URL of the Bot API"`
+		Retries             int    `yaml:"retries,omitempty" description:"Number of retries of a failed request"`
+		Timeout             int    `yaml:"timeout,omitempty" description:"Timeout of each request in seconds"`
+	}
+
+	// DingTalkConfig defines DingTalk custom robot notification settings
+	DingTalkConfig struct {
+		WebhookURL string   `yaml:"webhook_url,omitempty" description:"Webhook URL of the robot"`
+		Secret     string   `yaml:"secret,omitempty" description:"Signing secret of the robot"`
+		AtMobiles  []string `yaml:"at_mobiles,omitempty" description:"Mobile numbers mentioned"`
+		AtAll      bool     `yaml:"at_all,omitempty" description:"Mention everyone"`
+		Retries    int      `yaml:"retries,omitempty" description:"Number of retries of a failed request"`
+		Timeout    int      `yaml:"timeout,omitempty" description:"Timeout of each request in seconds"`
+	}
+
+	// FeishuConfig defines Feishu/Lark custom bot notification settings
+	FeishuConfig struct {
+		WebhookURL string `yaml:"webhook_url,omitempty" description:"Webhook URL of the bot"`
+		Secret     string `yaml:"secret,omitempty" description:"Signing secret of the bot"`
+		Retries    int    `yaml:"retries,omitempty" description:"Number of retries of a failed request"`
+		Timeout    int    `yaml:"timeout,omitempty" description:"Timeout of each request in seconds"`
+	}
+
+	// WeComConfig defines WeCom (WeChat Work) group robot notification settings
+	WeComConfig struct {
+		WebhookURL          string   `yaml:"webhook_url,omitempty" description:"Webhook URL of the robot"`
+		MentionedList       []string `yaml:"mentioned_list,omitempty" description:"User IDs mentioned"`
+		MentionedMobileList []string `yaml:"mentioned_mobile_list,omitempty" description:"Mobile numbers mentioned"`
+		Retries             int      `yaml:"retries,omitempty" description:"Number of retries of a failed request"`
+		Timeout             int      `yaml:"timeout,omitempty" description:"Timeout of each request in seconds"`
+	}
+
+	// PagerDutyConfig defines PagerDuty Events API v2 notification settings
+	PagerDutyConfig struct {
+		RoutingKey string `yaml:"routing_key,omitempty" description:"Integration key of the service"`
+		Severity   string `yaml:"severity,omitempty" description:"Severity of outages, critical if empty" enum:"critical,error,warning,info"`
+		Source     string `yaml:"source,omitempty" description:"Source of the events"`
+		APIURL     string `yaml:"api_url,omitempty" description:"URL of the Events API"`
+		Retries    int    `yaml:"retries,omitempty" description:"Number of retries of a failed request"`
+		Timeout    int    `yaml:"timeout,omitempty" description:"Timeout of each request in seconds"`
+	}
+
+	// OpsgenieConfig defines Opsgenie Alert API notification settings
+	OpsgenieConfig struct {
+		APIKey   string   `yaml:"api_key,omitempty" description:"API key of the integration"`
+		Priority string   `yaml:"priority,omitempty" description:"Priority of outages" enum:"P1,P2,P3,P4,P5"`
+		Tags     []string `yaml:"tags,omitempty" description:"Tags of the alerts"`
+		Team     string   `yaml:"team,omitempty" description:"Team responsible for the alerts"`
+		APIURL   string   `yaml:"api_url,omitempty" description:"URL of the Alert API"`
+		Retries  int      `yaml:"retries,omitempty" description:"Number of retries of a failed request"`
+		Timeout  int      `yaml:"timeout,omitempty" description:"Timeout of each request in seconds"`
+	}
+
+	// NtfyConfig defines ntfy push notification settings
+	NtfyConfig struct {
+		ServerURL string   `yaml:"server_url,omitempty" description:"URL of the ntfy server"`
+		Topic     string   `yaml:"topic,omitempty" description:"Topic of the messages"`
+		Token     string   `yaml:"token,omitempty" description:"Access token"`
+		Username  string   `yaml:"username,omitempty" description:"Username of basic authentication"`
+		Password  string   `yaml:"password,omitempty" description:"Password of basic authentication"`
+		Tags      []string `yaml:"tags,omitempty" description:"Tags of the messages"`
+		Retries   int      `yaml:"retries,omitempty" description:"Number of retries of a failed request"`
+		Timeout   int      `yaml:"timeout,omitempty" description:"Timeout of each request in seconds"`
+	}
+
+	// GotifyConfig defines Gotify push notification settings
+	GotifyConfig struct {
+		ServerURL string `yaml:"server_url,omitempty" description:"URL of the Gotify server"`
+		Token     string `yaml:"token,omitempty" description:"Application token"`
+		Retries   int    `yaml:"retries,omitempty" description:"Number of retries of a failed request"`
+		Timeout   int    `yaml:"timeout,omitempty" description:"Timeout of each request in seconds"`
+	}
+
+	// PushoverConfig defines Pushover push notification settings
+	PushoverConfig struct {
+		Token   string `yaml:"token,omitempty" description:"Application token"`
+		UserKey string `yaml:"user_key,omitempty" description:"User or group key"`
+		Device  string `yaml:"device,omitempty" description:"Device the messages are sent to, all devices if empty"`
+		Sound   string `yaml:"sound,omitempty" description:"Sound of the messages"`
+		APIURL  string `yaml:"api_url,omitempty" description:"URL of the Pushover API"`
+		Retries int    `yaml:"retries,omitempty" description:"Number of retries of a failed request"`
+		Timeout int    `yaml:"timeout,omitempty" description:"Timeout of each request in seconds"`
+	}
+
+	// MatrixConfig defines Matrix room notification settings
+	MatrixConfig struct {
+		HomeserverURL string `yaml:"homeserver_url,omitempty" description:"URL of the homeserver"`
+		AccessToken   string `yaml:"access_token,omitempty" description:"Access token of the sender"`
+		RoomID        string `yaml:"room_id,omitempty" description:"ID of the room"`
+		Retries       int    `yaml:"retries,omitempty" description:"Number of retries of a failed request"`
+		Timeout       int    `yaml:"timeout,omitempty" description:"Timeout of each request in seconds"`
+	}
+
+	// CommandConfig defines settings for running a local command as a notification channel
+	CommandConfig struct {
+		Path    string            `yaml:"path" description:"Path of the command"`
+		Args    []string          `yaml:"args,omitempty" description:"Arguments of the command"`
+		Env     map[string]string `yaml:"env,omitempty" description:"Additional environment variables"`
+		WorkDir string            `yaml:"work_dir,omitempty" description:"Working directory of the command"`
+		Timeout int               `yaml:"timeout,omitempty" description:"Timeout of the command in seconds"`
 	}
 )
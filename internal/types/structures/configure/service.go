@@ -3,23 +3,65 @@ package configure
 type (
 	// Service defines the configuration for a service, including its health and Endpoints ports
 	Service struct {
-		Name          string     `yaml:"name"`
-		Endpoints     []Endpoint `yaml:"endpoints"`
-		Timeout       int        `yaml:"timeout,omitempty"`
-		MaxRetryTimes int        `yaml:"max_retry_times,omitempty"`
+		Name          string     `yaml:"name" description:"Unique name of the service"`
+		Group         string     `yaml:"group,omitempty" description:"Group the service is listed under on the status page"`
+		Tags          []string   `yaml:"tags,omitempty" description:"Tags of the service, used to filter the report, the status API and notifications"`
+		Endpoints     []Endpoint `yaml:"endpoints" description:"Endpoints checked for the service"`
+		Timeout       int        `yaml:"timeout,omitempty" description:"Timeout of each request in seconds, overrides the global timeout and defaults"`
+		MaxRetryTimes int        `yaml:"max_retry_times,omitempty" description:"Number of retries of a failed request, overrides the global setting and defaults"`
+
+		// Defaults are inherited by the endpoints of the service and override the global defaults
+		Defaults *EndpointDefaults `yaml:"defaults,omitempty" description:"Settings inherited by the endpoints of the service"`
+
+		// DependsOn lists the services this service depends on. While one of them is unavailable, failures of
+		// this service are reported as impacted instead of as an outage of its own.
+		DependsOn []string `yaml:"depends_on,omitempty" description:"Names of the services this service depends on, its failures are reported as impacted while one of them is unavailable"`
+
+		// Maintenance windows during which the service is checked and logged, but not alerted on
+		Maintenance []MaintenanceWindow `yaml:"maintenance,omitempty" description:"Maintenance windows during which failures are not alerted on"`
+	}
+
+	// MaintenanceWindow defines a one-off window from Start to End, or a recurring window starting
+	// whenever the five-field Cron expression fires and lasting for Duration, e.g. "2h".
+	// Times without offset and cron expressions use Timezone, or local time if it is empty.
+	MaintenanceWindow struct {
+		Description string `yaml:"description,omitempty" description:"Description shown in the report"`
+		Start       string `yaml:"start,omitempty" description:"Start of a one-off window, e.g. 2025-03-05 02:00"`
+		End         string `yaml:"end,omitempty" description:"End of a one-off window, e.g. 2025-03-05 04:00"`
+		Cron        string `yaml:"cron,omitempty" description:"Five-field cron expression starting a recurring window"`
+		Duration    string `yaml:"duration,omitempty" description:"Duration of a recurring window, e.g. 2h"`
+		Timezone    string `yaml:"timezone,omitempty" description:"IANA time zone of the times and the cron expression, local time if empty"`
 	}
 
 	// Endpoint defines the configuration for a port
 	Endpoint struct {
-		URL                 string            `yaml:"url"`
+		URL                 string            `yaml:"url" description:"URL to request, supports special parameters"`
 		ParsedURL           string            `yaml:"-"`
-		Method              string            `yaml:"method,omitempty"`
-		Headers             map[string]string `yaml:"headers,omitempty"`
+		Method              string            `yaml:"method,omitempty" description:"HTTP method of the request" enum:"GET,POST,PUT"`
+		Headers             map[string]string `yaml:"headers,omitempty" description:"HTTP headers of the request, support special parameters"`
 		ParsedHeaders       map[string]string `yaml:"-"`
-		Body                string            `yaml:"body,omitempty"`
+		Body                string            `yaml:"body,omitempty" description:"Body of the request, supports special parameters"`
 		ParsedBody          string            `yaml:"-"`
-		StatusCode          int               `yaml:"status_code,omitempty"`
-		ResponseRegex       string            `yaml:"response_regex,omitempty"`
+		StatusCode          int               `yaml:"status_code,omitempty" description:"Expected HTTP status code, 200 if empty" minimum:"100" maximum:"599"`
+		ResponseRegex       string            `yaml:"response_regex,omitempty" description:"Regular expression the response body must match, supports special parameters"`
 		ParsedResponseRegex string            `yaml:"-"`
+		Timeout             int               `yaml:"timeout,omitempty" description:"Timeout of each request in seconds"`
+		MaxRetryTimes       int               `yaml:"max_retry_times,omitempty" description:"Number of retries of a failed request"`
+		SkipTLSVerify       *bool             `yaml:"skip_tls_verify,omitempty" description:"Skip the verification of the certificate of the server"`
+		Tags                []string          `yaml:"tags,omitempty" description:"Tags of the endpoint, used to filter the report, the status API and notifications"`
+	}
+
+	// EndpointDefaults defines the settings endpoints inherit from the defaults of their service and from
+	// the global defaults, unless they set them. Headers are inherited by name and tags are added to
+	// the tags of the endpoint.
+	EndpointDefaults struct {
+		Method        string            `yaml:"method,omitempty" description:"HTTP method of the requests" enum:"GET,POST,PUT"`
+		Headers       map[string]string `yaml:"headers,omitempty" description:"HTTP headers of the requests, support special parameters"`
+		StatusCode    int               `yaml:"status_code,omitempty" description:"Expected HTTP status code" minimum:"100" maximum:"599"`
+		ResponseRegex string            `yaml:"response_regex,omitempty" description:"Regular expression the response bodies must match, supports special parameters"`
+		Timeout       int               `yaml:"timeout,omitempty" description:"Timeout of each request in seconds"`
+		MaxRetryTimes int               `yaml:"max_retry_times,omitempty" description:"Number of retries of a failed request"`
+		SkipTLSVerify *bool             `yaml:"skip_tls_verify,omitempty" description:"Skip the verification of the certificates of the servers"`
+		Tags          []string          `yaml:"tags,omitempty" description:"Tags added to the endpoints"`
 	}
 )
@@ -0,0 +1,42 @@
+package configure
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Error returns the position, the path and the message of the validation error,
+// e.g. "config.yaml:5:14: services[0].endpoints[0].url: URL is empty"
+func (e ValidationError) Error() string {
+	message := e.Message
+	if e.Path != "" {
+		message = e.Path + ": " + message
+	}
+
+	position := e.File
+	if e.Line > 0 {
+		position += fmt.Sprintf(":%d", e.Line)
+		if e.Column > 0 {
+			position += fmt.Sprintf(":%d", e.Column)
+		}
+	}
+	position = strings.TrimPrefix(position, ":")
+	if position == "" {
+		return message
+	}
+	return position + ": " + message
+}
+
+// Error returns the validation errors, one per line
+func (errs ValidationErrors) Error() string {
+	messages := make([]string, len(errs))
+	for i, validationError := range errs {
+		messages[i] = validationError.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Add appends a validation error for the given path
+func (errs *ValidationErrors) Add(path, message string) {
+	*errs = append(*errs, ValidationError{Path: path, Message: message})
+}
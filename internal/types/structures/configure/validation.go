@@ -0,0 +1,16 @@
+package configure
+
+type (
+	// ValidationError is a problem found in the configuration at Path, e.g. "services[0].endpoints[1].url".
+	// File, Line and Column locate it in the YAML file, Line and Column are 0 if unknown.
+	ValidationError struct {
+		Path    string
+		Message string
+		File    string
+		Line    int
+		Column  int
+	}
+
+	// ValidationErrors holds all problems found in the configuration
+	ValidationErrors []ValidationError
+)
@@ -0,0 +1,28 @@
+package incident
+
+import "html/template"
+
+type (
+	// Incident is a period in which a service was partially or fully unavailable, derived from the log
+	Incident struct {
+		ID          string   `json:"id"`
+		ServiceName string   `json:"service_name"`
+		Start       string   `json:"start"`
+		End         string   `json:"end,omitempty"`
+		Endpoints   []string `json:"endpoints"`
+
+		// PeakFailures is the highest number of endpoints that failed in the same run
+		PeakFailures int `json:"peak_failures"`
+
+		// FirstError and LastError are the kind and status code of the first and the latest failures
+		// recorded while the incident was ongoing, without the failure details as they may contain secrets
+		FirstError string `json:"first_error,omitempty"`
+		LastError  string `json:"last_error,omitempty"`
+
+		// Notes is the rendered markdown notes file of the incident, it is not persisted
+		Notes template.HTML `json:"-"`
+	}
+
+	// Incidents holds the incidents of all services, the most recent first
+	Incidents []Incident
+)
@@ -0,0 +1,6 @@
+package incident
+
+// IsOngoing checks if the incident has not ended yet
+func (i Incident) IsOngoing() bool {
+	return i.End == ""
+}
@@ -1,5 +1,7 @@
 package logger
 
+import "time"
+
 type (
 	// HistoryEntry represents a single history entry
 	HistoryEntry struct {
@@ -19,4 +21,11 @@ type (
 
 	// Logger represents the entire log structure
 	Logger map[string]Service
+
+	// Outage is a period in which an endpoint was unavailable, Ongoing is set if it had not
+	// ended by the end of the examined period
+	Outage struct {
+		Start, End time.Time
+		Ongoing    bool
+	}
 )
@@ -3,6 +3,8 @@ package logger
 import (
 	"log"
 	"time"
+
+	"github.com/wcy-dt/ponghub/internal/types/types/chk_result"
 )
 
 // CleanExpiredEntries removes entries older than maxDays from the history entry list.
@@ -34,3 +36,51 @@ func (h History) AddEntry(entry HistoryEntry) History {
 	newHistory := append(h, entry)
 	return newHistory
 }
+
+// Outages returns the periods between from and to in which the endpoint of the history was
+// unavailable, clipped to that range. Entries logged during maintenance or while the endpoint was
+// impacted by a service it depends on neither start nor end an outage.
+func (h History) Outages(from, to time.Time) []Outage {
+	var outages []Outage
+	var start time.Time
+	inOutage := false
+
+	for _, entry := range h {
+		entryTime, err := time.Parse(time.RFC3339, entry.Time)
+		if err != nil {
+			continue
+		}
+		if entryTime.After(to) {
+			break
+		}
+
+		switch chk_result.ParseCheckResult(entry.Status) {
+		case chk_result.NONE:
+			if !inOutage {
+				inOutage = true
+				start = entryTime
+			}
+		case chk_result.MAINTENANCE, chk_result.IMPACTED:
+		default:
+			if inOutage {
+				inOutage = false
+				if entryTime.After(from) {
+					outages = append(outages, Outage{Start: latest(start, from), End: entryTime})
+				}
+			}
+		}
+	}
+
+	if inOutage {
+		outages = append(outages, Outage{Start: latest(start, from), End: to, Ongoing: true})
+	}
+	return outages
+}
+
+// latest returns the later of the two times
+func latest(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,69 @@
+package notifier
+
+type (
+	// DeliveryResult describes the delivery of a notification through a single channel
+	DeliveryResult struct {
+		Channel   string `json:"channel"`
+		Attempts  int    `json:"attempts"`
+		LatencyMs int64  `json:"latency_ms"`
+		Error     string `json:"error,omitempty"`
+
+		// Skipped is set for plain text channels when there is no message to send
+		Skipped bool `json:"skipped,omitempty"`
+
+		// Retry is set for notifications retried from the outbox of an earlier run
+		Retry bool `json:"retry,omitempty"`
+
+		// Queued is set if the notification was not delivered and is retried in a later run
+		Queued bool `json:"queued,omitempty"`
+
+		// Muted is set if the channel is in quiet hours
+		Muted bool `json:"muted,omitempty"`
+
+		// Digest is set for the delivery of the daily or weekly digest
+		Digest bool `json:"digest,omitempty"`
+	}
+
+	// DeliveryReport is the outcome of sending a notification through all configured channels
+	DeliveryReport struct {
+		Time    string           `json:"time"`
+		Title   string           `json:"title"`
+		Results []DeliveryResult `json:"results"`
+	}
+)
+
+// Failed returns the results of the channels the notification could not be delivered to
+func (r *DeliveryReport) Failed() []DeliveryResult {
+	if r == nil {
+		return nil
+	}
+	var failed []DeliveryResult
+	for _, result := range r.Results {
+		if result.Error != "" {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Delivered checks if the notification was delivered to at least one channel
+func (r *DeliveryReport) Delivered() bool {
+	if r == nil {
+		return false
+	}
+	for _, result := range r.Results {
+		if result.Error == "" && !result.Skipped && !result.Muted {
+			return true
+		}
+	}
+	return false
+}
+
+// FailedChannels returns the names of the channels the notification could not be delivered to
+func (r *DeliveryReport) FailedChannels() []string {
+	var channels []string
+	for _, result := range r.Failed() {
+		channels = append(channels, result.Channel)
+	}
+	return channels
+}
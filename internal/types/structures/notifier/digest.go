@@ -0,0 +1,43 @@
+package notifier
+
+import "time"
+
+type (
+	// Digest is a summary of the checks of all services over a period
+	Digest struct {
+		Weekly       bool
+		From, To     time.Time
+		Services     []DigestService
+		Slowest      []DigestEndpoint
+		Certificates []DigestCertificate
+	}
+
+	// DigestService summarizes the availability and incidents of a service
+	DigestService struct {
+		Name         string
+		Availability float64
+		Incidents    int
+		Downtime     time.Duration
+	}
+
+	// DigestEndpoint summarizes the response times of an endpoint in milliseconds
+	DigestEndpoint struct {
+		ServiceName     string
+		URL             string
+		AvgResponseTime int
+		MaxResponseTime int
+	}
+
+	// DigestCertificate is a certificate that expires soon or has expired
+	DigestCertificate struct {
+		ServiceName   string
+		URL           string
+		RemainingDays int
+		Expired       bool
+	}
+
+	// DigestState records when the last digest was sent
+	DigestState struct {
+		LastSent string `json:"last_sent"`
+	}
+)
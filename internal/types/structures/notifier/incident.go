@@ -0,0 +1,15 @@
+package notifier
+
+type (
+	// Incident is an ongoing outage of an endpoint, tracked across runs to escalate notifications
+	Incident struct {
+		ServiceName  string   `json:"service_name"`
+		URL          string   `json:"url"`
+		Started      string   `json:"started"`
+		Acknowledged string   `json:"acknowledged,omitempty"`
+		Escalated    []string `json:"escalated,omitempty"`
+	}
+
+	// Incidents holds the open incidents keyed by the dedup key of their endpoint
+	Incidents map[string]*Incident
+)
@@ -0,0 +1,31 @@
+package notifier
+
+import "github.com/wcy-dt/ponghub/internal/types/types/event_type"
+
+type (
+	// Event describes a status change or problem of a single endpoint
+	Event struct {
+		Type              event_type.EventType `json:"type"`
+		ServiceName       string               `json:"service_name"`
+		URL               string               `json:"url"`
+		Method            string               `json:"method,omitempty"`
+		StatusCode        int                  `json:"status_code,omitempty"`
+		Error             string               `json:"error,omitempty"`
+		CertRemainingDays int                  `json:"cert_remaining_days,omitempty"`
+		Time              string               `json:"time"`
+	}
+
+	// Notification is a rendered alert together with the events it was generated from
+	Notification struct {
+		Title   string  `json:"title"`
+		Message string  `json:"message"`
+		Events  []Event `json:"events,omitempty"`
+
+		// StatusPageURL links to the published status page for click-through
+		StatusPageURL string `json:"status_page_url,omitempty"`
+
+		// ReportMissing is set when the report could not be generated in this run, so that the report
+		// of a previous run is not attached
+		ReportMissing bool `json:"-"`
+	}
+)
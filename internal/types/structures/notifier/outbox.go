@@ -0,0 +1,18 @@
+package notifier
+
+type (
+	// OutboxEntry is a notification that could not be delivered to a channel and is retried in later runs
+	OutboxEntry struct {
+		ID           string       `json:"id"`
+		Channel      string       `json:"channel"`
+		Fingerprint  string       `json:"fingerprint"`
+		Notification Notification `json:"notification"`
+		Created      string       `json:"created"`
+		Attempts     int          `json:"attempts"`
+		NextAttempt  string       `json:"next_attempt"`
+		LastError    string       `json:"last_error,omitempty"`
+	}
+
+	// Outbox holds the pending notifications of all channels, in the order they were created
+	Outbox []OutboxEntry
+)
@@ -0,0 +1,70 @@
+package notifier
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// DedupKey returns a stable key identifying the endpoint of the event across runs,
+// derived from the service name and the (unresolved) endpoint URL
+func (e Event) DedupKey() string {
+	sum := sha256.Sum256([]byte(e.ServiceName + "\n" + e.URL))
+	return "ponghub-" + hex.EncodeToString(sum[:16])
+}
+
+// CertDedupKey returns a stable key for certificate events, kept separate from the
+// availability incident of the same endpoint
+func (e Event) CertDedupKey() string {
+	return e.DedupKey() + "-cert"
+}
+
+// HasProblems checks if the notification contains at least one problem event
+func (n *Notification) HasProblems() bool {
+	for _, event := range n.Events {
+		if event.Type.IsProblem() {
+			return true
+		}
+	}
+	return false
+}
+
+// Fingerprint returns a key identifying the content of the notification regardless of when it
+// was generated, derived from the title and the type and endpoint of every event, or the message
+// if there are no events
+func (n *Notification) Fingerprint() string {
+	var content strings.Builder
+	content.WriteString(n.Title)
+	for _, event := range n.Events {
+		content.WriteString("\n" + event.Type.String() + " " + event.DedupKey())
+	}
+	if len(n.Events) == 0 {
+		content.WriteString("\n" + n.Message)
+	}
+	sum := sha256.Sum256([]byte(content.String()))
+	return hex.EncodeToString(sum[:16])
+}
+
+// IdempotencyKey returns a key identifying this notification, which stays the same when it is
+// delivered again but differs from the notifications of other runs, derived from the title, the
+// message and the type, endpoint and time of every event
+func (n *Notification) IdempotencyKey() string {
+	var content strings.Builder
+	content.WriteString(n.Title + "\n" + n.Message)
+	for _, event := range n.Events {
+		content.WriteString("\n" + event.Type.String() + " " + event.DedupKey() + " " + event.Time)
+	}
+	sum := sha256.Sum256([]byte(content.String()))
+	return hex.EncodeToString(sum[:16])
+}
+
+// Pending returns the entries of the outbox for the given channel, in order
+func (o Outbox) Pending(channel string) Outbox {
+	var entries Outbox
+	for _, entry := range o {
+		if entry.Channel == channel {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
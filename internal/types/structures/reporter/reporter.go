@@ -6,21 +6,24 @@ import "github.com/wcy-dt/ponghub/internal/types/types/highlight"
 type (
 	// HistoryEntry represents a single history entry
 	HistoryEntry struct {
-		Time         string
-		Status       string
-		ResponseTime int
+		Time         string `json:"time"`
+		Status       string `json:"status"`
+		ResponseTime int    `json:"response_time"`
 	}
 
 	History []HistoryEntry
 
 	Endpoint struct {
-		URL               string // Added URL field to store the endpoint URL
-		EndpointHistory   History
-		IsHTTPS           bool
-		IsCertExpired     bool
-		CertRemainingDays int
-		DisplayURL        string              // Resolved URL for display
-		HighlightSegments []highlight.Segment // Segments with highlight info
+		URL               string   `json:"url"` // Added URL field to store the endpoint URL
+		Tags              []string `json:"tags,omitempty"`
+		EndpointHistory   History  `json:"history"`
+		IsHTTPS           bool     `json:"is_https,omitempty"`
+		IsCertExpired     bool     `json:"is_cert_expired,omitempty"`
+		CertRemainingDays int      `json:"cert_remaining_days,omitempty"`
+
+		// DisplayURL and HighlightSegments are only rendered in the report, they may contain resolved parameters
+		DisplayURL        string              `json:"-"` // Resolved URL for display
+		HighlightSegments []highlight.Segment `json:"-"` // Segments with highlight info
 	}
 
 	// Endpoints is a slice of Endpoint
@@ -28,12 +31,30 @@ type (
 
 	// Service represents the result of checking a service
 	Service struct {
-		Name           string // Added Name field to identify the service
-		ServiceHistory History
-		Availability   float64
-		Endpoints      Endpoints
+		Name           string    `json:"name"` // Added Name field to identify the service
+		Group          string    `json:"group,omitempty"`
+		Tags           []string  `json:"tags,omitempty"`
+		DependsOn      []string  `json:"depends_on,omitempty"`
+		ImpactedBy     string    `json:"impacted_by,omitempty"`
+		ServiceHistory History   `json:"history"`
+		Availability   float64   `json:"availability"`
+		Endpoints      Endpoints `json:"endpoints"`
 	}
 
 	// Reporter is a slice of Service
 	Reporter []Service
+
+	// Group is a group of services on the status page with the merged status of their latest checks.
+	// Services without a group are listed in a group without name.
+	Group struct {
+		Name     string   `json:"name"`
+		Status   string   `json:"status"`
+		Services Reporter `json:"services"`
+	}
+
+	// Status is the JSON status of the services written next to the report and served by the status API
+	Status struct {
+		UpdateTime string  `json:"update_time"`
+		Groups     []Group `json:"groups"`
+	}
 )
@@ -68,6 +68,7 @@ func ParseLogResult(logResult logger.Logger, serviceNames []string, cfg *configu
 					endpointHistory := convertToHistory(endpointLog, cfg.DisplayNum)
 					endpoints = append(endpoints, Endpoint{
 						URL:             url,
+						Tags:            endpointConfig.Tags,
 						EndpointHistory: endpointHistory,
 					})
 				}
@@ -91,6 +92,11 @@ func ParseLogResult(logResult logger.Logger, serviceNames []string, cfg *configu
 			ServiceHistory: serviceHistory,
 			Endpoints:      endpoints,
 		}
+		if serviceConfig != nil {
+			newService.Group = serviceConfig.Group
+			newService.Tags = serviceConfig.Tags
+			newService.DependsOn = serviceConfig.DependsOn
+		}
 		report = append(report, newService)
 	}
 	return report
@@ -0,0 +1,24 @@
+package schema
+
+type (
+	// Schema is a JSON Schema (draft-07) describing the configuration file or one of its values.
+	// AdditionalProperties is false or the *Schema of the values of a map.
+	Schema struct {
+		Schema               string             `json:"$schema,omitempty"`
+		Ref                  string             `json:"$ref,omitempty"`
+		Title                string             `json:"title,omitempty"`
+		Description          string             `json:"description,omitempty"`
+		Type                 Types              `json:"type,omitempty"`
+		Properties           map[string]*Schema `json:"properties,omitempty"`
+		AdditionalProperties any                `json:"additionalProperties,omitempty"`
+		Items                *Schema            `json:"items,omitempty"`
+		Enum                 []string           `json:"enum,omitempty"`
+		Minimum              *int               `json:"minimum,omitempty"`
+		Maximum              *int               `json:"maximum,omitempty"`
+		Default              any                `json:"default,omitempty"`
+		Definitions          map[string]*Schema `json:"definitions,omitempty"`
+	}
+
+	// Types lists the JSON types a value may have, e.g. "object" and "null"
+	Types []string
+)
@@ -0,0 +1,11 @@
+package schema
+
+import "encoding/json"
+
+// MarshalJSON encodes a single type as a string and several types as an array
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
@@ -12,6 +12,12 @@ const (
 	// NONE represents no ports are online
 	NONE CheckResult = "none"
 
+	// MAINTENANCE represents a service checked during one of its maintenance windows
+	MAINTENANCE CheckResult = "maintenance"
+
+	// IMPACTED represents a failing service whose failure is caused by a service it depends on
+	IMPACTED CheckResult = "impacted"
+
 	// UNKNOWN represents an unknown test result
 	UNKNOWN CheckResult = "unknown"
 )
@@ -25,6 +31,10 @@ func (tr CheckResult) String() string {
 		return "part"
 	case NONE:
 		return "none"
+	case MAINTENANCE:
+		return "maintenance"
+	case IMPACTED:
+		return "impacted"
 	default:
 		return "unknown"
 	}
@@ -32,7 +42,7 @@ func (tr CheckResult) String() string {
 
 // IsValid checks if the CheckResult is valid
 func (tr CheckResult) IsValid() bool {
-	return tr == ALL || tr == PART || tr == NONE
+	return tr == ALL || tr == PART || tr == NONE || tr == MAINTENANCE || tr == IMPACTED
 }
 
 // IsALL checks if the CheckResult is ALL
@@ -40,6 +50,11 @@ func IsALL(resultStr string) bool {
 	return ParseCheckResult(resultStr) == ALL
 }
 
+// IsMaintenance checks if the CheckResult is MAINTENANCE
+func IsMaintenance(resultStr string) bool {
+	return ParseCheckResult(resultStr) == MAINTENANCE
+}
+
 // ParseCheckResult parses a string into a CheckResult
 func ParseCheckResult(s string) CheckResult {
 	switch s {
@@ -49,6 +64,10 @@ func ParseCheckResult(s string) CheckResult {
 		return PART
 	case "none":
 		return NONE
+	case "maintenance":
+		return MAINTENANCE
+	case "impacted":
+		return IMPACTED
 	default:
 		return UNKNOWN
 	}
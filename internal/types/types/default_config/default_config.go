@@ -1,5 +1,10 @@
 package default_config
 
+import (
+	"cmp"
+	"path/filepath"
+)
+
 const (
 	// timeout is the default timeout for service checks in seconds
 	timeout = 5
@@ -62,6 +67,96 @@ func SetDefaultCertNotifyDays(cfg *int) {
 	}
 }
 
+const (
+	// deliveryTimeout is the default deadline for delivering a notification through all channels in seconds
+	deliveryTimeout = 120
+)
+
+// GetDefaultDeliveryTimeout returns the default deadline for delivering a notification through all channels
+func GetDefaultDeliveryTimeout() int {
+	return deliveryTimeout
+}
+
+// SetDefaultDeliveryTimeout sets the default delivery deadline for a given configuration pointer
+func SetDefaultDeliveryTimeout(cfg *int) {
+	if *cfg <= 0 {
+		*cfg = GetDefaultDeliveryTimeout()
+	}
+}
+
+const (
+	// outboxMaxAge is the default number of hours after which a queued notification is dropped
+	outboxMaxAge = 24
+
+	// outboxMaxAttempts is the default number of attempts after which a queued notification is dropped
+	outboxMaxAttempts = 20
+)
+
+// GetDefaultOutboxMaxAge returns the default number of hours after which a queued notification is dropped
+func GetDefaultOutboxMaxAge() int {
+	return outboxMaxAge
+}
+
+// GetDefaultOutboxMaxAttempts returns the default number of attempts after which a queued notification is dropped
+func GetDefaultOutboxMaxAttempts() int {
+	return outboxMaxAttempts
+}
+
+const (
+	// dailyDigestSchedule and weeklyDigestSchedule are the default cron schedules of the digest
+	dailyDigestSchedule  = "0 9 * * *"
+	weeklyDigestSchedule = "0 9 * * 1"
+
+	// digestSlowest is the default number of slowest endpoints listed in the digest
+	digestSlowest = 5
+
+	// digestCertDays is the default number of days before expiration from which certificates are listed in the digest
+	digestCertDays = 30
+)
+
+// GetDefaultDigestSchedule returns the default cron schedule of the digest, every Monday for weekly digests
+// and every day otherwise
+func GetDefaultDigestSchedule(weekly bool) string {
+	if weekly {
+		return weeklyDigestSchedule
+	}
+	return dailyDigestSchedule
+}
+
+// GetDefaultDigestSlowest returns the default number of slowest endpoints listed in the digest
+func GetDefaultDigestSlowest() int {
+	return digestSlowest
+}
+
+// SetDefaultDigestSlowest sets the default number of slowest endpoints for a given configuration pointer
+func SetDefaultDigestSlowest(cfg *int) {
+	if *cfg <= 0 {
+		*cfg = GetDefaultDigestSlowest()
+	}
+}
+
+// GetDefaultDigestCertDays returns the default number of days before expiration from which certificates are listed in the digest
+func GetDefaultDigestCertDays() int {
+	return digestCertDays
+}
+
+// SetDefaultDigestCertDays sets the default number of certificate days for a given configuration pointer
+func SetDefaultDigestCertDays(cfg *int) {
+	if *cfg <= 0 {
+		*cfg = GetDefaultDigestCertDays()
+	}
+}
+
+const (
+	// incidentRetentionDays is the default number of days after which ended incidents are dropped
+	incidentRetentionDays = 90
+)
+
+// GetDefaultIncidentRetentionDays returns the default number of days after which ended incidents are dropped
+func GetDefaultIncidentRetentionDays() int {
+	return incidentRetentionDays
+}
+
 const (
 	// displayNum is the default number of logs per endpoint to display in the HTML report
 	displayNum = 72
@@ -80,43 +175,199 @@ func SetDefaultDisplayNum(cfg *int) {
 }
 
 const (
-	// configPath is the default path to the configuration file
-	configPath = "config.yaml"
+	// defaultConfigPath is the default path to the configuration file
+	defaultConfigPath = "config.yaml"
+
+	// defaultDataDir is the default directory of the data files
+	defaultDataDir = "data"
+
+	// defaultTemplatePath is the default path to the HTML template file
+	defaultTemplatePath = "templates/report.html"
+
+	// logFile is the name of the data file where logs are stored
+	logFile = "ponghub_log.json"
+
+	// reportFile is the name of the HTML report file in the data directory
+	reportFile = "index.html"
+
+	// statusFile is the name of the JSON status file written next to the HTML report
+	statusFile = "status.json"
+
+	// notifyFile is the name of the notification template file
+	notifyFile = "notify.txt"
+
+	// deliveryReportFile is the name of the report of the last notification delivery
+	deliveryReportFile = "delivery_report.json"
 
-	// logPath is the default path to the data file where logs are stored
-	logPath = "data/ponghub_log.json"
+	// outboxFile is the name of the file of the notifications waiting to be retried
+	outboxFile = "notification_outbox.json"
 
-	// reportPath is the default path to the HTML report file
-	reportPath = "data/index.html"
+	// digestStateFile is the name of the file of the time the last digest was sent
+	digestStateFile = "digest_state.json"
 
-	// templatePath is the default path to the HTML template file
-	templatePath = "templates/report.html"
+	// incidentsFile is the name of the file of the open incidents tracked for escalation
+	incidentsFile = "incidents.json"
 
-	// notifyPath is the default path to the notification template file
-	notifyPath = "data/notify.txt"
+	// certAlertsFile is the name of the file of the endpoints notified about their certificates
+	certAlertsFile = "cert_alerts.json"
+
+	// incidentHistoryFile is the name of the file of the incidents derived from the log
+	incidentHistoryFile = "incident_history.json"
+
+	// defaultEnabledFile is the name of the flag file written when the default notification is triggered
+	defaultEnabledFile = "default_enabled.txt"
+
+	// ackFile is the name of the default file listing acknowledged incidents
+	ackFile = "ack.txt"
+
+	// defaultIncidentNotesDir is the default directory of the markdown notes of incidents
+	defaultIncidentNotesDir = "incidents"
+
+	// staticDir is the directory of the stylesheet and images of the report
+	staticDir = "static"
 )
 
-// GetConfigPath returns the default path to the configuration file
+var (
+	// configPath, dataDir, templatePath and incidentNotesDir can be overridden by command-line flags and
+	// environment variables
+	configPath       = defaultConfigPath
+	dataDir          = defaultDataDir
+	templatePath     = defaultTemplatePath
+	incidentNotesDir = defaultIncidentNotesDir
+
+	// reportPath overrides the path to the HTML report file if it is not empty
+	reportPath = ""
+)
+
+// SetConfigPath sets the path to the configuration file, directory or glob pattern, an empty path restores the default
+func SetConfigPath(path string) {
+	configPath = cmp.Or(path, defaultConfigPath)
+}
+
+// SetDataDir sets the directory of the data files, an empty directory restores the default
+func SetDataDir(dir string) {
+	dataDir = cmp.Or(dir, defaultDataDir)
+}
+
+// SetTemplatePath sets the path to the HTML template file, an empty path restores the default
+func SetTemplatePath(path string) {
+	templatePath = cmp.Or(path, defaultTemplatePath)
+}
+
+// SetIncidentNotesDir sets the directory of the markdown notes of incidents, an empty directory restores the default
+func SetIncidentNotesDir(dir string) {
+	incidentNotesDir = cmp.Or(dir, defaultIncidentNotesDir)
+}
+
+// SetReportPath sets the path to the HTML report file, an empty path restores the default in the data directory
+func SetReportPath(path string) {
+	reportPath = path
+}
+
+// GetDefaultConfigPath returns the default path to the configuration file
+func GetDefaultConfigPath() string {
+	return defaultConfigPath
+}
+
+// GetDefaultDataDir returns the default directory of the data files
+func GetDefaultDataDir() string {
+	return defaultDataDir
+}
+
+// GetDefaultTemplatePath returns the default path to the HTML template file
+func GetDefaultTemplatePath() string {
+	return defaultTemplatePath
+}
+
+// GetConfigPath returns the path to the configuration file, directory or glob pattern
 func GetConfigPath() string {
 	return configPath
 }
 
-// GetLogPath returns the default path to the data file where logs are stored
+// GetDataDir returns the directory of the data files
+func GetDataDir() string {
+	return dataDir
+}
+
+// GetLogPath returns the path to the data file where logs are stored
 func GetLogPath() string {
-	return logPath
+	return filepath.Join(dataDir, logFile)
 }
 
-// GetReportPath returns the default path to the HTML report file
+// GetReportPath returns the path to the HTML report file
 func GetReportPath() string {
-	return reportPath
+	if reportPath != "" {
+		return reportPath
+	}
+	return filepath.Join(dataDir, reportFile)
+}
+
+// GetStatusPath returns the path to the JSON status file, which is written next to the HTML report
+func GetStatusPath() string {
+	return filepath.Join(filepath.Dir(GetReportPath()), statusFile)
 }
 
-// GetTemplatePath returns the default path to the HTML template file
+// GetTemplatePath returns the path to the HTML template file
 func GetTemplatePath() string {
 	return templatePath
 }
 
-// GetNotifyPath returns the default path to the notification template file
+// GetNotifyPath returns the path to the notification template file
 func GetNotifyPath() string {
-	return notifyPath
+	return filepath.Join(dataDir, notifyFile)
+}
+
+// GetDeliveryReportPath returns the path to the report of the last notification delivery
+func GetDeliveryReportPath() string {
+	return filepath.Join(dataDir, deliveryReportFile)
+}
+
+// GetOutboxPath returns the path to the notifications waiting to be retried
+func GetOutboxPath() string {
+	return filepath.Join(dataDir, outboxFile)
+}
+
+// GetIncidentsPath returns the path to the open incidents tracked for escalation
+func GetIncidentsPath() string {
+	return filepath.Join(dataDir, incidentsFile)
+}
+
+// GetCertAlertsPath returns the path to the endpoints notified about their certificates until they are renewed
+func GetCertAlertsPath() string {
+	return filepath.Join(dataDir, certAlertsFile)
+}
+
+// GetAckPath returns the default path to the file listing acknowledged incidents
+func GetAckPath() string {
+	return filepath.Join(dataDir, ackFile)
+}
+
+// GetDigestStatePath returns the path to the time the last digest was sent
+func GetDigestStatePath() string {
+	return filepath.Join(dataDir, digestStateFile)
+}
+
+// GetIncidentHistoryPath returns the path to the incidents derived from the log
+func GetIncidentHistoryPath() string {
+	return filepath.Join(dataDir, incidentHistoryFile)
+}
+
+// GetDefaultEnabledPath returns the path to the flag file written when the default notification is triggered
+func GetDefaultEnabledPath() string {
+	return filepath.Join(dataDir, defaultEnabledFile)
+}
+
+// GetDefaultIncidentNotesDir returns the default directory of the markdown notes of incidents
+func GetDefaultIncidentNotesDir() string {
+	return defaultIncidentNotesDir
+}
+
+// GetIncidentNotesDir returns the directory of the markdown notes of incidents
+func GetIncidentNotesDir() string {
+	return incidentNotesDir
+}
+
+// GetStaticDir returns the directory of the stylesheet and images of the report
+func GetStaticDir() string {
+	return staticDir
 }
@@ -0,0 +1,45 @@
+package event_type
+
+type EventType string
+
+const (
+	// OUTAGE represents an endpoint that is unavailable
+	OUTAGE EventType = "outage"
+
+	// RECOVERY represents an endpoint that became available again after an outage
+	RECOVERY EventType = "recovery"
+
+	// CERT_WARNING represents an endpoint whose certificate expires soon
+	CERT_WARNING EventType = "cert_warning"
+
+	// CERT_EXPIRED represents an endpoint whose certificate has expired
+	CERT_EXPIRED EventType = "cert_expired"
+
+	// CERT_RENEWED represents an endpoint whose certificate no longer expires soon after a certificate event
+	CERT_RENEWED EventType = "cert_renewed"
+)
+
+// String returns the string representation of the EventType
+func (et EventType) String() string {
+	return string(et)
+}
+
+// IsProblem checks if the EventType reports a problem rather than a recovery
+func (et EventType) IsProblem() bool {
+	return et == OUTAGE || et == CERT_WARNING || et == CERT_EXPIRED
+}
+
+// IsCert checks if the EventType is a certificate event
+func (et EventType) IsCert() bool {
+	return et == CERT_WARNING || et == CERT_EXPIRED || et == CERT_RENEWED
+}
+
+// ParseEventType parses a string into an EventType, it returns false if the string is not a valid event type
+func ParseEventType(s string) (EventType, bool) {
+	switch et := EventType(s); et {
+	case OUTAGE, RECOVERY, CERT_WARNING, CERT_EXPIRED, CERT_RENEWED:
+		return et, true
+	default:
+		return "", false
+	}
+}